@@ -67,6 +67,22 @@ var createReceiptCommand = cli.Command{
 				"which would allow user to see what he paid for later in" +
 				" the wallet.",
 		},
+		cli.BoolFlag{
+			Name: "route_hints",
+			Usage: "(optional) Works only for lightning invoices. Include " +
+				"route hints for private channels in the invoice.",
+		},
+		cli.BoolFlag{
+			Name: "fallback_address",
+			Usage: "(optional) Works only for lightning invoices. Include " +
+				"on-chain fallback address in the invoice.",
+		},
+		cli.BoolFlag{
+			Name: "description_hash",
+			Usage: "(optional) Works only for lightning invoices. Place " +
+				"hash of the description in the invoice instead of the " +
+				"description itself.",
+		},
 	},
 	Action: createReceipt,
 }
@@ -112,9 +128,13 @@ func createReceipt(ctx *cli.Context) error {
 			asset = crpc.Asset_ETH
 		case "dash":
 			asset = crpc.Asset_DASH
+		case "doge", "dogecoin":
+			asset = crpc.Asset_DOGE
+		case "zec", "zcash":
+			asset = crpc.Asset_ZEC
 		default:
 			return errors.Errorf("invalid asset %v, supported assets"+
-				"are: 'btc', 'bch', 'dash', 'eth', 'ltc'", stringAsset)
+				"are: 'btc', 'bch', 'dash', 'doge', 'eth', 'ltc', 'zec'", stringAsset)
 		}
 	default:
 		return errors.Errorf("asset argument missing")
@@ -130,10 +150,13 @@ func createReceipt(ctx *cli.Context) error {
 
 	ctxb := context.Background()
 	resp, err := client.CreateReceipt(ctxb, &crpc.CreateReceiptRequest{
-		Asset:       asset,
-		Media:       media,
-		Amount:      amount,
-		Description: description,
+		Asset:           asset,
+		Media:           media,
+		Amount:          amount,
+		Description:     description,
+		RouteHints:      ctx.Bool("route_hints"),
+		FallbackAddress: ctx.Bool("fallback_address"),
+		DescriptionHash: ctx.Bool("description_hash"),
 	})
 	if err != nil {
 		return err
@@ -211,9 +234,13 @@ func validateReceipt(ctx *cli.Context) error {
 			asset = crpc.Asset_ETH
 		case "dash":
 			asset = crpc.Asset_DASH
+		case "doge", "dogecoin":
+			asset = crpc.Asset_DOGE
+		case "zec", "zcash":
+			asset = crpc.Asset_ZEC
 		default:
 			return errors.Errorf("invalid asset %v, supported assets"+
-				"are: 'btc', 'bch', 'dash', 'eth', 'ltc'", stringAsset)
+				"are: 'btc', 'bch', 'dash', 'doge', 'eth', 'ltc', 'zec'", stringAsset)
 		}
 	default:
 		return errors.Errorf("asset argument missing")
@@ -299,9 +326,13 @@ func balance(ctx *cli.Context) error {
 			asset = crpc.Asset_ETH
 		case "dash":
 			asset = crpc.Asset_DASH
+		case "doge", "dogecoin":
+			asset = crpc.Asset_DOGE
+		case "zec", "zcash":
+			asset = crpc.Asset_ZEC
 		default:
 			return errors.Errorf("invalid asset %v, supported assets"+
-				"are: 'btc', 'bch', 'dash', 'eth', 'ltc'", stringAsset)
+				"are: 'btc', 'bch', 'dash', 'doge', 'eth', 'ltc', 'zec'", stringAsset)
 		}
 	}
 
@@ -388,9 +419,13 @@ func estimateFee(ctx *cli.Context) error {
 			asset = crpc.Asset_ETH
 		case "dash":
 			asset = crpc.Asset_DASH
+		case "doge", "dogecoin":
+			asset = crpc.Asset_DOGE
+		case "zec", "zcash":
+			asset = crpc.Asset_ZEC
 		default:
 			return errors.Errorf("invalid asset %v, supported assets"+
-				"are: 'btc', 'bch', 'dash', 'eth', 'ltc'", stringAsset)
+				"are: 'btc', 'bch', 'dash', 'doge', 'eth', 'ltc', 'zec'", stringAsset)
 		}
 	default:
 		return errors.Errorf("asset argument missing")
@@ -431,7 +466,8 @@ var sendPaymentCommand = cli.Command{
 		cli.StringFlag{
 			Name: "media",
 			Usage: "Media is a type of technology which is used to transport" +
-				" value of underlying asset",
+				" value of underlying asset, with 'auto' media is chosen" +
+				" depending on the receipt",
 		},
 		cli.StringFlag{
 			Name: "amount",
@@ -443,6 +479,21 @@ var sendPaymentCommand = cli.Command{
 			Usage: "Receipt is either blockchain address or lightning network" +
 				" invoice which identifies the receiver of the payment.",
 		},
+		cli.StringFlag{
+			Name: "max_fee",
+			Usage: "(optional) Absolute maximum fee which might be paid for" +
+				" routing the lightning network payment.",
+		},
+		cli.Int64Flag{
+			Name: "max_fee_percent",
+			Usage: "(optional) Maximum fee which might be paid for routing" +
+				" the lightning network payment, in percents of the amount.",
+		},
+		cli.Int64Flag{
+			Name: "timeout",
+			Usage: "(optional) Timeout in seconds after which we stop" +
+				" waiting for lightning network payment to be sent.",
+		},
 	},
 	Action: sendPayment,
 }
@@ -466,9 +517,11 @@ func sendPayment(ctx *cli.Context) error {
 			media = crpc.Media_BLOCKCHAIN
 		case "li", "lightning":
 			media = crpc.Media_LIGHTNING
+		case "auto":
+			media = crpc.Media_AUTO
 		default:
 			return errors.Errorf("invalid media type %v, support media type "+
-				"are: 'blockchain' and 'lightning'", stringMedia)
+				"are: 'blockchain', 'lightning' and 'auto'", stringMedia)
 		}
 	default:
 		return errors.New("media argument missing")
@@ -488,9 +541,13 @@ func sendPayment(ctx *cli.Context) error {
 			asset = crpc.Asset_ETH
 		case "dash":
 			asset = crpc.Asset_DASH
+		case "doge", "dogecoin":
+			asset = crpc.Asset_DOGE
+		case "zec", "zcash":
+			asset = crpc.Asset_ZEC
 		default:
 			return errors.Errorf("invalid asset %v, supported assets"+
-				"are: 'btc', 'bch', 'dash', 'eth', 'ltc'", stringAsset)
+				"are: 'btc', 'bch', 'dash', 'doge', 'eth', 'ltc', 'zec'", stringAsset)
 		}
 	default:
 		return errors.Errorf("asset argument missing")
@@ -513,10 +570,13 @@ func sendPayment(ctx *cli.Context) error {
 
 	ctxb := context.Background()
 	resp, err := client.SendPayment(ctxb, &crpc.SendPaymentRequest{
-		Asset:   asset,
-		Media:   media,
-		Amount:  amount,
-		Receipt: receipt,
+		Asset:         asset,
+		Media:         media,
+		Amount:        amount,
+		Receipt:       receipt,
+		MaxFee:        ctx.String("max_fee"),
+		MaxFeePercent: ctx.Int64("max_fee_percent"),
+		Timeout:       ctx.Int64("timeout"),
 	})
 	if err != nil {
 		return err
@@ -668,9 +728,13 @@ func listPayments(ctx *cli.Context) error {
 			asset = crpc.Asset_ETH
 		case "dash":
 			asset = crpc.Asset_DASH
+		case "doge", "dogecoin":
+			asset = crpc.Asset_DOGE
+		case "zec", "zcash":
+			asset = crpc.Asset_ZEC
 		default:
 			return errors.Errorf("invalid asset %v, supported assets"+
-				"are: 'btc', 'bch', 'dash', 'eth', 'ltc'", stringAsset)
+				"are: 'btc', 'bch', 'dash', 'doge', 'eth', 'ltc', 'zec'", stringAsset)
 		}
 	}
 
@@ -728,3 +792,407 @@ func listPayments(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var createPayLinkCommand = cli.Command{
+	Name:     "createpaylink",
+	Category: "LNURL",
+	Usage:    "Generates new lnurl-pay link.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "Asset is an acronym of the crypto currency",
+		},
+		cli.StringFlag{
+			Name: "account",
+			Usage: "(optional) Account is the account for which invoices " +
+				"will be created.",
+		},
+	},
+	Action: createPayLink,
+}
+
+func createPayLink(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		asset   crpc.Asset
+		account string
+	)
+
+	switch {
+	case ctx.IsSet("asset"):
+		stringAsset := strings.ToLower(ctx.String("asset"))
+		switch stringAsset {
+		case "btc", "bitcoin":
+			asset = crpc.Asset_BTC
+		case "ltc", "litecoin":
+			asset = crpc.Asset_LTC
+		default:
+			return errors.Errorf("invalid asset %v, supported assets"+
+				"are: 'btc', 'ltc'", stringAsset)
+		}
+	default:
+		return errors.Errorf("asset argument missing")
+	}
+
+	if ctx.IsSet("account") {
+		account = ctx.String("account")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.CreatePayLink(ctxb, &crpc.CreatePayLinkRequest{
+		Asset:   asset,
+		Account: account,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var createWithdrawLinkCommand = cli.Command{
+	Name:     "createwithdrawlink",
+	Category: "LNURL",
+	Usage:    "Generates new one-time lnurl-withdraw link.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "Asset is an acronym of the crypto currency",
+		},
+		cli.StringFlag{
+			Name:  "min_amount",
+			Usage: "(optional) Minimum amount which could be withdrawn.",
+		},
+		cli.StringFlag{
+			Name:  "max_amount",
+			Usage: "Maximum amount which could be withdrawn.",
+		},
+		cli.StringFlag{
+			Name: "description",
+			Usage: "(optional) Description which will be proposed to the " +
+				"user wallet as invoice description.",
+		},
+	},
+	Action: createWithdrawLink,
+}
+
+func createWithdrawLink(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		asset       crpc.Asset
+		minAmount   string
+		maxAmount   string
+		description string
+	)
+
+	switch {
+	case ctx.IsSet("asset"):
+		stringAsset := strings.ToLower(ctx.String("asset"))
+		switch stringAsset {
+		case "btc", "bitcoin":
+			asset = crpc.Asset_BTC
+		case "ltc", "litecoin":
+			asset = crpc.Asset_LTC
+		default:
+			return errors.Errorf("invalid asset %v, supported assets"+
+				"are: 'btc', 'ltc'", stringAsset)
+		}
+	default:
+		return errors.Errorf("asset argument missing")
+	}
+
+	if ctx.IsSet("min_amount") {
+		minAmount = ctx.String("min_amount")
+	}
+
+	if ctx.IsSet("max_amount") {
+		maxAmount = ctx.String("max_amount")
+	} else {
+		return errors.Errorf("max_amount argument is missing")
+	}
+
+	if ctx.IsSet("description") {
+		description = ctx.String("description")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.CreateWithdrawLink(ctxb, &crpc.CreateWithdrawLinkRequest{
+		Asset:       asset,
+		MinAmount:   minAmount,
+		MaxAmount:   maxAmount,
+		Description: description,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var lightningDepositAddressCommand = cli.Command{
+	Name:     "lightningdepositaddress",
+	Category: "Lightning",
+	Usage:    "Generates new address of lightning network daemon on-chain wallet.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "Asset is an acronym of the crypto currency",
+		},
+	},
+	Action: lightningDepositAddress,
+}
+
+func lightningDepositAddress(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var asset crpc.Asset
+
+	switch {
+	case ctx.IsSet("asset"):
+		stringAsset := strings.ToLower(ctx.String("asset"))
+		switch stringAsset {
+		case "btc", "bitcoin":
+			asset = crpc.Asset_BTC
+		case "ltc", "litecoin":
+			asset = crpc.Asset_LTC
+		default:
+			return errors.Errorf("invalid asset %v, supported assets"+
+				"are: 'btc', 'ltc'", stringAsset)
+		}
+	default:
+		return errors.Errorf("asset argument missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.LightningDepositAddress(ctxb,
+		&crpc.LightningDepositAddressRequest{
+			Asset: asset,
+		})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var depositLightningCommand = cli.Command{
+	Name:     "depositlightning",
+	Category: "Lightning",
+	Usage: "Moves funds from blockchain hot wallet in lightning network " +
+		"daemon on-chain wallet.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "Asset is an acronym of the crypto currency",
+		},
+		cli.StringFlag{
+			Name:  "amount",
+			Usage: "Amount is the number of funds which should be moved.",
+		},
+	},
+	Action: depositLightning,
+}
+
+func depositLightning(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		asset  crpc.Asset
+		amount string
+	)
+
+	switch {
+	case ctx.IsSet("asset"):
+		stringAsset := strings.ToLower(ctx.String("asset"))
+		switch stringAsset {
+		case "btc", "bitcoin":
+			asset = crpc.Asset_BTC
+		case "ltc", "litecoin":
+			asset = crpc.Asset_LTC
+		default:
+			return errors.Errorf("invalid asset %v, supported assets"+
+				"are: 'btc', 'ltc'", stringAsset)
+		}
+	default:
+		return errors.Errorf("asset argument missing")
+	}
+
+	if ctx.IsSet("amount") {
+		amount = ctx.String("amount")
+	} else {
+		return errors.Errorf("amount argument is missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.DepositLightning(ctxb, &crpc.DepositLightningRequest{
+		Asset:  asset,
+		Amount: amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var finalizePaymentCommand = cli.Command{
+	Name:     "finalizepayment",
+	Category: "Payment",
+	Usage: "Sends the payment, which is waiting to be signed, with the " +
+		"externally signed transaction",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "ID it is unique identificator of the payment.",
+		},
+		cli.StringFlag{
+			Name: "psbt",
+			Usage: "Psbt is the base64 encoded BIP174 transaction of the " +
+				"payment signed by the external signer.",
+		},
+	},
+	Action: finalizePayment,
+}
+
+func finalizePayment(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var id, psbt string
+
+	if ctx.IsSet("id") {
+		id = ctx.String("id")
+	} else {
+		return errors.Errorf("id argument is missing")
+	}
+
+	if ctx.IsSet("psbt") {
+		psbt = ctx.String("psbt")
+	} else {
+		return errors.Errorf("psbt argument is missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.FinalizePayment(ctxb, &crpc.FinalizePaymentRequest{
+		PaymentId: id,
+		Psbt:      psbt,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var cancelPaymentCommand = cli.Command{
+	Name:     "cancelpayment",
+	Category: "Payment",
+	Usage: "Cancels the payment, which is waiting to be signed, and " +
+		"releases its outputs",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "ID it is unique identificator of the payment.",
+		},
+	},
+	Action: cancelPayment,
+}
+
+func cancelPayment(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var id string
+
+	if ctx.IsSet("id") {
+		id = ctx.String("id")
+	} else {
+		return errors.Errorf("id argument is missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.CancelPayment(ctxb, &crpc.CancelPaymentRequest{
+		PaymentId: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var setSweepPausedCommand = cli.Command{
+	Name:     "setsweeppaused",
+	Category: "Sweep",
+	Usage: "Pauses or resumes automatic sweeping of the hot wallet funds " +
+		"in the cold wallet",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "Asset is an acronym of the crypto currency",
+		},
+		cli.BoolTFlag{
+			Name: "paused",
+			Usage: "Paused denotes whether sweeping should be paused, " +
+				"use --paused=false to resume sweeping.",
+		},
+	},
+	Action: setSweepPaused,
+}
+
+func setSweepPaused(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var asset crpc.Asset
+
+	switch {
+	case ctx.IsSet("asset"):
+		stringAsset := strings.ToLower(ctx.String("asset"))
+		switch stringAsset {
+		case "btc", "bitcoin":
+			asset = crpc.Asset_BTC
+		case "bch", "bitcoincash":
+			asset = crpc.Asset_BCH
+		case "ltc", "litecoin":
+			asset = crpc.Asset_LTC
+		case "eth", "ethereum":
+			asset = crpc.Asset_ETH
+		case "dash":
+			asset = crpc.Asset_DASH
+		case "doge", "dogecoin":
+			asset = crpc.Asset_DOGE
+		case "zec", "zcash":
+			asset = crpc.Asset_ZEC
+		default:
+			return errors.Errorf("invalid asset %v, supported assets"+
+				"are: 'btc', 'bch', 'dash', 'doge', 'eth', 'ltc', 'zec'", stringAsset)
+		}
+	default:
+		return errors.Errorf("asset argument missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.SetSweepPaused(ctxb, &crpc.SetSweepPausedRequest{
+		Asset:  asset,
+		Paused: ctx.BoolT("paused"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
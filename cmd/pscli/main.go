@@ -63,6 +63,13 @@ func main() {
 		paymentByIDCommand,
 		paymentByReceiptCommand,
 		listPaymentsCommand,
+		createPayLinkCommand,
+		createWithdrawLinkCommand,
+		lightningDepositAddressCommand,
+		depositLightningCommand,
+		finalizePaymentCommand,
+		cancelPaymentCommand,
+		setSweepPausedCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
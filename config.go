@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"log"
+	"time"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/go-flags"
@@ -20,6 +21,18 @@ const (
 	defaultPrometheusEndpointHost = "0.0.0.0"
 	defaultPrometheusEndpointPort = "9999"
 
+	defaultLnurlHost            = "0.0.0.0"
+	defaultLnurlPort            = "9003"
+	defaultLnurlMinSendable     = "0.00000001"
+	defaultLnurlMaxSendable     = "0.042"
+	defaultLnurlMaxWithdrawable = "0.042"
+	defaultLnurlWithdrawExpiry  = time.Hour
+
+	defaultLndMaxFeePercent  = 3
+	defaultLndPaymentTimeout = time.Minute
+
+	defaultSweepInterval = 10 * time.Minute
+
 	defaultTLSCertFilename = "server.cert"
 	defaultTLSKeyFilename  = "server.key"
 
@@ -45,6 +58,27 @@ type prometheusConfig struct {
 	Port string `long:"port" description:"The port of the prometheus metrics endpoint, from which metric server is trying to fetch metrics"`
 }
 
+type lnurlConfig struct {
+	Disabled        bool          `long:"disable" description:"Disable lnurl-pay and lnurl-withdraw http endpoints"`
+	Host            string        `long:"host" description:"The host of the lnurl http endpoint"`
+	Port            string        `long:"port" description:"The port of the lnurl http endpoint"`
+	PublicURL       string        `long:"publicurl" description:"The url under which lnurl http endpoint is reachable by lightning wallets"`
+	MinSendable     string        `long:"minsendable" description:"Minimum amount which could be paid with lnurl-pay"`
+	MaxSendable     string        `long:"maxsendable" description:"Maximum amount which could be paid with lnurl-pay"`
+	MaxWithdrawable string        `long:"maxwithdrawable" description:"Maximum amount for which lnurl-withdraw link could be created"`
+	WithdrawExpiry  time.Duration `long:"withdrawexpiry" description:"For how long lnurl-withdraw link could be used"`
+}
+
+type sweepConfig struct {
+	Ceiling   string        `long:"ceiling" description:"Confirmed balance of the hot wallet above which the excess is swept in the cold wallet. If not specified sweeping is disabled"`
+	Target    string        `long:"target" description:"Balance of the hot wallet which is left after sweep"`
+	MinAmount string        `long:"minamount" description:"Minimum amount of the sweep, smaller excess is left in the hot wallet"`
+	Address   string        `long:"address" description:"The cold wallet address on which funds are swept"`
+	XPub      string        `long:"xpub" description:"The cold wallet account level extended public key, if specified every sweep is made on the new address derived from it, supported only by bitcoind daemons"`
+	Interval  time.Duration `long:"interval" description:"How often hot wallet balance is checked"`
+	Paused    bool          `long:"paused" description:"Start with sweeping paused, it could be resumed via RPC"`
+}
+
 // config defines the configuration options for lnd.
 //
 // See loadConfig for further details regarding the configuration
@@ -67,12 +101,17 @@ type config struct {
 
 	Prometheus *prometheusConfig `group:"Prometheus" namespace:"prometheus"`
 
-	Bitcoin          *BitcoindConfig `group:"bitcoin" namespace:"bitcoin"`
-	BitcoinLightning *LndConfig      `group:"bitcoinlightning" namespace:"bitcoinlightning"`
-	BitcoinCash      *BitcoindConfig `group:"bitcoincash" namespace:"bitcoincash"`
-	Litecoin         *BitcoindConfig `group:"litecoin" namespace:"litecoin"`
-	Dash             *BitcoindConfig `group:"dash" namespace:"dash"`
-	Ethereum         *GethConfig     `group:"ethereum" namespace:"ethereum"`
+	Lnurl *lnurlConfig `group:"lnurl" namespace:"lnurl"`
+
+	Bitcoin           *BitcoindConfig `group:"bitcoin" namespace:"bitcoin"`
+	BitcoinLightning  *LndConfig      `group:"bitcoinlightning" namespace:"bitcoinlightning"`
+	BitcoinCash       *BitcoindConfig `group:"bitcoincash" namespace:"bitcoincash"`
+	Litecoin          *BitcoindConfig `group:"litecoin" namespace:"litecoin"`
+	LitecoinLightning *LndConfig      `group:"litecoinlightning" namespace:"litecoinlightning"`
+	Dash              *BitcoindConfig `group:"dash" namespace:"dash"`
+	Dogecoin          *BitcoindConfig `group:"dogecoin" namespace:"dogecoin"`
+	Zcash             *BitcoindConfig `group:"zcash" namespace:"zcash"`
+	Ethereum          *GethConfig     `group:"ethereum" namespace:"ethereum"`
 
 	DataDir string `long:"datadir" description:"Path to data directory"`
 }
@@ -90,6 +129,10 @@ type LndConfig struct {
 
 	// TODO(andrew.shvv) Remove when lnd would return this info
 	PeerHost string `long:"peerhost" description:"Public host of the lnd via which other lightning network nodes could connect"`
+
+	MaxFee         string        `long:"maxfee" description:"Default absolute maximum fee which might be paid for routing the payment, if specified max fee percent is ignored"`
+	MaxFeePercent  int64         `long:"maxfeepercent" description:"Default maximum fee which might be paid for routing the payment, in percents of the payment amount"`
+	PaymentTimeout time.Duration `long:"paymenttimeout" description:"Default time after which we stop waiting for payment to be sent"`
 }
 
 type GethConfig struct {
@@ -101,6 +144,12 @@ type GethConfig struct {
 	Port             int    `long:"port" description:"The port of the lnd daemon"`
 	User             string `long:"user" description:"Part of the credential information needed to connect to the daemon RPC endpoint"`
 	Password         string `long:"password" description:"Part of the credential information needed to connect to the daemon RPC endpoint"`
+	PendingExpiry    time.Duration `long:"pendingexpiry" description:"The time after which pending payment, which transaction is neither in the mempool nor in the blockchain, is marked as failed. If not specified geth transaction pool lifetime is used"`
+	RecoveryInterval time.Duration `long:"recoveryinterval" description:"How often outgoing payments, which transactions have been dropped by the daemon, are rebroadcast, and stale waiting payments are resolved. Check is also done on startup"`
+	WaitingTimeout   time.Duration `long:"waitingtimeout" description:"The time after which payment, which has been created but hasn't been sent, is resolved according to the waiting policy"`
+	WaitingPolicy    string        `long:"waitingpolicy" description:"Whether stale waiting payments are sent or cancelled, inputs of the cancelled payments are released" choice:"broadcast" choice:"cancel"`
+
+	Sweep *sweepConfig `group:"sweep" namespace:"sweep"`
 }
 
 type BitcoindConfig struct {
@@ -113,6 +162,17 @@ type BitcoindConfig struct {
 	Port             int    `long:"port" description:"The port of the lnd daemon"`
 	User             string `long:"user" description:"Part of the credential information needed to connect to the daemon RPC endpoint"`
 	Password         string `long:"password" description:"Part of the credential information needed to connect to the daemon RPC endpoint"`
+	AddressType      string `long:"addresstype" description:"Type of deposit and change addresses, supported only by bitcoin and litecoin daemons. If not specified daemon default is used" choice:"legacy" choice:"p2sh-segwit" choice:"bech32"`
+	ZMQBlockHost     string `long:"zmqblockhost" description:"The host:port of the daemon ZMQ publisher of hashblock or rawblock notifications, if specified sync is triggered on new block"`
+	ZMQTxHost        string `long:"zmqtxhost" description:"The host:port of the daemon ZMQ publisher of rawtx notifications, if specified incoming transactions are recorded as soon as they enter mempool"`
+	XPub             string `long:"xpub" description:"The account level extended public key (xpub, ypub or zpub), if specified deposit addresses are derived from it and imported in the daemon as watch-only, and daemon wallet is used only as hot wallet for withdrawals"`
+	ColdSigning      bool   `long:"coldsigning" description:"Withdrawals are not signed by the daemon, instead unsigned BIP174 transaction is stored in the payment, and payment is sent after being signed externally and finalized, supported only by bitcoin and litecoin daemons"`
+	PendingExpiry    time.Duration `long:"pendingexpiry" description:"The time after which pending payment, which transaction is neither in the mempool nor in the blockchain, is marked as failed. If not specified daemon mempool expiry is used"`
+	RecoveryInterval time.Duration `long:"recoveryinterval" description:"How often outgoing payments, which transactions have been dropped by the daemon, are rebroadcast, and stale waiting payments are resolved. Check is also done on startup"`
+	WaitingTimeout   time.Duration `long:"waitingtimeout" description:"The time after which payment, which has been created but hasn't been sent, is resolved according to the waiting policy"`
+	WaitingPolicy    string        `long:"waitingpolicy" description:"Whether stale waiting payments are sent or cancelled, inputs of the cancelled payments are released" choice:"broadcast" choice:"cancel"`
+
+	Sweep *sweepConfig `group:"sweep" namespace:"sweep"`
 }
 
 // getDefaultConfig return default version of service config.
@@ -134,6 +194,41 @@ func getDefaultConfig() config {
 			Host: defaultPrometheusEndpointHost,
 			Port: defaultPrometheusEndpointPort,
 		},
+
+		BitcoinLightning: &LndConfig{
+			MaxFeePercent:  defaultLndMaxFeePercent,
+			PaymentTimeout: defaultLndPaymentTimeout,
+		},
+
+		// Litecoin lightning network daemon is optional, and in order to
+		// not break existing setups it is disabled by default.
+		LitecoinLightning: &LndConfig{
+			Disabled:       true,
+			MaxFeePercent:  defaultLndMaxFeePercent,
+			PaymentTimeout: defaultLndPaymentTimeout,
+		},
+
+		// Dogecoin daemon is optional, and in order to not break existing
+		// setups it is disabled by default.
+		Dogecoin: &BitcoindConfig{
+			Disabled: true,
+		},
+
+		// Zcash daemon is optional as well, only transparent addresses are
+		// supported.
+		Zcash: &BitcoindConfig{
+			Disabled: true,
+		},
+
+		Lnurl: &lnurlConfig{
+			Disabled:        true,
+			Host:            defaultLnurlHost,
+			Port:            defaultLnurlPort,
+			MinSendable:     defaultLnurlMinSendable,
+			MaxSendable:     defaultLnurlMaxSendable,
+			MaxWithdrawable: defaultLnurlMaxWithdrawable,
+			WithdrawExpiry:  defaultLnurlWithdrawExpiry,
+		},
 	}
 }
 
@@ -0,0 +1,205 @@
+package bitcoin
+
+import (
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/go-errors/errors"
+)
+
+const (
+	// bech32mConst is the constant which bech32m checksum is xored with,
+	// as defined in BIP-350.
+	bech32mConst = 0x2bc830a3
+
+	// bech32Charset is the charset of the bech32 encoded data.
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// taprootWitnessVersion is the witness version of the taproot outputs.
+	taprootWitnessVersion = 1
+
+	// taprootProgramSize is the size of the taproot witness program, which
+	// is the x-only output public key.
+	taprootProgramSize = 32
+)
+
+// AddressTaproot is an address which pays to the witness v1 (taproot)
+// program, encoded with bech32m as defined in BIP-350.
+//
+// NOTE: Implements btcutil.Address interface.
+type AddressTaproot struct {
+	hrp            string
+	witnessProgram [taprootProgramSize]byte
+}
+
+// NewAddressTaproot returns new taproot address for the given witness
+// program.
+func NewAddressTaproot(witnessProgram []byte,
+	netParams *chaincfg.Params) (*AddressTaproot, error) {
+
+	if len(witnessProgram) != taprootProgramSize {
+		return nil, errors.Errorf("witness program must be %v bytes",
+			taprootProgramSize)
+	}
+
+	addr := &AddressTaproot{hrp: netParams.Bech32HRPSegwit}
+	copy(addr.witnessProgram[:], witnessProgram)
+	return addr, nil
+}
+
+// EncodeAddress returns the bech32m encoded address.
+func (a *AddressTaproot) EncodeAddress() string {
+	converted, err := bech32.ConvertBits(a.witnessProgram[:], 8, 5, true)
+	if err != nil {
+		return ""
+	}
+
+	data := append([]byte{taprootWitnessVersion}, converted...)
+	return bech32mEncode(a.hrp, data)
+}
+
+// ScriptAddress returns the witness program of the address.
+func (a *AddressTaproot) ScriptAddress() []byte {
+	return a.witnessProgram[:]
+}
+
+// IsForNet returns whether the address is associated with the passed
+// network.
+func (a *AddressTaproot) IsForNet(netParams *chaincfg.Params) bool {
+	return a.hrp == netParams.Bech32HRPSegwit
+}
+
+// String returns the human-readable encoding of the address.
+func (a *AddressTaproot) String() string {
+	return a.EncodeAddress()
+}
+
+// WitnessVersion returns the witness version of the address.
+func (a *AddressTaproot) WitnessVersion() byte {
+	return taprootWitnessVersion
+}
+
+// WitnessProgram returns the witness program of the address.
+func (a *AddressTaproot) WitnessProgram() []byte {
+	return a.witnessProgram[:]
+}
+
+// isTaprootAddress returns whether address looks like the taproot address
+// of the given network.
+func isTaprootAddress(address string, netParams *chaincfg.Params) bool {
+	prefix := netParams.Bech32HRPSegwit + "1p"
+	return strings.HasPrefix(strings.ToLower(address), prefix)
+}
+
+// decodeTaprootAddress decodes the bech32m encoded witness v1 address.
+func decodeTaprootAddress(address string,
+	netParams *chaincfg.Params) (*AddressTaproot, error) {
+
+	hrp, data, err := bech32mDecode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if hrp != netParams.Bech32HRPSegwit {
+		return nil, errors.New("address is not for specified network")
+	}
+
+	if len(data) == 0 || data[0] != taprootWitnessVersion {
+		return nil, errors.New("unsupported witness version")
+	}
+
+	witnessProgram, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAddressTaproot(witnessProgram, netParams)
+}
+
+// bech32mPolymod calculates the BCH checksum of the values.
+func bech32mPolymod(values []byte) uint32 {
+	generator := [5]uint32{
+		0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3,
+	}
+
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+
+	return chk
+}
+
+// bech32mHrpExpand expands human readable part for the checksum
+// calculation.
+func bech32mHrpExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// bech32mEncode encodes the 5-bit data with bech32m checksum.
+func bech32mEncode(hrp string, data []byte) string {
+	values := append(bech32mHrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32mPolymod(values) ^ bech32mConst
+
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, d := range data {
+		b.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+
+	return b.String()
+}
+
+// bech32mDecode decodes the bech32m string, verifies its checksum and
+// returns human readable part and 5-bit data without the checksum.
+func bech32mDecode(address string) (string, []byte, error) {
+	if len(address) > 90 {
+		return "", nil, errors.New("address is too long")
+	}
+
+	lower := strings.ToLower(address)
+	if address != lower && address != strings.ToUpper(address) {
+		return "", nil, errors.New("address has mixed case")
+	}
+
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || sep+7 > len(lower) {
+		return "", nil, errors.New("invalid separator position")
+	}
+
+	hrp := lower[:sep]
+	data := make([]byte, 0, len(lower)-sep-1)
+	for _, c := range lower[sep+1:] {
+		d := strings.IndexRune(bech32Charset, c)
+		if d == -1 {
+			return "", nil, errors.Errorf("invalid character(%c)", c)
+		}
+		data = append(data, byte(d))
+	}
+
+	if bech32mPolymod(append(bech32mHrpExpand(hrp), data...)) != bech32mConst {
+		return "", nil, errors.New("invalid bech32m checksum")
+	}
+
+	return hrp, data[:len(data)-6], nil
+}
@@ -0,0 +1,41 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestTaprootAddressEncoding(t *testing.T) {
+	address := "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"
+	program := "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"
+
+	decoded, err := decodeTaprootAddress(address, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to decode address: %v", err)
+	}
+
+	if hex.EncodeToString(decoded.ScriptAddress()) != program {
+		t.Fatalf("wrong witness program: %x", decoded.ScriptAddress())
+	}
+
+	if decoded.EncodeAddress() != address {
+		t.Fatalf("wrong encoded address: %v", decoded.EncodeAddress())
+	}
+
+	rawProgram, _ := hex.DecodeString(program)
+	addr, err := NewAddressTaproot(rawProgram, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	if addr.IsForNet(&chaincfg.MainNetParams) {
+		t.Fatalf("testnet address is for mainnet")
+	}
+
+	if _, err := NewAddressTaproot(rawProgram[:20],
+		&chaincfg.MainNetParams); err == nil {
+		t.Fatalf("short witness program shouldn't be accepted")
+	}
+}
@@ -11,6 +11,12 @@ func DecodeAddress(address, netName string) (btcutil.Address, error) {
 		return nil, errors.Errorf("unable to get net params: %v", err)
 	}
 
+	// Taproot addresses are encoded with bech32m, which isn't supported by
+	// btcutil, that is why they are decoded separately.
+	if isTaprootAddress(address, netParams) {
+		return decodeTaprootAddress(address, netParams)
+	}
+
 	decodedAddress, err := btcutil.DecodeAddress(address, netParams)
 	if err != nil {
 		return nil, err
@@ -37,6 +37,36 @@ func TestValidate(t *testing.T) {
 			args:    args{"BTC", "mainnet", "bc1qn6f5cd9rpxtgavsxyk7lgyvgn75mj8tcnxexy2"},
 			wantErr: false,
 		},
+		{
+			name:    "BTC mainnet P2WPKH uppercase",
+			args:    args{"BTC", "mainnet", "BC1QN6F5CD9RPXTGAVSXYK7LGYVGN75MJ8TCNXEXY2"},
+			wantErr: false,
+		},
+		{
+			name:    "BTC mainnet P2WPKH mixed case",
+			args:    args{"BTC", "mainnet", "bc1qn6f5cd9rpxtgavsxyk7lgyvgn75mj8tcNXEXY2"},
+			wantErr: true,
+		},
+		{
+			name:    "BTC mainnet P2TR",
+			args:    args{"BTC", "mainnet", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+			wantErr: false,
+		},
+		{
+			name:    "BTC mainnet P2TR uppercase",
+			args:    args{"BTC", "mainnet", "BC1P5CYXNUXMEUWUVKWFEM96LQZSZD02N6XDCJRS20CAC6YQJJWUDPXQKEDRCR"},
+			wantErr: false,
+		},
+		{
+			name:    "BTC mainnet P2TR bech32 checksum",
+			args:    args{"BTC", "mainnet", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqr9a0ap"},
+			wantErr: true,
+		},
+		{
+			name:    "BTC mainnet P2TR testnet3 address",
+			args:    args{"BTC", "mainnet", "tb1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqp3mvzv"},
+			wantErr: true,
+		},
 		{
 			name:    "BTC mainnet P2SH",
 			args:    args{"BTC", "mainnet", "38xPXRp7AZ9XHCnLycRP8rDEeVMG2GYFMg"},
@@ -119,6 +149,21 @@ func TestValidate(t *testing.T) {
 			args:    args{"BTC", "regtest", "2MzWbbAk8n1esUzQtek3FkoCVrqZRj9kPti"},
 			wantErr: false,
 		},
+		{
+			name:    "BTC regtest P2WPKH",
+			args:    args{"BTC", "regtest", "bcrt1qqv9pzxqlyckngw6zf9g9whn9d3eh4qvg0z9lm9"},
+			wantErr: false,
+		},
+		{
+			name:    "BTC regtest P2WSH",
+			args:    args{"BTC", "regtest", "bcrt1qqyrqkyq4rg0jg2fwxvur6sj8f3g4vkmqv44x7are06pc3rvjj7wq57svkk"},
+			wantErr: false,
+		},
+		{
+			name:    "BTC regtest testnet3 P2WPKH",
+			args:    args{"BTC", "regtest", "tb1qqv9pzxqlyckngw6zf9g9whn9d3eh4qvgdtujvv"},
+			wantErr: true,
+		},
 		{
 			name:    "BTC regtest private WIF uncompressed",
 			args:    args{"BTC", "regtest", "91tjjQ7ygsy3Z8zcEm2FNgvJLx9seH7DL1FR6YEajCHptzT74Ye"},
@@ -188,6 +233,21 @@ func TestValidate(t *testing.T) {
 			args:    args{"BTC", "testnet3", "tb1qn6f5cd9rpxtgavsxyk7lgyvgn75mj8tceqz4le"},
 			wantErr: false,
 		},
+		{
+			name:    "BTC testnet3 P2TR",
+			args:    args{"BTC", "testnet3", "tb1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqp3mvzv"},
+			wantErr: false,
+		},
+		{
+			name:    "BTC testnet3 P2TR bech32 checksum",
+			args:    args{"BTC", "testnet3", "tb1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxq5dtq8w"},
+			wantErr: true,
+		},
+		{
+			name:    "BTC testnet3 P2TR mainnet address",
+			args:    args{"BTC", "testnet3", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+			wantErr: true,
+		},
 		{
 			name:    "BTC testnet3 P2SH",
 			args:    args{"BTC", "testnet3", "2MzWbbAk8n1esUzQtek3FkoCVrqZRj9kPti"},
@@ -265,6 +325,21 @@ func TestValidate(t *testing.T) {
 			args:    args{"BTC", "simnet", "sb1q3588f3ckfhshjhraeufhe8t82yhmy5auzzmklx"},
 			wantErr: true,
 		},
+		{
+			name:    "BTC simnet P2TR",
+			args:    args{"BTC", "simnet", "bcrt1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqvg32hk"},
+			wantErr: false,
+		},
+		{
+			name:    "BTC simnet P2TR bech32 checksum",
+			args:    args{"BTC", "simnet", "bcrt1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqe5pxj5"},
+			wantErr: true,
+		},
+		{
+			name:    "BTC simnet P2TR testnet3 address",
+			args:    args{"BTC", "simnet", "tb1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqp3mvzv"},
+			wantErr: true,
+		},
 		{
 			name:    "BTC simnet P2SH",
 			args:    args{"BTC", "simnet", "rY8j22gBpTbVyp17a3F6eJmGzQRQWLmEcK"},
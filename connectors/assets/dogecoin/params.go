@@ -0,0 +1,94 @@
+package dogecoin
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/go-errors/errors"
+)
+
+// chainIDPrefix is created to distinguish different chains during
+// the process of registration with btcutil mustRegister function.
+//
+// NOTE: This is needed because of the fact how btcutil DecodeAddress works,
+// it couldn't proper decode address if its networks wasn't previously
+// registered.
+var chainIDPrefix wire.BitcoinNet = 5
+
+var (
+	// Mainnet represents the main network.
+	Mainnet = wire.MainNet + chainIDPrefix
+
+	// TestNet represents the regression network.
+	TestNet = wire.TestNet + chainIDPrefix
+
+	// TestNet3 represents the test network.
+	TestNet3 = wire.TestNet3 + chainIDPrefix
+)
+
+var MainNetParams = chaincfg.Params{
+	Net:              Mainnet,
+	Name:             "mainnet",
+	PubKeyHashAddrID: 30,  // addresses start with 'D'
+	ScriptHashAddrID: 22,  // script addresses start with '9' or 'A'
+	PrivateKeyID:     158, // private keys start with '6' or 'Q'
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPublicKeyID:  [4]byte{0x02, 0xfa, 0xca, 0xfd}, // starts with dgub
+	HDPrivateKeyID: [4]byte{0x02, 0xfa, 0xc3, 0x98}, // starts with dgpv
+}
+
+var TestNet3Params = chaincfg.Params{
+	Net:              TestNet3,
+	Name:             "testnet3",
+	PubKeyHashAddrID: 113, // addresses start with 'n'
+	ScriptHashAddrID: 196, // script addresses start with '2'
+	PrivateKeyID:     241, // private keys start with '9' or 'c'
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+}
+
+// RegressionNetParams defines the network parameters for the regression test
+// Dogecoin network. Unlike testnet it uses the Bitcoin testnet address
+// prefixes.
+var RegressionNetParams = chaincfg.Params{
+	Net:              TestNet,
+	Name:             "regtest",
+	PubKeyHashAddrID: 111, // addresses start with 'm' or 'n'
+	ScriptHashAddrID: 196, // script addresses start with '2'
+	PrivateKeyID:     239, // private keys start with '9' or 'c'
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+}
+
+// mustRegister performs the same function as Register except it panics if there
+// is an error.  This should only be called from package init functions.
+func mustRegister(params *chaincfg.Params) {
+	if err := chaincfg.Register(params); err != nil &&
+		err != chaincfg.ErrDuplicateNet {
+		panic("failed to register network: " + err.Error())
+	}
+}
+
+func init() {
+	mustRegister(&MainNetParams)
+	mustRegister(&TestNet3Params)
+	mustRegister(&RegressionNetParams)
+}
+
+func GetParams(netName string) (*chaincfg.Params, error) {
+	switch netName {
+	case "mainnet", "main":
+		return &MainNetParams, nil
+	case "regtest", "simnet":
+		return &RegressionNetParams, nil
+	case "testnet3", "test", "testnet":
+		return &TestNet3Params, nil
+	}
+
+	return nil, errors.Errorf("network '%s' is "+
+		"invalid or unsupported", netName)
+}
@@ -0,0 +1,247 @@
+package dogecoin
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+
+	type args struct {
+		asset string
+		net   string
+		addr  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		// DOGE mainnet
+		{
+			name:    "DOGE mainnet P2PKH uncompressed",
+			args:    args{"DOGE", "mainnet", "DCahkFomSj5FjSMWZJosRzyXiYUTRywCjV"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE mainnet P2PKH compressed",
+			args:    args{"DOGE", "mainnet", "D8yNCnLQxYpqo3hBqV5EwQtS1C6S6PqzKG"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE mainnet P2PKH hybrid",
+			args:    args{"DOGE", "mainnet", "DKKQNZ6cnw9uaHmExbbtbmbR1hdEpVyFUk"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE mainnet P2SH",
+			args:    args{"DOGE", "mainnet", "AAq2ogbhrBf5JuVi1mkPfSQsJmZZVYeUwo"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE mainnet private WIF",
+			args:    args{"DOGE", "mainnet", "6Jd4NdmhfpgbSNi2pgZnawNtqCJCUFd72FtasYiUumWjQ323jYr"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet private WIF compressed",
+			args:    args{"DOGE", "mainnet", "QQn9tVJJWMTkzzp73TdiCo1dmirudHYMaYigW6z6BuUE8XmkyKbA"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet BTC mainnet address",
+			args:    args{"DOGE", "mainnet", "1HDNEqzJWdRkcieyD2AHkPJ2wTDW48BpmM"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet LTC mainnet address",
+			args:    args{"DOGE", "mainnet", "LNfTp5bn61RiCb8AJUEnyJNPqRrqtPAogm"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet DASH mainnet address",
+			args:    args{"DOGE", "mainnet", "XwXafPNkhTBQiRFsu8qZiLNEmsWi9nbTfw"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet ETH address",
+			args:    args{"DOGE", "mainnet", "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet testnet3 address",
+			args:    args{"DOGE", "mainnet", "nfmPvt958d9s48Wi7JnzRdWUyaa3UniNrV"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet regtest address",
+			args:    args{"DOGE", "mainnet", "mfyogxydD4YuwccfsaQxSRnbQvvqkoJsEc"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet random",
+			args:    args{"DOGE", "mainnet", "dGj3h7mvUfYuLGX2LoemYxsMyBQo90qQ20"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE mainnet empty",
+			args:    args{"DOGE", "mainnet", ""},
+			wantErr: true,
+		},
+
+		// DOGE regtest
+		{
+			name:    "DOGE regtest P2PKH uncompressed",
+			args:    args{"DOGE", "regtest", "mfyogxydD4YuwccfsaQxSRnbQvvqkoJsEc"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE regtest P2PKH compressed",
+			args:    args{"DOGE", "regtest", "n14jdP4QSKVsTwztPzFCs5y6ikC7b3bfii"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE regtest P2PKH hybrid",
+			args:    args{"DOGE", "regtest", "mwiuzDuyJT2nfsjQLr6sN9jbVFSfKMC53L"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE regtest P2SH",
+			args:    args{"DOGE", "regtest", "2N5EmPz9xkBFE5iJyMAW8ccFHEBojtAewfJ"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE regtest private WIF",
+			args:    args{"DOGE", "regtest", "93Bu5LrburenmEqLrWcpbsPcnJAyzbTeLy6aMAjRwzqrqLYp9GW"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE regtest private WIF compressed",
+			args:    args{"DOGE", "regtest", "cUf6rAdw6J9nzG4NdXj76uQpvvo4ZD6bq8bcC1xH7kJewuxrYCWx"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE regtest DASH regtest address",
+			args:    args{"DOGE", "regtest", "yhABgLTC8zqV4ABRTz9xkMnb4A15b8zc57"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE regtest ETH address",
+			args:    args{"DOGE", "regtest", "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE regtest mainnet address",
+			args:    args{"DOGE", "regtest", "D8yNCnLQxYpqo3hBqV5EwQtS1C6S6PqzKG"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE regtest testnet3 address",
+			args:    args{"DOGE", "regtest", "neCmPy2ePW7CRPahyFmYD9ze5oTrjX3vd6"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE regtest random",
+			args:    args{"DOGE", "regtest", "dGj3h7mvUfYuLGX2LoemYxsMyBQo90qQ20"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE regtest empty",
+			args:    args{"DOGE", "regtest", ""},
+			wantErr: true,
+		},
+		// false positive
+		{
+			name:    "DOGE regtest BTC regtest address",
+			args:    args{"DOGE", "regtest", "mwjKXu5HKes1Pq8avb8faJWMoSpD3TmeCP"},
+			wantErr: false,
+		},
+
+		// DOGE testnet3
+		{
+			name:    "DOGE testnet3 P2PKH uncompressed",
+			args:    args{"DOGE", "testnet3", "nfmPvt958d9s48Wi7JnzRdWUyaa3UniNrV"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE testnet3 P2PKH compressed",
+			args:    args{"DOGE", "testnet3", "neCmPy2ePW7CRPahyFmYD9ze5oTrjX3vd6"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE testnet3 P2PKH hybrid",
+			args:    args{"DOGE", "testnet3", "nhn8ZN4LRHRkeSDYh5SZz3ntnc4EQ6FPq7"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE testnet3 P2SH",
+			args:    args{"DOGE", "testnet3", "2Mutzwu6DqadvDmAXuBsjo8dp2fxeAcW1uP"},
+			wantErr: false,
+		},
+		{
+			name:    "DOGE testnet3 private WIF",
+			args:    args{"DOGE", "testnet3", "96jsJg9LHS81NmWfEw6NxcPKyPm7J6zJuVh35XxCJUfsfPpBEeQ"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 private WIF compressed",
+			args:    args{"DOGE", "testnet3", "ckLD31mn7Xsvf5TunVkjvTBYjuTgnVRx2hdiB1cct98ac5pE5Zk5"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 BTC testnet3 address",
+			args:    args{"DOGE", "testnet3", "mgG2f5CocNT2bg9hwzuC75mGHZT4tdguXh"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 DASH testnet3 address",
+			args:    args{"DOGE", "testnet3", "yhABgLTC8zqV4ABRTz9xkMnb4A15b8zc57"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 ETH address",
+			args:    args{"DOGE", "testnet3", "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 mainnet address",
+			args:    args{"DOGE", "testnet3", "DCahkFomSj5FjSMWZJosRzyXiYUTRywCjV"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 regtest address",
+			args:    args{"DOGE", "testnet3", "n14jdP4QSKVsTwztPzFCs5y6ikC7b3bfii"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 random",
+			args:    args{"DOGE", "testnet3", "dGj3h7mvUfYuLGX2LoemYxsMyBQo90qQ20"},
+			wantErr: true,
+		},
+		{
+			name:    "DOGE testnet3 empty",
+			args:    args{"DOGE", "testnet3", ""},
+			wantErr: true,
+		},
+		// false positive
+		{
+			name:    "DOGE testnet3 regtest P2SH address",
+			args:    args{"DOGE", "testnet3", "2N5EmPz9xkBFE5iJyMAW8ccFHEBojtAewfJ"},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("unexpected panic: %v", r)
+				}
+			}()
+			var err error
+			if _, err = DecodeAddress(tt.args.addr, tt.args.net);
+				(err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -35,6 +35,11 @@ func TestValidate(t *testing.T) {
 			args:    args{"LTC", "mainnet", "ltc1qupndfjxttgfdtq3k4wzuvyegcdz8uun0t09j0n"},
 			wantErr: false,
 		},
+		{
+			name:    "LTC mainnet P2WPKH uppercase",
+			args:    args{"LTC", "mainnet", "LTC1QUPNDFJXTTGFDTQ3K4WZUVYEGCDZ8UUN0T09J0N"},
+			wantErr: false,
+		},
 		{
 			name:    "LTC mainnet P2SH legacy",
 			args:    args{"LTC", "mainnet", "35fs1NJAvtMvL2EsAzFPwtdyEmQk2LTBHs"},
@@ -0,0 +1,221 @@
+package zcash
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/go-errors/errors"
+)
+
+const (
+	// hashSize is the size of the hash of transparent address.
+	hashSize = 20
+
+	// checksumSize is the size of the base58check checksum.
+	checksumSize = 4
+)
+
+// AddressPubKeyHash is the transparent P2PKH Zcash address. Unlike bitcoin
+// one it is encoded with two-byte version prefix.
+type AddressPubKeyHash struct {
+	hash   [hashSize]byte
+	prefix [2]byte
+	net    wire.BitcoinNet
+}
+
+// A compile time check to ensure AddressPubKeyHash implements the
+// btcutil.Address interface.
+var _ btcutil.Address = (*AddressPubKeyHash)(nil)
+
+// NewAddressPubKeyHash returns new P2PKH address of the given network.
+func NewAddressPubKeyHash(pkHash []byte, netName string) (*AddressPubKeyHash,
+	error) {
+
+	netParams, addrParams, err := getNetParams(netName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkHash) != hashSize {
+		return nil, errors.New("pkHash must be 20 bytes")
+	}
+
+	addr := &AddressPubKeyHash{
+		prefix: addrParams.PubKeyHashAddrID,
+		net:    netParams.Net,
+	}
+	copy(addr.hash[:], pkHash)
+
+	return addr, nil
+}
+
+// EncodeAddress returns the string encoding of the address.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressPubKeyHash) EncodeAddress() string {
+	return encodeAddress(a.hash[:], a.prefix)
+}
+
+// ScriptAddress returns the public key hash.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressPubKeyHash) ScriptAddress() []byte {
+	return a.hash[:]
+}
+
+// IsForNet returns whether the address is associated with the passed
+// network.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressPubKeyHash) IsForNet(net *chaincfg.Params) bool {
+	return a.net == net.Net
+}
+
+// String returns the string encoding of the address.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressPubKeyHash) String() string {
+	return a.EncodeAddress()
+}
+
+// AddressScriptHash is the transparent P2SH Zcash address. Unlike bitcoin
+// one it is encoded with two-byte version prefix.
+type AddressScriptHash struct {
+	hash   [hashSize]byte
+	prefix [2]byte
+	net    wire.BitcoinNet
+}
+
+// A compile time check to ensure AddressScriptHash implements the
+// btcutil.Address interface.
+var _ btcutil.Address = (*AddressScriptHash)(nil)
+
+// NewAddressScriptHashFromHash returns new P2SH address of the given
+// network.
+func NewAddressScriptHashFromHash(scriptHash []byte,
+	netName string) (*AddressScriptHash, error) {
+
+	netParams, addrParams, err := getNetParams(netName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scriptHash) != hashSize {
+		return nil, errors.New("scriptHash must be 20 bytes")
+	}
+
+	addr := &AddressScriptHash{
+		prefix: addrParams.ScriptHashAddrID,
+		net:    netParams.Net,
+	}
+	copy(addr.hash[:], scriptHash)
+
+	return addr, nil
+}
+
+// EncodeAddress returns the string encoding of the address.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressScriptHash) EncodeAddress() string {
+	return encodeAddress(a.hash[:], a.prefix)
+}
+
+// ScriptAddress returns the script hash.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressScriptHash) ScriptAddress() []byte {
+	return a.hash[:]
+}
+
+// IsForNet returns whether the address is associated with the passed
+// network.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressScriptHash) IsForNet(net *chaincfg.Params) bool {
+	return a.net == net.Net
+}
+
+// String returns the string encoding of the address.
+//
+// NOTE: Part of the btcutil.Address interface.
+func (a *AddressScriptHash) String() string {
+	return a.EncodeAddress()
+}
+
+// PayToAddrScript creates public key script paying to the given transparent
+// address.
+func PayToAddrScript(address btcutil.Address) ([]byte, error) {
+	switch addr := address.(type) {
+	case *AddressPubKeyHash:
+		return txscript.NewScriptBuilder().
+			AddOp(txscript.OP_DUP).
+			AddOp(txscript.OP_HASH160).
+			AddData(addr.ScriptAddress()).
+			AddOp(txscript.OP_EQUALVERIFY).
+			AddOp(txscript.OP_CHECKSIG).
+			Script()
+
+	case *AddressScriptHash:
+		return txscript.NewScriptBuilder().
+			AddOp(txscript.OP_HASH160).
+			AddData(addr.ScriptAddress()).
+			AddOp(txscript.OP_EQUAL).
+			Script()
+
+	default:
+		return nil, errors.Errorf("unsupported address type %T", address)
+	}
+}
+
+// encodeAddress encodes the hash with two-byte version prefix using
+// base58check encoding.
+func encodeAddress(hash []byte, prefix [2]byte) string {
+	payload := make([]byte, 0, len(prefix)+len(hash)+checksumSize)
+	payload = append(payload, prefix[:]...)
+	payload = append(payload, hash...)
+
+	checksum := chainhash.DoubleHashB(payload)[:checksumSize]
+	payload = append(payload, checksum...)
+
+	return base58.Encode(payload)
+}
+
+// decodeAddress decodes base58check encoded address with two-byte version
+// prefix, and returns the prefix and the payload.
+func decodeAddress(address string) ([2]byte, []byte, error) {
+	var prefix [2]byte
+
+	decoded := base58.Decode(address)
+	if len(decoded) < len(prefix)+checksumSize {
+		return prefix, nil, errors.New("address is too short")
+	}
+
+	payloadEnd := len(decoded) - checksumSize
+	checksum := chainhash.DoubleHashB(decoded[:payloadEnd])[:checksumSize]
+	if !bytes.Equal(checksum, decoded[payloadEnd:]) {
+		return prefix, nil, errors.New("checksum mismatch")
+	}
+
+	copy(prefix[:], decoded[:len(prefix)])
+	return prefix, decoded[len(prefix):payloadEnd], nil
+}
+
+// getNetParams returns chain and address params of the given network.
+func getNetParams(netName string) (*chaincfg.Params, *AddressParams, error) {
+	netParams, err := GetParams(netName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addrParams, err := GetAddressParams(netName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return netParams, addrParams, nil
+}
@@ -0,0 +1,145 @@
+package zcash
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/go-errors/errors"
+)
+
+// chainIDPrefix is created to distinguish different chains during
+// the process of registration with btcutil mustRegister function.
+//
+// NOTE: Zcash addresses are not decoded by btcutil, but networks are still
+// registered, so that they wouldn't collide with the networks of other
+// assets.
+var chainIDPrefix wire.BitcoinNet = 6
+
+var (
+	// Mainnet represents the main network.
+	Mainnet = wire.MainNet + chainIDPrefix
+
+	// TestNet represents the regression network.
+	TestNet = wire.TestNet + chainIDPrefix
+
+	// TestNet3 represents the test network.
+	TestNet3 = wire.TestNet3 + chainIDPrefix
+)
+
+// AddressParams defines the two-byte version prefixes of the Zcash
+// addresses, which couldn't be expressed with chaincfg.Params.
+type AddressParams struct {
+	// PubKeyHashAddrID is the prefix of transparent P2PKH address.
+	PubKeyHashAddrID [2]byte
+
+	// ScriptHashAddrID is the prefix of transparent P2SH address.
+	ScriptHashAddrID [2]byte
+
+	// SproutAddrID is the prefix of shielded Sprout address.
+	SproutAddrID [2]byte
+
+	// SaplingHRP is the human readable part of shielded Sapling address.
+	SaplingHRP string
+
+	// UnifiedHRP is the human readable part of unified address.
+	UnifiedHRP string
+}
+
+var MainNetParams = chaincfg.Params{
+	Net:          Mainnet,
+	Name:         "mainnet",
+	PrivateKeyID: 128, // private keys start with '5', 'K' or 'L'
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPublicKeyID:  [4]byte{0x04, 0x88, 0xb2, 0x1e}, // starts with xpub
+	HDPrivateKeyID: [4]byte{0x04, 0x88, 0xad, 0xe4}, // starts with xprv
+}
+
+var MainNetAddressParams = AddressParams{
+	PubKeyHashAddrID: [2]byte{0x1c, 0xb8}, // addresses start with 't1'
+	ScriptHashAddrID: [2]byte{0x1c, 0xbd}, // script addresses start with 't3'
+	SproutAddrID:     [2]byte{0x16, 0x9a}, // addresses start with 'zc'
+	SaplingHRP:       "zs",
+	UnifiedHRP:       "u",
+}
+
+var TestNet3Params = chaincfg.Params{
+	Net:          TestNet3,
+	Name:         "testnet3",
+	PrivateKeyID: 239, // private keys start with '9' or 'c'
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+}
+
+var TestNet3AddressParams = AddressParams{
+	PubKeyHashAddrID: [2]byte{0x1d, 0x25}, // addresses start with 'tm'
+	ScriptHashAddrID: [2]byte{0x1c, 0xba}, // script addresses start with 't2'
+	SproutAddrID:     [2]byte{0x16, 0xb6}, // addresses start with 'zt'
+	SaplingHRP:       "ztestsapling",
+	UnifiedHRP:       "utest",
+}
+
+// RegressionNetParams defines the network parameters for the regression test
+// Zcash network. Transparent addresses have the same prefixes as in testnet.
+var RegressionNetParams = chaincfg.Params{
+	Net:          TestNet,
+	Name:         "regtest",
+	PrivateKeyID: 239, // private keys start with '9' or 'c'
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+}
+
+var RegressionNetAddressParams = AddressParams{
+	PubKeyHashAddrID: [2]byte{0x1d, 0x25}, // addresses start with 'tm'
+	ScriptHashAddrID: [2]byte{0x1c, 0xba}, // script addresses start with 't2'
+	SproutAddrID:     [2]byte{0x16, 0xb6}, // addresses start with 'zt'
+	SaplingHRP:       "zregtestsapling",
+	UnifiedHRP:       "uregtest",
+}
+
+// mustRegister performs the same function as Register except it panics if there
+// is an error.  This should only be called from package init functions.
+func mustRegister(params *chaincfg.Params) {
+	if err := chaincfg.Register(params); err != nil &&
+		err != chaincfg.ErrDuplicateNet {
+		panic("failed to register network: " + err.Error())
+	}
+}
+
+func init() {
+	mustRegister(&MainNetParams)
+	mustRegister(&TestNet3Params)
+	mustRegister(&RegressionNetParams)
+}
+
+func GetParams(netName string) (*chaincfg.Params, error) {
+	switch netName {
+	case "mainnet", "main":
+		return &MainNetParams, nil
+	case "regtest", "simnet":
+		return &RegressionNetParams, nil
+	case "testnet3", "test", "testnet":
+		return &TestNet3Params, nil
+	}
+
+	return nil, errors.Errorf("network '%s' is "+
+		"invalid or unsupported", netName)
+}
+
+// GetAddressParams returns address prefixes of the given network.
+func GetAddressParams(netName string) (*AddressParams, error) {
+	switch netName {
+	case "mainnet", "main":
+		return &MainNetAddressParams, nil
+	case "regtest", "simnet":
+		return &RegressionNetAddressParams, nil
+	case "testnet3", "test", "testnet":
+		return &TestNet3AddressParams, nil
+	}
+
+	return nil, errors.Errorf("network '%s' is "+
+		"invalid or unsupported", netName)
+}
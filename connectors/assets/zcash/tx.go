@@ -0,0 +1,407 @@
+package zcash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+)
+
+const (
+	// SaplingTxVersion is the version of the transactions created since
+	// Sapling network upgrade.
+	SaplingTxVersion = 4
+
+	// SaplingVersionGroupID is the version group id of Sapling
+	// transactions.
+	SaplingVersionGroupID = 0x892f2085
+
+	// overwinteredFlag is the flag of the transaction header, which is set
+	// in all transactions created since Overwinter network upgrade.
+	overwinteredFlag = 1 << 31
+)
+
+var (
+	// sigHashPersonalization is the prefix of personalization of the
+	// signature hash, the rest of it is the consensus branch id.
+	sigHashPersonalization = []byte("ZcashSigHash")
+
+	prevoutsHashPersonalization = personalization("ZcashPrevoutHash")
+	sequenceHashPersonalization = personalization("ZcashSequencHash")
+	outputsHashPersonalization  = personalization("ZcashOutputsHash")
+)
+
+// Tx is the Zcash transaction of Sapling format. Only transparent
+// transactions are supported, i.e. without shielded spends, outputs and
+// joinsplits.
+type Tx struct {
+	TxIn         []*wire.TxIn
+	TxOut        []*wire.TxOut
+	LockTime     uint32
+	ExpiryHeight uint32
+}
+
+// NewTx creates Zcash transaction with the transparent inputs and outputs of
+// the given transaction, which couldn't be mined after the expiry height.
+func NewTx(tx *wire.MsgTx, expiryHeight uint32) *Tx {
+	return &Tx{
+		TxIn:         tx.TxIn,
+		TxOut:        tx.TxOut,
+		LockTime:     tx.LockTime,
+		ExpiryHeight: expiryHeight,
+	}
+}
+
+// MsgTx returns the transparent part of the transaction as bitcoin
+// transaction, it shouldn't be serialized, and is used only to access
+// inputs and outputs.
+func (tx *Tx) MsgTx() *wire.MsgTx {
+	return &wire.MsgTx{
+		Version:  SaplingTxVersion,
+		TxIn:     tx.TxIn,
+		TxOut:    tx.TxOut,
+		LockTime: tx.LockTime,
+	}
+}
+
+// TxHash returns the id of the transaction.
+func (tx *Tx) TxHash() chainhash.Hash {
+	var buf bytes.Buffer
+	_ = tx.Serialize(&buf)
+	return chainhash.DoubleHashH(buf.Bytes())
+}
+
+// Serialize encodes the transaction in Sapling format.
+func (tx *Tx) Serialize(w io.Writer) error {
+	if err := writeUint32(w, SaplingTxVersion|overwinteredFlag); err != nil {
+		return err
+	}
+
+	if err := writeUint32(w, SaplingVersionGroupID); err != nil {
+		return err
+	}
+
+	if err := wire.WriteVarInt(w, 0, uint64(len(tx.TxIn))); err != nil {
+		return err
+	}
+
+	for _, txIn := range tx.TxIn {
+		if err := writeOutPoint(w, &txIn.PreviousOutPoint); err != nil {
+			return err
+		}
+
+		if err := wire.WriteVarBytes(w, 0, txIn.SignatureScript); err != nil {
+			return err
+		}
+
+		if err := writeUint32(w, txIn.Sequence); err != nil {
+			return err
+		}
+	}
+
+	if err := wire.WriteVarInt(w, 0, uint64(len(tx.TxOut))); err != nil {
+		return err
+	}
+
+	for _, txOut := range tx.TxOut {
+		if err := wire.WriteTxOut(w, 0, 0, txOut); err != nil {
+			return err
+		}
+	}
+
+	if err := writeUint32(w, tx.LockTime); err != nil {
+		return err
+	}
+
+	if err := writeUint32(w, tx.ExpiryHeight); err != nil {
+		return err
+	}
+
+	// Value balance, and number of shielded spends, shielded outputs and
+	// joinsplits are always zero for transparent transaction.
+	_, err := w.Write(make([]byte, 8+1+1+1))
+	return err
+}
+
+// Deserialize decodes the transaction of Sapling format.
+func (tx *Tx) Deserialize(r io.Reader) error {
+	header, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+
+	if header != SaplingTxVersion|overwinteredFlag {
+		return errors.Errorf("unsupported transaction header(%x)", header)
+	}
+
+	versionGroupID, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+
+	if versionGroupID != SaplingVersionGroupID {
+		return errors.Errorf("unsupported version group id(%x)",
+			versionGroupID)
+	}
+
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return err
+	}
+
+	tx.TxIn = make([]*wire.TxIn, count)
+	for i := range tx.TxIn {
+		txIn := &wire.TxIn{}
+		if err := readOutPoint(r, &txIn.PreviousOutPoint); err != nil {
+			return err
+		}
+
+		txIn.SignatureScript, err = wire.ReadVarBytes(r, 0,
+			wire.MaxMessagePayload, "signature script")
+		if err != nil {
+			return err
+		}
+
+		if txIn.Sequence, err = readUint32(r); err != nil {
+			return err
+		}
+
+		tx.TxIn[i] = txIn
+	}
+
+	count, err = wire.ReadVarInt(r, 0)
+	if err != nil {
+		return err
+	}
+
+	tx.TxOut = make([]*wire.TxOut, count)
+	for i := range tx.TxOut {
+		var value [8]byte
+		if _, err := io.ReadFull(r, value[:]); err != nil {
+			return err
+		}
+
+		pkScript, err := wire.ReadVarBytes(r, 0, wire.MaxMessagePayload,
+			"public key script")
+		if err != nil {
+			return err
+		}
+
+		tx.TxOut[i] = wire.NewTxOut(
+			int64(binary.LittleEndian.Uint64(value[:])), pkScript)
+	}
+
+	if tx.LockTime, err = readUint32(r); err != nil {
+		return err
+	}
+
+	if tx.ExpiryHeight, err = readUint32(r); err != nil {
+		return err
+	}
+
+	var valueBalance [8]byte
+	if _, err := io.ReadFull(r, valueBalance[:]); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"shielded spends", "shielded outputs",
+		"joinsplits"} {
+
+		count, err := wire.ReadVarInt(r, 0)
+		if err != nil {
+			return err
+		}
+
+		if count != 0 {
+			return errors.Errorf("transactions with %v are not "+
+				"supported", name)
+		}
+	}
+
+	return nil
+}
+
+// SignatureHash returns the hash of the transaction which is signed by the
+// given input as described in ZIP 243. Consensus branch id of the network
+// upgrade, at which transaction will be mined, is committed to the hash, so
+// that transaction signed for one upgrade is invalid in the others.
+func (tx *Tx) SignatureHash(script []byte, idx int, amount int64,
+	hashType txscript.SigHashType, branchID uint32) ([]byte, error) {
+
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return nil, errors.Errorf("input index %v is out of range", idx)
+	}
+
+	anyoneCanPay := hashType&txscript.SigHashAnyOneCanPay != 0
+	baseType := hashType & 0x1f
+
+	var hashPrevouts, hashSequence, hashOutputs [blake2bSize]byte
+
+	if !anyoneCanPay {
+		var buf bytes.Buffer
+		for _, txIn := range tx.TxIn {
+			writeOutPoint(&buf, &txIn.PreviousOutPoint)
+		}
+		hashPrevouts = blake2b256(prevoutsHashPersonalization, buf.Bytes())
+	}
+
+	if !anyoneCanPay && baseType != txscript.SigHashSingle &&
+		baseType != txscript.SigHashNone {
+
+		var buf bytes.Buffer
+		for _, txIn := range tx.TxIn {
+			writeUint32(&buf, txIn.Sequence)
+		}
+		hashSequence = blake2b256(sequenceHashPersonalization, buf.Bytes())
+	}
+
+	if baseType != txscript.SigHashSingle &&
+		baseType != txscript.SigHashNone {
+
+		var buf bytes.Buffer
+		for _, txOut := range tx.TxOut {
+			wire.WriteTxOut(&buf, 0, 0, txOut)
+		}
+		hashOutputs = blake2b256(outputsHashPersonalization, buf.Bytes())
+
+	} else if baseType == txscript.SigHashSingle && idx < len(tx.TxOut) {
+		var buf bytes.Buffer
+		wire.WriteTxOut(&buf, 0, 0, tx.TxOut[idx])
+		hashOutputs = blake2b256(outputsHashPersonalization, buf.Bytes())
+	}
+
+	var preimage bytes.Buffer
+	writeUint32(&preimage, SaplingTxVersion|overwinteredFlag)
+	writeUint32(&preimage, SaplingVersionGroupID)
+	preimage.Write(hashPrevouts[:])
+	preimage.Write(hashSequence[:])
+	preimage.Write(hashOutputs[:])
+
+	// Hashes of joinsplits, shielded spends and shielded outputs are
+	// zero for transparent transaction.
+	preimage.Write(make([]byte, 3*blake2bSize))
+
+	writeUint32(&preimage, tx.LockTime)
+	writeUint32(&preimage, tx.ExpiryHeight)
+
+	// Value balance is zero for transparent transaction.
+	preimage.Write(make([]byte, 8))
+
+	writeUint32(&preimage, uint32(hashType))
+
+	txIn := tx.TxIn[idx]
+	writeOutPoint(&preimage, &txIn.PreviousOutPoint)
+	wire.WriteVarBytes(&preimage, 0, script)
+
+	var value [8]byte
+	binary.LittleEndian.PutUint64(value[:], uint64(amount))
+	preimage.Write(value[:])
+
+	writeUint32(&preimage, txIn.Sequence)
+
+	var sigHashPersonal [16]byte
+	copy(sigHashPersonal[:], sigHashPersonalization)
+	binary.LittleEndian.PutUint32(sigHashPersonal[12:], branchID)
+
+	hash := blake2b256(sigHashPersonal, preimage.Bytes())
+	return hash[:], nil
+}
+
+// VerifyInput ensures that the input spending P2PKH output has valid
+// signature for the given consensus branch id.
+func (tx *Tx) VerifyInput(idx int, pkScript []byte, amount int64,
+	branchID uint32) error {
+
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return errors.Errorf("input index %v is out of range", idx)
+	}
+
+	if txscript.GetScriptClass(pkScript) != txscript.PubKeyHashTy {
+		return errors.New("only P2PKH inputs are supported")
+	}
+
+	pushes, err := txscript.PushedData(tx.TxIn[idx].SignatureScript)
+	if err != nil {
+		return errors.Errorf("unable to parse signature script: %v", err)
+	}
+
+	if len(pushes) != 2 || len(pushes[0]) == 0 {
+		return errors.New("signature script should contain signature " +
+			"and public key")
+	}
+
+	rawSig, rawPubKey := pushes[0], pushes[1]
+	hashType := txscript.SigHashType(rawSig[len(rawSig)-1])
+
+	sig, err := btcec.ParseDERSignature(rawSig[:len(rawSig)-1], btcec.S256())
+	if err != nil {
+		return errors.Errorf("unable to parse signature: %v", err)
+	}
+
+	pubKey, err := btcec.ParsePubKey(rawPubKey, btcec.S256())
+	if err != nil {
+		return errors.Errorf("unable to parse public key: %v", err)
+	}
+
+	// Public key script of P2PKH output is 'OP_DUP OP_HASH160 <hash>
+	// OP_EQUALVERIFY OP_CHECKSIG'.
+	if !bytes.Equal(btcutil.Hash160(rawPubKey), pkScript[3:23]) {
+		return errors.New("public key doesn't match the spent output")
+	}
+
+	hash, err := tx.SignatureHash(pkScript, idx, amount, hashType, branchID)
+	if err != nil {
+		return err
+	}
+
+	if !sig.Verify(hash, pubKey) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
+
+// personalization converts the string to the BLAKE2b personalization.
+func personalization(s string) [16]byte {
+	var p [16]byte
+	copy(p[:], s)
+	return p
+}
+
+func writeUint32(w io.Writer, v uint32) error {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	_, err := w.Write(b[:])
+	return err
+}
+
+func readUint32(r io.Reader) (uint32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b[:]), nil
+}
+
+func writeOutPoint(w io.Writer, op *wire.OutPoint) error {
+	if _, err := w.Write(op.Hash[:]); err != nil {
+		return err
+	}
+	return writeUint32(w, op.Index)
+}
+
+func readOutPoint(r io.Reader, op *wire.OutPoint) error {
+	if _, err := io.ReadFull(r, op.Hash[:]); err != nil {
+		return err
+	}
+
+	var err error
+	op.Index, err = readUint32(r)
+	return err
+}
@@ -0,0 +1,235 @@
+package zcash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+const (
+	saplingBranchID = 0x76b809bb
+	nu5BranchID     = 0xc2d6d0b4
+)
+
+func TestBlake2b256(t *testing.T) {
+	// Expected digests are obtained with the reference implementation.
+	tests := []struct {
+		size   int
+		digest string
+	}{
+		{0, "d53a633bbecf82fe9e9484d8a0e727c73bb9e68c96e72dec30144f6a84afa136"},
+		{3, "02898941978164da311de6ddfff3b35b390be48582d7849af7e821a53674cb1a"},
+		{128, "97acf9a7b2989a94d5856bcdef6d8518674a7c586c916213c9f184f220466818"},
+		{129, "6ca06ba2d3ff381180a190424859fccbe6f277b959c8189dda6eecca5b63520d"},
+		{300, "18dd22529ca4dd5f37f9a6d8066168ddec72e0ec159b247aa275e3071b585fcd"},
+	}
+
+	for _, test := range tests {
+		data := make([]byte, test.size)
+		for i := range data {
+			data[i] = byte(i % 251)
+		}
+
+		digest := blake2b256(prevoutsHashPersonalization, data)
+		if hex.EncodeToString(digest[:]) != test.digest {
+			t.Fatalf("wrong digest of %v bytes: %x", test.size, digest)
+		}
+	}
+
+	// Without personalization digest should be the same as the ordinary
+	// BLAKE2b-256 one.
+	digest := blake2b256([16]byte{}, []byte("abc"))
+	if hex.EncodeToString(digest[:]) != "bddd813c634239723171ef3fee98579b"+
+		"94964e3bb1cb3e427262c8c068d52319" {
+		t.Fatalf("wrong digest without personalization: %x", digest)
+	}
+}
+
+// testTx returns transparent transaction with two inputs and two outputs.
+func testTx(t *testing.T) (*Tx, []byte) {
+	pkHash := sha256.Sum256([]byte("zec"))
+	pkAddress, err := NewAddressPubKeyHash(pkHash[:20], "mainnet")
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	scriptHash := sha256.Sum256([]byte("zec script"))
+	scriptAddress, err := NewAddressScriptHashFromHash(scriptHash[:20],
+		"mainnet")
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	pkScript, err := PayToAddrScript(pkAddress)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	scriptHashScript, err := PayToAddrScript(scriptAddress)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+
+	hash := chainhash.Hash(sha256.Sum256([]byte("in0")))
+	msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&hash, 1), nil, nil))
+
+	hash = chainhash.Hash(sha256.Sum256([]byte("in1")))
+	txIn := wire.NewTxIn(wire.NewOutPoint(&hash, 0), nil, nil)
+	txIn.Sequence = wire.MaxTxInSequenceNum - 1
+	msgTx.AddTxIn(txIn)
+
+	msgTx.AddTxOut(wire.NewTxOut(100000, pkScript))
+	msgTx.AddTxOut(wire.NewTxOut(50000, scriptHashScript))
+
+	return NewTx(msgTx, 1000), pkScript
+}
+
+func TestTxSerialize(t *testing.T) {
+	tx, _ := testTx(t)
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+
+	rawTx := "0400008085202f89021ca4b3296f0b31b9f584e06c4dfc59119ecc408ba0" +
+		"395047c8d616f4c88a20c60100000000ffffffff87dac51506d06652be0211" +
+		"0bd1e34c2156faa5fa37253dc885da93b840b4bdec0000000000feffffff02" +
+		"a0860100000000001976a9144092503716f23af46b012c27a434e2e0416087" +
+		"dc88ac50c300000000000017a914c0c66fc814da1ce405fb326ba432b388aa" +
+		"5d408d8700000000e80300000000000000000000000000"
+	if hex.EncodeToString(buf.Bytes()) != rawTx {
+		t.Fatalf("wrong serialized tx: %x", buf.Bytes())
+	}
+
+	txID := "ad3ce467ee06d64639cfa97c8a2621b9b15773ddf230652d30af7ea2bb775656"
+	if tx.TxHash().String() != txID {
+		t.Fatalf("wrong tx id: %v", tx.TxHash())
+	}
+
+	decoded := &Tx{}
+	if err := decoded.Deserialize(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("unable to deserialize tx: %v", err)
+	}
+
+	if decoded.TxHash() != tx.TxHash() {
+		t.Fatalf("wrong deserialized tx: %v", decoded.TxHash())
+	}
+
+	// Bitcoin transaction shouldn't be decoded as Zcash one.
+	var btcTx bytes.Buffer
+	if err := tx.MsgTx().Serialize(&btcTx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+
+	if err := decoded.Deserialize(&btcTx); err == nil {
+		t.Fatalf("bitcoin tx is decoded")
+	}
+}
+
+func TestSignatureHash(t *testing.T) {
+	tx, pkScript := testTx(t)
+
+	// Expected hashes are obtained with the reference implementation of
+	// ZIP 243.
+	tests := []struct {
+		idx      int
+		amount   int64
+		hashType txscript.SigHashType
+		branchID uint32
+		hash     string
+	}{
+		{
+			idx:      0,
+			amount:   200000,
+			hashType: txscript.SigHashAll,
+			branchID: saplingBranchID,
+			hash:     "cf516669bf6cae219c44916f0a202ebad1800f9fa16fa2d45e5b77d706752c19",
+		},
+		{
+			idx:      1,
+			amount:   300000,
+			hashType: txscript.SigHashAll,
+			branchID: nu5BranchID,
+			hash:     "096d0ad69292daf4279b3cee263236ebcb21661d0242d731141e7f5a03a4fdc1",
+		},
+		{
+			idx:      1,
+			amount:   300000,
+			hashType: txscript.SigHashSingle | txscript.SigHashAnyOneCanPay,
+			branchID: saplingBranchID,
+			hash:     "57f90787689a885cfbc7c8ea3cd1f8428262a8884070b0a2044feccca41fc5f3",
+		},
+	}
+
+	for i, test := range tests {
+		hash, err := tx.SignatureHash(pkScript, test.idx, test.amount,
+			test.hashType, test.branchID)
+		if err != nil {
+			t.Fatalf("(%v) unable to get signature hash: %v", i, err)
+		}
+
+		if hex.EncodeToString(hash) != test.hash {
+			t.Fatalf("(%v) wrong signature hash: %x", i, hash)
+		}
+	}
+}
+
+func TestVerifyInput(t *testing.T) {
+	tx, _ := testTx(t)
+
+	seed := sha256.Sum256([]byte("zec key"))
+	privKey, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), seed[:])
+
+	address, err := NewAddressPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()), "mainnet")
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	pkScript, err := PayToAddrScript(address)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	hash, err := tx.SignatureHash(pkScript, 0, 200000, txscript.SigHashAll,
+		nu5BranchID)
+	if err != nil {
+		t.Fatalf("unable to get signature hash: %v", err)
+	}
+
+	sig, err := privKey.Sign(hash)
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+
+	tx.TxIn[0].SignatureScript, err = txscript.NewScriptBuilder().
+		AddData(append(sig.Serialize(), byte(txscript.SigHashAll))).
+		AddData(pubKey.SerializeCompressed()).
+		Script()
+	if err != nil {
+		t.Fatalf("unable to create signature script: %v", err)
+	}
+
+	if err := tx.VerifyInput(0, pkScript, 200000, nu5BranchID); err != nil {
+		t.Fatalf("valid signature is rejected: %v", err)
+	}
+
+	// Signature made for one network upgrade is invalid in the others.
+	if err := tx.VerifyInput(0, pkScript, 200000, saplingBranchID); err == nil {
+		t.Fatalf("signature is valid for the wrong branch id")
+	}
+
+	if err := tx.VerifyInput(0, pkScript, 100000, nu5BranchID); err == nil {
+		t.Fatalf("signature is valid for the wrong amount")
+	}
+}
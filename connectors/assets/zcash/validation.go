@@ -0,0 +1,67 @@
+package zcash
+
+import (
+	"strings"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+)
+
+// ErrShieldedAddress is returned if shielded or unified address is given,
+// only transparent addresses are supported.
+var ErrShieldedAddress = errors.New("shielded addresses are not " +
+	"supported, only transparent ones")
+
+// DecodeAddress ensures that address is valid transparent address and
+// belongs to the given network, returns decoded address.
+func DecodeAddress(address, netName string) (btcutil.Address, error) {
+	_, addrParams, err := getNetParams(netName)
+	if err != nil {
+		return nil, errors.Errorf("unable  to get net params: %v", err)
+	}
+
+	if isShielded(address) {
+		return nil, ErrShieldedAddress
+	}
+
+	prefix, hash, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	switch prefix {
+	case addrParams.PubKeyHashAddrID:
+		return NewAddressPubKeyHash(hash, netName)
+	case addrParams.ScriptHashAddrID:
+		return NewAddressScriptHashFromHash(hash, netName)
+	default:
+		return nil, errors.New("address is not for specified network")
+	}
+}
+
+// isShielded returns whether the address is shielded Sprout or Sapling
+// address or unified address of any network.
+func isShielded(address string) bool {
+	allParams := []*AddressParams{
+		&MainNetAddressParams,
+		&TestNet3AddressParams,
+		&RegressionNetAddressParams,
+	}
+
+	lowered := strings.ToLower(address)
+	for _, params := range allParams {
+		// Bech32 and bech32m encoded addresses have '1' separator after
+		// the human readable part.
+		if strings.HasPrefix(lowered, params.SaplingHRP+"1") ||
+			strings.HasPrefix(lowered, params.UnifiedHRP+"1") {
+			return true
+		}
+
+		prefix, _, err := decodeAddress(address)
+		if err == nil && prefix == params.SproutAddrID {
+			return true
+		}
+	}
+
+	return false
+}
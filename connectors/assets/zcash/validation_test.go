@@ -0,0 +1,185 @@
+package zcash
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+
+	type args struct {
+		asset string
+		net   string
+		addr  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		// ZEC mainnet
+		{
+			name:    "ZEC mainnet P2PKH",
+			args:    args{"ZEC", "mainnet", "t1Pm2XeBSm2fNCZerFCe5wTLrhJtgMaL1mM"},
+			wantErr: false,
+		},
+		{
+			name:    "ZEC mainnet P2SH",
+			args:    args{"ZEC", "mainnet", "t3c8vPL5zEGyCkc7THDJctxm8eWctwaFKTW"},
+			wantErr: false,
+		},
+		{
+			name:    "ZEC mainnet Sapling address",
+			args:    args{"ZEC", "mainnet", "zs1z7rejlpsa98s2rrrfkwmaxu53e4ue0ulcrw0h4x5g8jl04tak0d3mm47vdtahatqrlkngh9slya"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC mainnet Sprout address",
+			args:    args{"ZEC", "mainnet", "zcMRpNy15Npn6Bjg6wXg4PN8GssjAm5NhTMZZoQS1r9F6fEvpd7tAhhxBujYBWCWkMVRhEFJMGf5XkfbdXyPCP2PKCFmTc2"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC mainnet BTC mainnet address",
+			args:    args{"ZEC", "mainnet", "16tRXJmJnhsmbvbxJmpxoeEwSehbU74mwD"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC mainnet ETH address",
+			args:    args{"ZEC", "mainnet", "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC mainnet testnet3 address",
+			args:    args{"ZEC", "mainnet", "tmFbmrUfr9hAsLorHuvwpo81cJHyVrGon5c"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC mainnet random",
+			args:    args{"ZEC", "mainnet", "dGj3h7mvUfYuLGX2LoemYxsMyBQo90qQ20"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC mainnet empty",
+			args:    args{"ZEC", "mainnet", ""},
+			wantErr: true,
+		},
+
+		// ZEC regtest
+		{
+			name:    "ZEC regtest P2PKH",
+			args:    args{"ZEC", "regtest", "tmFbmrUfr9hAsLorHuvwpo81cJHyVrGon5c"},
+			wantErr: false,
+		},
+		{
+			name:    "ZEC regtest P2SH",
+			args:    args{"ZEC", "regtest", "t2Q87S1C86jaaJJhCCxJfSawmkzr4nfmVHu"},
+			wantErr: false,
+		},
+		{
+			name:    "ZEC regtest Sprout address",
+			args:    args{"ZEC", "regtest", "ztXCyCkc4XfF4211n51fD52unHBW1DnRxxpMAan6CPXsg9whKY2zwX87riBA5WxQuhdKGz7waHdK6B6PbsAmFswttLy5TCS"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC regtest BTC testnet3 address",
+			args:    args{"ZEC", "regtest", "mgG2f5CocNT2bg9hwzuC75mGHZT4tdguXh"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC regtest ETH address",
+			args:    args{"ZEC", "regtest", "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC regtest mainnet address",
+			args:    args{"ZEC", "regtest", "t1Pm2XeBSm2fNCZerFCe5wTLrhJtgMaL1mM"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC regtest random",
+			args:    args{"ZEC", "regtest", "dGj3h7mvUfYuLGX2LoemYxsMyBQo90qQ20"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC regtest empty",
+			args:    args{"ZEC", "regtest", ""},
+			wantErr: true,
+		},
+
+		// ZEC testnet3
+		{
+			name:    "ZEC testnet3 P2PKH",
+			args:    args{"ZEC", "testnet3", "tmFbmrUfr9hAsLorHuvwpo81cJHyVrGon5c"},
+			wantErr: false,
+		},
+		{
+			name:    "ZEC testnet3 P2SH",
+			args:    args{"ZEC", "testnet3", "t2Q87S1C86jaaJJhCCxJfSawmkzr4nfmVHu"},
+			wantErr: false,
+		},
+		{
+			name:    "ZEC testnet3 Sprout address",
+			args:    args{"ZEC", "testnet3", "ztXCyCkc4XfF4211n51fD52unHBW1DnRxxpMAan6CPXsg9whKY2zwX87riBA5WxQuhdKGz7waHdK6B6PbsAmFswttLy5TCS"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC testnet3 BTC testnet3 address",
+			args:    args{"ZEC", "testnet3", "mgG2f5CocNT2bg9hwzuC75mGHZT4tdguXh"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC testnet3 ETH address",
+			args:    args{"ZEC", "testnet3", "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC testnet3 mainnet address",
+			args:    args{"ZEC", "testnet3", "t1Pm2XeBSm2fNCZerFCe5wTLrhJtgMaL1mM"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC testnet3 random",
+			args:    args{"ZEC", "testnet3", "dGj3h7mvUfYuLGX2LoemYxsMyBQo90qQ20"},
+			wantErr: true,
+		},
+		{
+			name:    "ZEC testnet3 empty",
+			args:    args{"ZEC", "testnet3", ""},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("unexpected panic: %v", r)
+				}
+			}()
+			var err error
+			if _, err = DecodeAddress(tt.args.addr, tt.args.net);
+				(err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeShieldedAddress(t *testing.T) {
+	addresses := []struct {
+		net  string
+		addr string
+	}{
+		{"mainnet", "zs1z7rejlpsa98s2rrrfkwmaxu53e4ue0ulcrw0h4x5g8jl04tak0d3mm47vdtahatqrlkngh9slya"},
+		{"mainnet", "zcMRpNy15Npn6Bjg6wXg4PN8GssjAm5NhTMZZoQS1r9F6fEvpd7tAhhxBujYBWCWkMVRhEFJMGf5XkfbdXyPCP2PKCFmTc2"},
+		{"testnet3", "ztXCyCkc4XfF4211n51fD52unHBW1DnRxxpMAan6CPXsg9whKY2zwX87riBA5WxQuhdKGz7waHdK6B6PbsAmFswttLy5TCS"},
+		{"testnet3", "ztestsapling1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq"},
+		{"regtest", "uregtest1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq"},
+	}
+
+	for _, a := range addresses {
+		if _, err := DecodeAddress(a.addr, a.net); err != ErrShieldedAddress {
+			t.Fatalf("address(%v) isn't rejected as shielded: %v",
+				a.addr, err)
+		}
+	}
+}
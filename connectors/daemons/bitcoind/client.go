@@ -14,6 +14,7 @@ import (
 	"github.com/bitlum/connector/connectors/daemons/bitcoind/rpcclient"
 	"github.com/bitlum/connector/metrics/crypto"
 	"github.com/btcsuite/btclog"
+	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
 	"github.com/shopspring/decimal"
 	"github.com/bitlum/connector/connectors"
@@ -30,9 +31,44 @@ var (
 	// defaultAccount denotes default account of wallet.
 	defaultAccount = ""
 
+	// coldAccount is the account to which cold wallet addresses derived
+	// from the extended public key are assigned.
+	coldAccount = "cold_wallet"
+
+	// defaultPendingExpiry is the default time after which pending payment,
+	// which transaction is neither in the mempool nor in the blockchain, is
+	// considered to be expired. It is equal to the default mempool expiry
+	// of bitcoind.
+	defaultPendingExpiry = 336 * time.Hour
+
+	// defaultRecoveryInterval is the default interval between checks of
+	// dropped transactions and stale waiting payments.
+	defaultRecoveryInterval = 10 * time.Minute
+
+	// defaultWaitingTimeout is the default time after which payment, which
+	// has been created but hasn't been sent, is resolved by the recovery.
+	defaultWaitingTimeout = time.Hour
+
 	// minimumFeeRate is the minimal satoshis which we should pay for one byte
 	//  of information in blockchain.
 	minimumFeeRate = decimal.NewFromFloat(1.0)
+
+	// dogeMinimumFeeRate is the minimal satoshis which we should pay for
+	// one byte of information in Dogecoin blockchain. Dogecoin daemons
+	// refuse to relay transactions below the recommended fee of 0.01 DOGE
+	// per kilobyte, which is far above the bitcoin one.
+	dogeMinimumFeeRate = decimal.NewFromFloat(1000.0)
+
+	// dogeDustLimit is the minimal amount of the Dogecoin output, Dogecoin
+	// daemons treat outputs lower than 0.01 DOGE as dust.
+	dogeDustLimit btcutil.Amount = 1000000
+
+	// zecMinimumFeeRate is the minimal satoshis which we should pay for
+	// one byte of information in Zcash blockchain. Zcash daemons require
+	// the fee of 5000 zatoshis per transparent input or output (ZIP 317),
+	// which is covered by this rate for the transactions with one payment
+	// output and change.
+	zecMinimumFeeRate = decimal.NewFromFloat(50.0)
 )
 
 const (
@@ -47,6 +83,9 @@ const (
 	MethodValidate            = "Validate"
 	EstimateFee               = "EstimateFee"
 	GetFeeRate                = "GetFeeRate"
+	MethodReorg               = "Reorg"
+	MethodFinalizePayment     = "FinalizePayment"
+	MethodCancelPayment       = "CancelPayment"
 )
 
 type DaemonConfig struct {
@@ -101,6 +140,71 @@ type Config struct {
 	// StateStorage is used to keep data which is needed for connector to
 	// properly synchronise and track transactions.
 	StateStorage connectors.StateStorage
+
+	// AddressType is the type of deposit and change addresses which are
+	// generated by the daemon. If not specified daemon default is used.
+	//
+	// NOTE: Only BTC and LTC daemons support address types.
+	AddressType AddressType
+
+	// ZMQBlockAddress is the address of the daemon ZMQ publisher of
+	// 'hashblock' or 'rawblock' notifications. If specified, sync is
+	// triggered as soon as new block is received, otherwise connector
+	// relies only on polling.
+	ZMQBlockAddress string
+
+	// ZMQTxAddress is the address of the daemon ZMQ publisher of 'rawtx'
+	// notifications. If specified, incoming mempool transactions are
+	// recorded as pending as soon as they are received.
+	ZMQTxAddress string
+
+	// OnReorg is called after chain re-organisation has been handled.
+	// Optional.
+	OnReorg connectors.ReorgHandler
+
+	// XPub is the account level extended public key, e.g. m/44'/0'/0' or
+	// m/84'/0'/0'. If specified, deposit addresses are derived from it and
+	// imported in the daemon as watch-only, so that daemon doesn't hold
+	// their private keys. Daemon wallet is used only as hot wallet for
+	// withdrawals. Type of deposit addresses is implied by the key version.
+	XPub string
+
+	// HDStorage is used to keep track of the derived deposit addresses.
+	// Should be specified if extended public key is specified.
+	HDStorage HDAccountsStorage
+
+	// AccountStorage is used to keep track of the accounts to which deposit
+	// addresses are assigned, instead of the daemon accounts API.
+	AccountStorage AccountsStorage
+
+	// ColdSigning denotes that payments are signed outside of the daemon.
+	// In this case CreatePayment creates unsigned BIP174 transaction, and
+	// payment stays waiting until signed transaction is submitted with
+	// FinalizePayment. Only P2WPKH outputs are spent, so that transaction
+	// id is known before transaction is signed.
+	//
+	// NOTE: Only BTC and LTC daemons support cold signing.
+	ColdSigning bool
+
+	// PendingExpiry is the time after which pending payment, which
+	// transaction is neither in the mempool nor in the blockchain, is
+	// marked as failed. Default mempool expiry of bitcoind is used if not
+	// specified.
+	PendingExpiry time.Duration
+
+	// RecoveryInterval is the interval between checks of outgoing payments,
+	// which transactions have been dropped by the daemon, and of stale
+	// waiting payments. Check is also done on startup.
+	RecoveryInterval time.Duration
+
+	// WaitingTimeout is the time after which payment, which has been
+	// created but hasn't been sent, is resolved according to the
+	// WaitingPolicy.
+	WaitingTimeout time.Duration
+
+	// WaitingPolicy defines whether stale waiting payments are sent or
+	// cancelled. Payments are cancelled if not specified.
+	WaitingPolicy connectors.WaitingPolicy
 }
 
 func (c *Config) validate() error {
@@ -125,6 +229,26 @@ func (c *Config) validate() error {
 		c.SyncLoopDelay = 5
 	}
 
+	if c.PendingExpiry == 0 {
+		c.PendingExpiry = defaultPendingExpiry
+	}
+
+	if c.RecoveryInterval == 0 {
+		c.RecoveryInterval = defaultRecoveryInterval
+	}
+
+	if c.WaitingTimeout == 0 {
+		c.WaitingTimeout = defaultWaitingTimeout
+	}
+
+	switch c.WaitingPolicy {
+	case "":
+		c.WaitingPolicy = connectors.WaitingCancel
+	case connectors.WaitingBroadcast, connectors.WaitingCancel:
+	default:
+		return errors.Errorf("unknown waiting policy(%v)", c.WaitingPolicy)
+	}
+
 	if c.Asset == "" {
 		return errors.New("asset should be specified")
 	}
@@ -141,6 +265,57 @@ func (c *Config) validate() error {
 		return errors.New("payment store should be specified")
 	}
 
+	if c.AccountStorage == nil {
+		return errors.New("account storage should be specified")
+	}
+
+	switch c.AddressType {
+	case "":
+	case AddressLegacy, AddressP2SHSegwit, AddressBech32:
+		if c.Asset != connectors.BTC && c.Asset != connectors.LTC {
+			return errors.Errorf("address type isn't supported for "+
+				"asset(%v)", c.Asset)
+		}
+	default:
+		return errors.Errorf("unknown address type(%v)", c.AddressType)
+	}
+
+	if c.ColdSigning && c.Asset != connectors.BTC &&
+		c.Asset != connectors.LTC {
+		return errors.Errorf("cold signing isn't supported for asset(%v)",
+			c.Asset)
+	}
+
+	if c.XPub != "" {
+		// Deposit addresses are derived as bitcoin ones, which couldn't
+		// be encoded with two-byte Zcash prefixes.
+		if c.Asset == connectors.ZEC {
+			return errors.Errorf("extended public key isn't supported "+
+				"for asset(%v)", c.Asset)
+		}
+
+		if c.HDStorage == nil {
+			return errors.New("hd storage should be specified")
+		}
+
+		key, err := parseExtendedPubKey(c.XPub)
+		if err != nil {
+			return errors.Errorf("invalid extended public key: %v", err)
+		}
+
+		testnet, ok := key.isTestnet()
+		if ok && testnet == (c.Net == "mainnet") {
+			return errors.Errorf("extended public key is for the "+
+				"different network than net(%v)", c.Net)
+		}
+
+		if key.addressType != AddressLegacy &&
+			c.Asset != connectors.BTC && c.Asset != connectors.LTC {
+			return errors.Errorf("address type(%v) of extended public key "+
+				"isn't supported for asset(%v)", key.addressType, c.Asset)
+		}
+	}
+
 	return nil
 }
 
@@ -155,13 +330,12 @@ type Connector struct {
 	cfg    *Config
 	client *ExtendedRPCClient
 
-	// pending is a map of blockhain pending payments,
-	// which hasn't been confirmed from connector point of view.
-	// TODO(andrew.shvv) Remove because now we could use storage directly
-	pending map[string][]*connectors.Payment
-
 	lastSyncedBlock *btcjson.GetBlockVerboseResult
 
+	// lastRecovery is the time of the last check of dropped transactions
+	// and stale waiting payments.
+	lastRecovery time.Time
+
 	netParams *chaincfg.Params
 	log       *connectors.NamedLogger
 
@@ -169,11 +343,22 @@ type Connector struct {
 
 	// unspent is used to store btc uxto set locally, in order to craft
 	// transactions faster.
-	unspent map[string]btcjson.ListUnspentResult
+	unspent map[wire.OutPoint]btcjson.ListUnspentResult
 
 	// unspentSyncMtx is used to lock the utxo local map during is
 	// usage/population.
 	unspentSyncMtx sync.Mutex
+
+	// syncTrigger is used to trigger sync without waiting for the next
+	// sync loop tick.
+	syncTrigger chan struct{}
+
+	// xpub is the account extended public key from which deposit addresses
+	// are derived, nil if deposit addresses are generated by the daemon.
+	xpub *extendedPubKey
+
+	// deriveMtx is used to ensure that derivation index isn't used twice.
+	deriveMtx sync.Mutex
 }
 
 // A compile time check to ensure Connector implements the BlockchainConnector
@@ -185,14 +370,25 @@ func NewConnector(cfg *Config) (*Connector, error) {
 		return nil, err
 	}
 
-	return &Connector{
-		cfg:  cfg,
-		quit: make(chan struct{}),
+	c := &Connector{
+		cfg:         cfg,
+		quit:        make(chan struct{}),
+		syncTrigger: make(chan struct{}, 1),
 		log: &connectors.NamedLogger{
 			Name:   string(cfg.Asset),
 			Logger: cfg.Logger,
 		},
-	}, nil
+	}
+
+	if cfg.XPub != "" {
+		var err error
+		c.xpub, err = parseExtendedPubKey(cfg.XPub)
+		if err != nil {
+			return nil, errors.Errorf("invalid extended public key: %v", err)
+		}
+	}
+
+	return c, nil
 }
 
 func (c *Connector) Start() (err error) {
@@ -244,6 +440,15 @@ func (c *Connector) Start() (err error) {
 			return errors.Errorf("unable to get type of network: %v", err)
 		}
 		chain = resp.Chain
+	} else if c.cfg.Asset == connectors.ZEC {
+		// Zcash blockchain info response is different from standard
+		// bitcoin blockchain info.
+		resp, err := c.client.GetZcashBlockChainInfo()
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to get type of network: %v", err)
+		}
+		chain = resp.Chain
 	} else {
 		resp, err := c.client.GetBlockChainInfo()
 		if err != nil {
@@ -295,6 +500,11 @@ func (c *Connector) Start() (err error) {
 			"%v): %v", lastSyncedBlockHash, err)
 	}
 
+	if err := c.importAccounts(); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to import accounts: %v", err)
+	}
+
 	defaultAddress, err := c.fetchDefaultAddress()
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
@@ -325,6 +535,11 @@ func (c *Connector) Start() (err error) {
 					c.log.Error(err)
 					continue
 				}
+			case <-c.syncTrigger:
+				if err := c.sync(); err != nil {
+					c.log.Error(err)
+					continue
+				}
 			case <-reportTicker.C:
 				if err := c.reportMetrics(); err != nil {
 					c.log.Error(err)
@@ -337,6 +552,18 @@ func (c *Connector) Start() (err error) {
 		}
 	}()
 
+	if c.cfg.ZMQBlockAddress != "" {
+		c.wg.Add(1)
+		go c.subscribe(c.cfg.ZMQBlockAddress, []string{topicHashBlock,
+			topicRawBlock}, c.handleBlockNotification)
+	}
+
+	if c.cfg.ZMQTxAddress != "" {
+		c.wg.Add(1)
+		go c.subscribe(c.cfg.ZMQTxAddress, []string{topicRawTx},
+			c.handleTxNotification)
+	}
+
 	c.wg.Add(1)
 	go func() {
 		defer func() {
@@ -390,17 +617,12 @@ func (c *Connector) AccountAddress(accountAlias connectors.AccountAlias) (
 	defer m.Finish()
 
 	account := aliasToAccount(accountAlias)
-	addresses, err := c.client.GetAddressesByAccount(account)
+	address, err := c.cfg.AccountStorage.GetLastAccountAddress(account)
 	if err != nil {
 		m.AddError(metrics.MiddleSeverity)
 		return "", err
 	}
 
-	if len(addresses) == 0 {
-		return "", nil
-	}
-
-	address := addresses[0].String()
 	return address, nil
 }
 
@@ -412,13 +634,146 @@ func (c *Connector) CreateAddress(accountAlias connectors.AccountAlias) (string,
 		MethodCreateAddress, c.cfg.Metrics)
 	defer m.Finish()
 
-	address, err := c.client.GetNewAddress(aliasToAccount(accountAlias))
+	account := aliasToAccount(accountAlias)
+	if c.watchOnly() && account != defaultAccount {
+		address, err := c.deriveAddress(account)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return "", err
+		}
+
+		return address, nil
+	}
+
+	// Account is passed as the label, so that address could be recognised
+	// in the daemon wallet, but mapping is kept in our storage.
+	address, err := c.client.GetNewAddressType(account, c.cfg.AddressType)
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		return "", err
 	}
 
-	return address.String(), nil
+	if err := c.cfg.AccountStorage.AddAddressToAccount(address, account); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return "", errors.Errorf("unable to save address(%v): %v",
+			address, err)
+	}
+
+	return address, nil
+}
+
+// deriveAddress derives the next deposit address from the extended public
+// key, imports it in the daemon as watch-only and assigns it to the account.
+func (c *Connector) deriveAddress(account string) (string, error) {
+	c.deriveMtx.Lock()
+	defer c.deriveMtx.Unlock()
+
+	index, err := c.cfg.HDStorage.NextDerivationIndex(c.cfg.XPub)
+	if err != nil {
+		return "", errors.Errorf("unable to get derivation index: %v", err)
+	}
+
+	address, err := c.xpub.depositAddress(index, c.netParams)
+	if err != nil {
+		return "", errors.Errorf("unable to derive address(%v): %v",
+			index, err)
+	}
+
+	// Address is imported without rescan, because it has never been used
+	// before.
+	encodedAddress := address.EncodeAddress()
+	if err := c.client.ImportAddressLabel(encodedAddress, account); err != nil {
+		return "", errors.Errorf("unable to import address(%v): %v",
+			encodedAddress, err)
+	}
+
+	err = c.cfg.HDStorage.AddDerivedAddress(c.cfg.XPub, index,
+		encodedAddress, account)
+	if err != nil {
+		return "", errors.Errorf("unable to save address(%v): %v",
+			encodedAddress, err)
+	}
+
+	err = c.cfg.AccountStorage.AddAddressToAccount(encodedAddress, account)
+	if err != nil {
+		return "", errors.Errorf("unable to save address(%v): %v",
+			encodedAddress, err)
+	}
+
+	c.log.Infof("Derived address(%v), index(%v), account(%v)",
+		encodedAddress, index, account)
+
+	return encodedAddress, nil
+}
+
+// ColdAddress derives the next address from the cold wallet extended public
+// key. Unlike deposit addresses, cold wallet addresses aren't imported in
+// the daemon, because funds sent on them are no longer under control of
+// the connector.
+func (c *Connector) ColdAddress(xpub string) (string, error) {
+	if c.cfg.HDStorage == nil {
+		return "", errors.New("hd storage isn't specified")
+	}
+
+	key, err := parseExtendedPubKey(xpub)
+	if err != nil {
+		return "", errors.Errorf("invalid extended public key: %v", err)
+	}
+
+	testnet, ok := key.isTestnet()
+	if ok && testnet == (c.cfg.Net == "mainnet") {
+		return "", errors.Errorf("extended public key is for the "+
+			"different network than net(%v)", c.cfg.Net)
+	}
+
+	c.deriveMtx.Lock()
+	defer c.deriveMtx.Unlock()
+
+	index, err := c.cfg.HDStorage.NextDerivationIndex(xpub)
+	if err != nil {
+		return "", errors.Errorf("unable to get derivation index: %v", err)
+	}
+
+	address, err := key.depositAddress(index, c.netParams)
+	if err != nil {
+		return "", errors.Errorf("unable to derive address(%v): %v",
+			index, err)
+	}
+
+	encodedAddress := address.EncodeAddress()
+	err = c.cfg.HDStorage.AddDerivedAddress(xpub, index, encodedAddress,
+		coldAccount)
+	if err != nil {
+		return "", errors.Errorf("unable to save address(%v): %v",
+			encodedAddress, err)
+	}
+
+	c.log.Infof("Derived cold wallet address(%v), index(%v)",
+		encodedAddress, index)
+
+	return encodedAddress, nil
+}
+
+// IsColdAddress returns whether address has been derived from the cold
+// wallet extended public key.
+func (c *Connector) IsColdAddress(address string) (bool, error) {
+	if c.cfg.HDStorage == nil {
+		return false, errors.New("hd storage isn't specified")
+	}
+
+	account, err := c.cfg.HDStorage.DerivedAddressAccount(address)
+	if err != nil {
+		return false, errors.Errorf("unable to get account of address(%v): "+
+			"%v", address, err)
+	}
+
+	return account == coldAccount, nil
+}
+
+// watchOnly returns whether deposit addresses are watch-only ones derived
+// from the extended public key.
+func (c *Connector) watchOnly() bool {
+	return c.xpub != nil
 }
 
 // PendingTransactions return the transactions which has confirmation
@@ -432,24 +787,47 @@ func (c *Connector) PendingTransactions(accountAlias connectors.AccountAlias) (
 		MethodPendingTransactions, c.cfg.Metrics)
 	defer m.Finish()
 
-	// TODO(andrew.shvv) Use payment storage for getting pending transaction
-	// and remove pending map.
+	payments, err := c.pendingPayments(aliasToAccount(accountAlias))
+	if err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return nil, err
+	}
 
-	account := aliasToAccount(accountAlias)
-	transactions := make([]*connectors.Payment, len(c.pending[account]))
-	for i, tx := range c.pending[account] {
-		transactions[i] = tx
+	return payments, nil
+}
+
+// pendingPayments returns payments received on the account addresses,
+// which haven't been confirmed yet.
+func (c *Connector) pendingPayments(account string) ([]*connectors.Payment,
+	error) {
+
+	payments, err := connectors.PendingPayments(c.cfg.PaymentStore,
+		c.cfg.Asset)
+	if err != nil {
+		return nil, errors.Errorf("unable to list pending payments: %v",
+			err)
+	}
+
+	if account == allAccounts {
+		return payments, nil
 	}
 
-	return transactions, nil
+	var accountPayments []*connectors.Payment
+	for _, payment := range payments {
+		if payment.Account == account {
+			accountPayments = append(accountPayments, payment)
+		}
+	}
+
+	return accountPayments, nil
 }
 
 // CreatePayment generates the payment, but not sends it,
 // instead returns the payment id and waits for it to be approved.
 //
 // NOTE: Part of the connectors.BlockchainConnector interface.
-func (c *Connector) CreatePayment(address string, amount string) (*connectors.Payment,
-	error) {
+func (c *Connector) CreatePayment(address string, amount string,
+	opts *connectors.PaymentOptions) (*connectors.Payment, error) {
 	m := crypto.NewMetric(c.cfg.DaemonCfg.Name, string(c.cfg.Asset),
 		MethodCreatePayment, c.cfg.Metrics)
 	defer m.Finish()
@@ -468,47 +846,86 @@ func (c *Connector) CreatePayment(address string, amount string) (*connectors.Pa
 
 	feeSatoshiPerByte := uint64(c.getFeeRate().IntPart())
 	amtInSat := decAmount2Sat(amtInBtc)
-	tx, fee, err := c.craftTransaction(feeSatoshiPerByte, amtInSat, decodedAddress)
+	tx, prevOutputs, fee, err := c.craftTransaction(feeSatoshiPerByte,
+		amtInSat, decodedAddress)
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		return nil, errors.Errorf("unable to generate new transaction: %v", err)
 	}
 
-	signedTx, isSigned, err := c.client.SignRawTransaction(tx)
-	if err != nil {
-		m.AddError(metrics.HighSeverity)
-		return nil, errors.Errorf("unable to sign generated transaction: %v", err)
-	}
+	var (
+		txID   string
+		detail connectors.Serializable
+	)
 
-	if !isSigned {
-		m.AddError(metrics.HighSeverity)
-		return nil, errors.Errorf("unable to sign all generated transaction"+
-			" inputs: %v", err)
-	}
+	if c.cfg.ColdSigning {
+		var packet string
+		packet, txID, err = c.createPSBT(tx, prevOutputs)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return nil, errors.Errorf("unable to create psbt: %v", err)
+		}
 
-	var rawTx bytes.Buffer
-	if err := signedTx.Serialize(&rawTx); err != nil {
-		m.AddError(metrics.HighSeverity)
-		return nil, errors.Errorf("unable serialize signed tx: %v", err)
+		detail = &connectors.UnsignedTxDetails{
+			PSBT: packet,
+			TxID: txID,
+		}
+	} else if c.cfg.Asset == connectors.ZEC {
+		var rawTx []byte
+		rawTx, txID, err = c.signZcashTx(tx, prevOutputs)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return nil, errors.Errorf("unable to sign generated "+
+				"transaction: %v", err)
+		}
+
+		detail = &connectors.GeneratedTxDetails{
+			RawTx: rawTx,
+			TxID:  txID,
+		}
+	} else {
+		signedTx, isSigned, err := c.client.SignRawTransaction(tx)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return nil, errors.Errorf("unable to sign generated transaction: %v", err)
+		}
+
+		if !isSigned {
+			m.AddError(metrics.HighSeverity)
+			return nil, errors.Errorf("unable to sign all generated transaction"+
+				" inputs: %v", err)
+		}
+
+		var rawTx bytes.Buffer
+		if err := signedTx.Serialize(&rawTx); err != nil {
+			m.AddError(metrics.HighSeverity)
+			return nil, errors.Errorf("unable serialize signed tx: %v", err)
+		}
+
+		txID = signedTx.TxHash().String()
+		detail = &connectors.GeneratedTxDetails{
+			RawTx: rawTx.Bytes(),
+			TxID:  txID,
+		}
 	}
 
-	txID := signedTx.TxHash().String()
+	direction := connectors.Outgoing
+	if opts != nil && opts.Internal {
+		direction = connectors.Internal
+	}
 
 	payment := &connectors.Payment{
 		PaymentID: generatePaymentID(txID, address, connectors.Outgoing),
 		UpdatedAt: connectors.NowInMilliSeconds(),
 		Status:    connectors.Waiting,
-		Direction: connectors.Outgoing,
+		Direction: direction,
 		Receipt:   address,
 		Asset:     connectors.Asset(c.cfg.Asset),
 		Media:     connectors.Blockchain,
 		Amount:    amtInBtc.Round(8),
 		MediaFee:  sat2DecAmount(fee),
 		MediaID:   txID,
-		Detail: &connectors.GeneratedTxDetails{
-			RawTx: rawTx.Bytes(),
-			TxID:  txID,
-		},
+		Detail:    detail,
 	}
 
 	if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
@@ -537,21 +954,26 @@ func (c *Connector) SendPayment(paymentID string) (*connectors.Payment, error) {
 			err)
 	}
 
+	// Payment which has been already sent, or cancelled, shouldn't be
+	// sent again, otherwise inputs released on cancel might be spent twice.
+	if payment.Status != connectors.Waiting {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("payment(%v) couldn't be sent in "+
+			"status(%v)", paymentID, payment.Status)
+	}
+
+	if _, ok := payment.Detail.(*connectors.UnsignedTxDetails); ok {
+		return nil, errors.Errorf("payment(%v) should be signed "+
+			"externally and finalized", paymentID)
+	}
+
 	details, ok := payment.Detail.(*connectors.GeneratedTxDetails)
 	if !ok {
 		return nil, errors.Errorf("unable get details for payment(%v)",
 			paymentID)
 	}
 
-	wireTx := new(wire.MsgTx)
-	r := bytes.NewBuffer(details.RawTx)
-
-	if err := wireTx.Deserialize(r); err != nil {
-		m.AddError(metrics.HighSeverity)
-		return nil, errors.Errorf("unable to deserialize raw tx: %v", err)
-	}
-
-	_, err = c.client.SendRawTransaction(wireTx, true)
+	err = c.broadcastTx(details.RawTx)
 	if err != nil {
 		payment.Status = connectors.Failed
 		payment.UpdatedAt = connectors.NowInMilliSeconds()
@@ -569,6 +991,10 @@ func (c *Connector) SendPayment(paymentID string) (*connectors.Payment, error) {
 
 	payment.Status = connectors.Pending
 	payment.UpdatedAt = connectors.NowInMilliSeconds()
+	payment.Detail = &connectors.BlockchainPendingDetails{
+		ConfirmationsLeft: int64(c.cfg.MinConfirmations),
+		RawTx:             details.RawTx,
+	}
 
 	err = c.cfg.PaymentStore.SavePayment(payment)
 	if err != nil {
@@ -588,12 +1014,113 @@ func (c *Connector) SendPayment(paymentID string) (*connectors.Payment, error) {
 // NOTE: Part of the connectors.Connector interface.
 func (c *Connector) ConfirmedBalance(accountAlias connectors.AccountAlias) (decimal.Decimal, error) {
 	account := aliasToAccount(accountAlias)
-	balance, err := c.client.GetBalanceMinConf(account, c.cfg.MinConfirmations)
+
+	// Default account is the hot wallet, which funds are all spendable
+	// funds of the daemon wallet. Funds on the watch-only deposit addresses
+	// couldn't be spent by the daemon, so they are not included.
+	if account == allAccounts || account == defaultAccount {
+		balance, err := c.client.GetBalanceMinConf(allAccounts,
+			c.cfg.MinConfirmations)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		return decimal.NewFromFloat(balance.ToBTC()).Round(8), nil
+	}
+
+	return c.accountBalance(account)
+}
+
+// accountBalance returns the balance of the account computed from the
+// payments ledger, i.e. the sum of the confirmed payments received on the
+// addresses of the account. Accounts aren't debited on withdrawals, because
+// payments are sent from the hot wallet as a whole.
+func (c *Connector) accountBalance(account string) (decimal.Decimal, error) {
+	payments, err := c.cfg.PaymentStore.ListPayments(c.cfg.Asset,
+		connectors.Completed, connectors.Incoming, connectors.Blockchain)
 	if err != nil {
-		return decimal.Zero, err
+		return decimal.Zero, errors.Errorf("unable to list payments: %v",
+			err)
+	}
+
+	balance := decimal.Zero
+	for _, payment := range payments {
+		if payment.Account == account {
+			balance = balance.Add(payment.Amount)
+		}
+	}
+
+	return balance.Round(8), nil
+}
+
+// addressAccount returns the account to which address is assigned. Unknown
+// addresses, for example change ones, belong to the default account.
+func (c *Connector) addressAccount(address string) (string, error) {
+	account, err := c.cfg.AccountStorage.GetAccountByAddress(address)
+	if err != nil {
+		return "", errors.Errorf("unable to get account of address(%v): %v",
+			address, err)
+	}
+
+	return account, nil
+}
+
+// isWalletAddress returns whether address belongs to the wallet of the
+// daemon, either as its own or as the watch-only one.
+func (c *Connector) isWalletAddress(address string) (bool, error) {
+	ownership, err := c.client.GetAddressOwnership(address, false)
+	if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+		rpcErr.Code == btcjson.ErrRPCMethodNotFound.Code {
+		ownership, err = c.client.GetAddressOwnership(address, true)
+	}
+	if err != nil {
+		return false, errors.Errorf("unable to get address(%v) "+
+			"ownership: %v", address, err)
 	}
 
-	return decimal.NewFromFloat(balance.ToBTC()).Round(8), nil
+	return ownership.IsMine || ownership.IsWatchOnly, nil
+}
+
+// importAccounts assigns addresses created in the daemon wallet before
+// accounts were tracked in our storage to their accounts, which are taken
+// either from the deprecated accounts API or from the address labels.
+func (c *Connector) importAccounts() error {
+	addresses, err := c.client.ListAddressLabels()
+	if err != nil {
+		return err
+	}
+
+	for _, address := range addresses {
+		account := address.Account
+		if account == "" {
+			account = address.Label
+		}
+
+		if account == defaultAccount {
+			continue
+		}
+
+		storedAccount, err := c.addressAccount(address.Address)
+		if err != nil {
+			return err
+		}
+
+		if storedAccount != defaultAccount {
+			continue
+		}
+
+		err = c.cfg.AccountStorage.AddAddressToAccount(address.Address,
+			account)
+		if err != nil {
+			return errors.Errorf("unable to save address(%v): %v",
+				address.Address, err)
+		}
+
+		c.log.Infof("Import address(%v), account(%v)", address.Address,
+			account)
+	}
+
+	return nil
 }
 
 // PendingBalance return the amount of funds waiting ro be confirmed.
@@ -604,21 +1131,15 @@ func (c *Connector) PendingBalance(accountAlias connectors.AccountAlias) (decima
 		MethodPendingBalance, c.cfg.Metrics)
 	defer m.Finish()
 
-	// TODO(andrew.shvv) Use storage for getting pending transaction and
-	// calculation of pending balance.
+	payments, err := c.pendingPayments(aliasToAccount(accountAlias))
+	if err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return decimal.Zero, err
+	}
 
 	var amount decimal.Decimal
-	account := aliasToAccount(accountAlias)
-	if account == allAccounts {
-		for _, pendingPayments := range c.pending {
-			for _, payment := range pendingPayments {
-				amount = amount.Add(payment.Amount)
-			}
-		}
-	} else {
-		for _, payment := range c.pending[account] {
-			amount = amount.Add(payment.Amount)
-		}
+	for _, payment := range payments {
+		amount = amount.Add(payment.Amount)
 	}
 
 	return amount.Round(8), nil
@@ -635,39 +1156,14 @@ func (c *Connector) syncUnconfirmed() error {
 		return err
 	}
 
-	c.pending = make(map[string][]*connectors.Payment)
 	for _, tx := range txs {
-		payment := &connectors.Payment{
-			UpdatedAt: connectors.NowInMilliSeconds(),
-			Status:    connectors.Pending,
-			Receipt:   tx.Address,
-			Asset:     c.cfg.Asset,
-			Account:   tx.Account,
-			Media:     connectors.Blockchain,
-			Amount:    decimal.NewFromFloat(tx.Amount),
-			MediaFee:  decimal.Zero,
-			MediaID:   tx.TxID,
-			Detail: &connectors.BlockchainPendingDetails{
-				Confirmations:     tx.Confirmations,
-				ConfirmationsLeft: int64(c.cfg.MinConfirmations) - tx.Confirmations,
-			},
-		}
-
-		if tx.Account == defaultAccount {
-			payment.Direction = connectors.Internal
-			payment.MediaFee = decimal.Zero
-			payment.PaymentID = generatePaymentID(tx.TxID, tx.Address,
-				connectors.Internal)
-		} else {
-			payment.Direction = connectors.Incoming
-			payment.MediaFee = decimal.Zero
-			payment.PaymentID = generatePaymentID(tx.TxID, tx.Address,
-				connectors.Incoming)
+		account, err := c.addressAccount(tx.Address)
+		if err != nil {
+			return err
 		}
 
-		// TODO(andrew.shvv) Remove because now we could use storage directly
-		// for pending balance and pending transaction.
-		c.pending[tx.Account] = append(c.pending[tx.Account], payment)
+		payment := c.newPendingPayment(tx.TxID, tx.Address, account,
+			tx.Amount, tx.Confirmations)
 
 		if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
 			return errors.Errorf("unable to save payment(%v): %v",
@@ -677,37 +1173,284 @@ func (c *Connector) syncUnconfirmed() error {
 		c.log.Infof("Pending transaction(%v),"+
 			"confirmations left(%v), account(%v), amount(%v)", tx.TxID,
 			int64(c.cfg.MinConfirmations)-tx.Confirmations,
-			accountToAlias(tx.Account), tx.Amount)
+			accountToAlias(account), tx.Amount)
 	}
 
 	return nil
 }
 
+// syncSent updates confirmations of the payments sent by the connector,
+// which transactions haven't reached the minimum confirmations threshold
+// yet. Payments which transactions conflict with the confirmed ones are
+// failed as double-spent. Payments which transactions have left the mempool
+// aren't updated, so that they are expired if rebroadcast keeps failing.
+func (c *Connector) syncSent() error {
+	payments, err := connectors.SentPayments(c.cfg.PaymentStore, c.cfg.Asset)
+	if err != nil {
+		return errors.Errorf("unable to list sent payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		tx, err := c.client.GetWalletTransaction(payment.MediaID,
+			c.watchOnly())
+		if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+			rpcErr.Code == btcjson.ErrRPCInvalidAddressOrKey {
+			continue
+		} else if err != nil {
+			return errors.Errorf("unable to get tx(%v): %v",
+				payment.MediaID, err)
+		}
+
+		// Negative number of confirmations means that transaction
+		// conflicts with the one in the main chain.
+		if tx.Confirmations < 0 {
+			if err := c.failDoubleSpentSent(payment, tx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Wallet keeps unconfirmed transactions even if they have been
+		// dropped from the mempool.
+		if tx.Confirmations == 0 {
+			inMempool, err := c.inMempool(payment.MediaID)
+			if err != nil {
+				return err
+			}
+
+			if !inMempool {
+				continue
+			}
+		}
+
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		payment.Detail = &connectors.BlockchainPendingDetails{
+			Confirmations: tx.Confirmations,
+			ConfirmationsLeft: int64(c.cfg.MinConfirmations) -
+				tx.Confirmations,
+			BlockHash: tx.BlockHash,
+			RawTx:     connectors.SentRawTx(payment),
+		}
+
+		if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+
+		c.log.Debugf("Sent transaction(%v), confirmations left(%v), "+
+			"amount(%v)", payment.MediaID, int64(c.cfg.MinConfirmations)-
+			tx.Confirmations, payment.Amount)
+	}
+
+	return nil
+}
+
+// syncWalletTx records the outputs of the unconfirmed wallet transaction
+// with the given id as pending. Transactions which don't belong to the
+// wallet are skipped.
+func (c *Connector) syncWalletTx(txID string) error {
+	txHash, err := chainhash.NewHashFromStr(txID)
+	if err != nil {
+		return errors.Errorf("unable to decode hash: %v", err)
+	}
+
+	tx, err := c.client.GetTransactionWatchOnly(txHash, c.watchOnly())
+	if err != nil {
+		if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+			rpcErr.Code == btcjson.ErrRPCInvalidAddressOrKey {
+			return nil
+		}
+		return err
+	}
+
+	// Confirmed transactions are handled during block processing.
+	if tx.Confirmations >= int64(c.cfg.MinConfirmations) {
+		return nil
+	}
+
+	for _, detail := range tx.Details {
+		if detail.Category != "receive" {
+			continue
+		}
+
+		account, err := c.addressAccount(detail.Address)
+		if err != nil {
+			return err
+		}
+
+		payment := c.newPendingPayment(tx.TxID, detail.Address, account,
+			detail.Amount, tx.Confirmations)
+
+		if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+
+		c.log.Infof("Pending transaction(%v), confirmations left(%v), "+
+			"account(%v), amount(%v)", tx.TxID,
+			int64(c.cfg.MinConfirmations)-tx.Confirmations,
+			accountToAlias(account), detail.Amount)
+	}
+
+	return nil
+}
+
+// newPendingPayment creates payment for the output of the wallet
+// transaction, which hasn't been confirmed yet.
+func (c *Connector) newPendingPayment(txID, address, account string,
+	amount float64, confirmations int64) *connectors.Payment {
+
+	payment := &connectors.Payment{
+		UpdatedAt: connectors.NowInMilliSeconds(),
+		Status:    connectors.Pending,
+		Receipt:   address,
+		Asset:     c.cfg.Asset,
+		Account:   account,
+		Media:     connectors.Blockchain,
+		Amount:    decimal.NewFromFloat(amount),
+		MediaFee:  decimal.Zero,
+		MediaID:   txID,
+		Detail: &connectors.BlockchainPendingDetails{
+			Confirmations:     confirmations,
+			ConfirmationsLeft: int64(c.cfg.MinConfirmations) - confirmations,
+		},
+	}
+
+	if account == defaultAccount {
+		payment.Direction = connectors.Internal
+		payment.PaymentID = generatePaymentID(txID, address,
+			connectors.Internal)
+	} else {
+		payment.Direction = connectors.Incoming
+		payment.PaymentID = generatePaymentID(txID, address,
+			connectors.Incoming)
+	}
+
+	return payment
+}
+
 // findForkBlock is used to find block on which fork has happened,
-// at return it, so that syncing could continue.
+// at return it, so that syncing could continue. Hashes of the orphaned
+// blocks are returned as well.
 func (c *Connector) findForkBlock(orphanBlock *btcjson.GetBlockVerboseResult) (
-	*btcjson.GetBlockVerboseResult, error) {
+	*btcjson.GetBlockVerboseResult, map[string]struct{}, error) {
+
+	orphanedBlocks := make(map[string]struct{})
 	for orphanBlock.Confirmations == -1 {
+		orphanedBlocks[orphanBlock.Hash] = struct{}{}
+
 		prevHash, err := chainhash.NewHashFromStr(orphanBlock.PreviousHash)
 		if err != nil {
-			return nil, errors.Errorf("unable to decode hash of prev "+
-				"orphan block: %v", err)
+			return nil, nil, errors.Errorf("unable to decode hash of "+
+				"prev orphan block: %v", err)
 		}
 
 		orphanBlock, err = c.client.GetBlockVerbose(prevHash)
 		if err != nil {
-			return nil, errors.Errorf("unable to prev last sync block "+
-				"from daemon: %v", err)
+			return nil, nil, errors.Errorf("unable to prev last sync "+
+				"block from daemon: %v", err)
+
+		}
+	}
+
+	return orphanBlock, orphanedBlocks, nil
+}
+
+// handleReorg reverts payments which were confirmed in the orphaned blocks.
+// If transaction of the payment is still known by the wallet, payment is
+// reverted to pending, and will be completed again after transaction is
+// confirmed in the new chain, otherwise payment is marked as failed.
+func (c *Connector) handleReorg(forkBlock *btcjson.GetBlockVerboseResult,
+	orphanedBlocks map[string]struct{}) error {
+
+	m := crypto.NewMetric(c.cfg.DaemonCfg.Name, string(c.cfg.Asset),
+		MethodReorg, c.cfg.Metrics)
+	defer m.Finish()
+
+	m.ReorgDepth(len(orphanedBlocks))
+
+	payments, err := connectors.OrphanedPayments(c.cfg.PaymentStore,
+		c.cfg.Asset, orphanedBlocks)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to get orphaned payments: %v", err)
+	}
+
+	event := &connectors.ReorgEvent{
+		Asset:           c.cfg.Asset,
+		ForkBlockHash:   forkBlock.Hash,
+		ForkBlockNumber: forkBlock.Height,
+		Depth:           len(orphanedBlocks),
+	}
+
+	for _, payment := range payments {
+		txHash, err := chainhash.NewHashFromStr(payment.MediaID)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to decode tx hash(%v): %v",
+				payment.MediaID, err)
+		}
+
+		var vanished bool
+		var confirmations int64
+
+		tx, err := c.client.GetTransactionWatchOnly(txHash, c.watchOnly())
+		if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+			rpcErr.Code == btcjson.ErrRPCInvalidAddressOrKey {
+			vanished = true
+		} else if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to get tx(%v): %v",
+				payment.MediaID, err)
+		} else {
+			// Negative number of confirmations means that transaction
+			// conflicts with the one in the main chain.
+			vanished = tx.Confirmations < 0
+			confirmations = tx.Confirmations
+		}
 
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		if vanished {
+			payment.Status = connectors.Failed
+			payment.Detail = nil
+			event.FailedPayments = append(event.FailedPayments,
+				payment.PaymentID)
+		} else {
+			payment.Status = connectors.Pending
+			payment.Detail = &connectors.BlockchainPendingDetails{
+				Confirmations: confirmations,
+				ConfirmationsLeft: int64(c.cfg.MinConfirmations) -
+					confirmations,
+				RawTx: connectors.SentRawTx(payment),
+			}
+			event.RevertedPayments = append(event.RevertedPayments,
+				payment.PaymentID)
+		}
+
+		if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
 		}
 	}
 
-	return orphanBlock, nil
+	c.log.Warnf("Chain re-organisation, fork block(%v), depth(%v), "+
+		"reverted payments(%v), failed payments(%v)", event.ForkBlockHash,
+		event.Depth, event.RevertedPayments, event.FailedPayments)
+
+	if c.cfg.OnReorg != nil {
+		c.cfg.OnReorg(event)
+	}
+
+	return nil
 }
 
 // proceedNextBlock process new blocks and updates payment status that
-// transaction reached the minimum confirmation threshold.
-func (c *Connector) proceedNextBlock() error {
+// transaction reached the minimum confirmation threshold. All blocks, which
+// reached the threshold since last synced block, are proceeded at once, by
+// listing transactions of the wallet added since last synced block. Number of
+// blocks left to proceed is reported with the given sync metric.
+func (c *Connector) proceedNextBlock(m crypto.Metric) error {
 	var err error
 
 	hash, err := chainhash.NewHashFromStr(c.lastSyncedBlock.Hash)
@@ -728,7 +1471,7 @@ func (c *Connector) proceedNextBlock() error {
 	if c.lastSyncedBlock.Confirmations < 0 {
 		c.log.Info("Chain re-organisation has been found, handle it...")
 
-		forkBlock, err := c.findForkBlock(c.lastSyncedBlock)
+		forkBlock, orphanedBlocks, err := c.findForkBlock(c.lastSyncedBlock)
 		if err != nil {
 			return errors.Errorf("unable to handle "+
 				"re-organizations: %v", err)
@@ -736,128 +1479,235 @@ func (c *Connector) proceedNextBlock() error {
 
 		c.log.Infof("Fork have been detected on block("+
 			"%v) using it as last synced block", forkBlock.Hash)
+
+		if err := c.handleReorg(forkBlock, orphanedBlocks); err != nil {
+			return errors.Errorf("unable to handle "+
+				"re-organizations: %v", err)
+		}
+
+		forkHash, err := chainhash.NewHashFromStr(forkBlock.Hash)
+		if err != nil {
+			return err
+		}
+
+		encodedBlockHash := hex.EncodeToString(forkHash.CloneBytes())
+		err = c.cfg.StateStorage.PutLastSyncedHash([]byte(encodedBlockHash))
+		if err != nil {
+			return errors.Errorf("unable to put block hash in db: %v", err)
+		}
+
 		c.lastSyncedBlock = forkBlock
 	}
 
-	for {
+	bestHeight, err := c.client.GetBlockCount()
+	if err != nil {
+		return errors.Errorf("unable to get best block height: %v", err)
+	}
+
+	// Blocks are proceeded only if there is minimum amount of confirmations
+	// on top of them.
+	targetHeight := bestHeight - int64(c.cfg.MinConfirmations) + 1
+	if targetHeight <= c.lastSyncedBlock.Height {
+		m.BlocksBehind(0)
+		return nil
+	}
+
+	m.BlocksBehind(targetHeight - c.lastSyncedBlock.Height)
+
+	targetHash, err := c.client.GetBlockHash(targetHeight)
+	if err != nil {
+		return errors.Errorf("unable to get hash of block(%v): %v",
+			targetHeight, err)
+	}
+
+	targetBlock, err := c.client.GetBlockVerbose(targetHash)
+	if err != nil {
+		return errors.Errorf("unable to get block(%v): %v", targetHash, err)
+	}
+
+	// Instead of requesting every transaction of every block, take only
+	// transactions of our wallet, which were added since last synced block.
+	lastHash, err := chainhash.NewHashFromStr(c.lastSyncedBlock.Hash)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.ListSinceBlockWatchOnly(lastHash, 1, c.watchOnly())
+	if err != nil {
+		return errors.Errorf("unable to list txs since block(%v): %v",
+			lastHash, err)
+	}
+
+	// List includes transactions of blocks above the target, as well as
+	// of the orphaned blocks, so they are filtered by the block header,
+	// which are requested in one batch.
+	var blockHashes []string
+	blocks := make(map[string]*BlockHeaderResult)
+	for _, tx := range res.Transactions {
+		if tx.BlockHash == "" {
+			continue
+		}
+
+		if _, ok := blocks[tx.BlockHash]; !ok {
+			blocks[tx.BlockHash] = nil
+			blockHashes = append(blockHashes, tx.BlockHash)
+		}
+	}
+
+	headers, err := c.client.GetBlockHeaders(blockHashes)
+	if err != nil {
+		return errors.Errorf("unable to get block headers: %v", err)
+	}
+
+	for _, header := range headers {
+		blocks[header.Hash] = header
+	}
+
+	for _, tx := range res.Transactions {
 		select {
 		case <-c.quit:
 			return nil
 		default:
 		}
 
-		// We should check next block only if there is minimum amount of
-		// confirmation above it.
-		if c.lastSyncedBlock.Confirmations < int64(c.cfg.MinConfirmations)+1 {
-			return nil
-		}
-
-		// This check is a bit redundant, but we should be ensured that
-		// next hash exists, otherwise the last synced hash will be overwritten
-		// with zero hash.
-		if c.lastSyncedBlock.NextHash == "" {
-			c.log.Errorf("unable to continue processing block(%v):"+
-				"next hash empty", c.lastSyncedBlock.Hash)
-			return nil
+		block := blocks[tx.BlockHash]
+		if block == nil || block.Confirmations < 0 ||
+			block.Height > targetBlock.Height {
+			continue
 		}
 
-		nextHash, err := chainhash.NewHashFromStr(c.lastSyncedBlock.NextHash)
+		payment, err := c.confirmedPayment(tx, block, m)
 		if err != nil {
 			return err
 		}
 
-		proceededBlock, err := c.client.GetBlockVerbose(nextHash)
-		if err != nil {
-			return err
+		// Change outputs and outputs of coinbase transactions are
+		// skipped.
+		if payment == nil {
+			continue
 		}
 
-		for _, txHashStr := range proceededBlock.Tx {
-			txHash, err := chainhash.NewHashFromStr(txHashStr)
-			if err != nil {
-				c.log.Errorf("unable to decode tx hash(%v)", txHashStr)
-				continue
-			}
+		c.log.Infof("Receive payment %v", spew.Sdump(payment))
 
-			// Get transaction and if this transaction not correspond to non
-			// of our account the error will be returned, in the case skip
-			// this transaction.
-			tx, err := c.client.GetTransaction(txHash)
-			if err != nil {
-				continue
-			}
+		if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+	}
 
-			if len(tx.Details) == 0 {
-				c.log.Errorf("unable to sync tx(%v), there is "+
-					"no details", tx.TxID)
-				continue
-			}
+	encodedBlockHash := hex.EncodeToString(targetHash.CloneBytes())
+	err = c.cfg.StateStorage.PutLastSyncedHash([]byte(encodedBlockHash))
+	if err != nil {
+		return errors.Errorf("unable to put block hash in db: %v", err)
+	}
 
-			for _, detail := range tx.Details {
-				payment := &connectors.Payment{
-					UpdatedAt: connectors.NowInMilliSeconds(),
-					Status:    connectors.Completed,
-					Receipt:   detail.Address,
-					Asset:     c.cfg.Asset,
-					Account:   detail.Account,
-					Media:     connectors.Blockchain,
-					MediaID:   tx.TxID,
-				}
+	c.log.Infof("Process blocks from number(%v) to hash(%v), number(%v)",
+		c.lastSyncedBlock.Height+1, targetBlock.Hash, targetBlock.Height)
 
-				if detail.Category == "receive" &&
-					detail.Account == defaultAccount {
-
-					payment.MediaFee = decimal.Zero
-					payment.Direction = connectors.Internal
-					payment.Amount = decimal.NewFromFloat(detail.Amount)
-					payment.PaymentID = generatePaymentID(tx.TxID,
-						detail.Address, connectors.Internal)
-
-				} else if detail.Category == "receive" {
-					payment.Direction = connectors.Incoming
-					payment.MediaFee = decimal.Zero
-					payment.Amount = decimal.NewFromFloat(detail.Amount)
-					payment.PaymentID = generatePaymentID(tx.TxID,
-						detail.Address, connectors.Incoming)
-
-				} else if detail.Category == "send" {
-					payment.PaymentID = generatePaymentID(tx.TxID,
-						detail.Address, connectors.Outgoing)
-
-					_, err := c.cfg.PaymentStore.PaymentByID(payment.PaymentID)
-					if err != nil {
-						// If payment is not found in the storage that means
-						// that this is the "change". Such check only works if
-						// payment id consist of address and txid.
-						continue
-					}
-
-					payment.Amount = decimal.NewFromFloat(detail.Amount).Abs()
-					payment.MediaFee = decimal.NewFromFloat(tx.Fee).Abs()
-					payment.Direction = connectors.Outgoing
-				}
+	c.lastSyncedBlock = targetBlock
+	m.BlocksBehind(bestHeight - int64(c.cfg.MinConfirmations) + 1 -
+		targetBlock.Height)
 
-				c.log.Infof("Receive payment %v", spew.Sdump(payment))
+	return nil
+}
 
-				if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
-					return errors.Errorf("unable to save payment(%v): %v",
-						payment.PaymentID, err)
-				}
-			}
+// confirmedPayment converts the wallet transaction output, confirmed in the
+// given block, to the payment. Nil is returned if output is not a payment,
+// i.e. it is the change or the output of coinbase transaction. Outgoing
+// payments, which haven't been initiated by connector, are reported with
+// the given metric.
+func (c *Connector) confirmedPayment(tx btcjson.ListTransactionsResult,
+	block *BlockHeaderResult, m crypto.Metric) (*connectors.Payment, error) {
+
+	account, err := c.addressAccount(tx.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &connectors.BlockchainConfirmedDetails{
+		BlockHash:   block.Hash,
+		BlockNumber: block.Height,
+	}
+
+	payment := &connectors.Payment{
+		UpdatedAt: connectors.NowInMilliSeconds(),
+		Status:    connectors.Completed,
+		Receipt:   tx.Address,
+		Asset:     c.cfg.Asset,
+		Account:   account,
+		Media:     connectors.Blockchain,
+		MediaID:   tx.TxID,
+		Detail:    details,
+	}
+
+	switch {
+	case tx.Category == "receive" && account == defaultAccount:
+		payment.MediaFee = decimal.Zero
+		payment.Direction = connectors.Internal
+		payment.Amount = decimal.NewFromFloat(tx.Amount)
+		payment.PaymentID = generatePaymentID(tx.TxID, tx.Address,
+			connectors.Internal)
+
+	case tx.Category == "receive":
+		payment.Direction = connectors.Incoming
+		payment.MediaFee = decimal.Zero
+		payment.Amount = decimal.NewFromFloat(tx.Amount)
+		payment.PaymentID = generatePaymentID(tx.TxID, tx.Address,
+			connectors.Incoming)
+
+	case tx.Category == "send":
+		payment.PaymentID = generatePaymentID(tx.TxID, tx.Address,
+			connectors.Outgoing)
+
+		payment.Amount = decimal.NewFromFloat(tx.Amount).Abs()
+		payment.MediaFee = decimal.Zero
+		if tx.Fee != nil {
+			payment.MediaFee = decimal.NewFromFloat(*tx.Fee).Abs()
 		}
 
-		encodedBlockHash := hex.EncodeToString(nextHash.CloneBytes())
-		err = c.cfg.StateStorage.PutLastSyncedHash([]byte(encodedBlockHash))
+		storedPayment, err := c.cfg.PaymentStore.PaymentByID(payment.PaymentID)
+		if err == nil {
+			// Payments to our own wallets, for example deposit in
+			// lightning network daemon, are created as outgoing
+			// ones but marked as internal, so direction is taken
+			// from the stored payment.
+			payment.Direction = storedPayment.Direction
+			payment.External = storedPayment.External
+
+			// Transaction is kept in order to broadcast it again, if
+			// block is orphaned.
+			details.RawTx = connectors.SentRawTx(storedPayment)
+			break
+		}
+
+		// Output which is sent back to the address of our wallet is the
+		// "change".
+		isWalletAddress, err := c.isWalletAddress(tx.Address)
 		if err != nil {
-			return errors.Errorf("unable to put block hash in db: %v", err)
+			return nil, err
 		}
 
-		c.lastSyncedBlock = proceededBlock
+		if isWalletAddress {
+			return nil, nil
+		}
+
+		// Otherwise payment has been sent bypassing the connector, for
+		// example with the daemon directly, or has been lost from the
+		// storage.
+		payment.Account = ""
+		payment.Direction = connectors.Outgoing
+		payment.External = true
+
+		m.AddExternalPayment()
+		c.log.Warnf("Payment(%v) hasn't been initiated by connector, "+
+			"tx(%v), address(%v), amount(%v)", payment.PaymentID,
+			payment.MediaID, payment.Receipt, payment.Amount)
 
-		// After transaction has been consumed by other subsystem
-		// overwrite cache.
-		c.log.Infof("Process block hash(%v), number(%v)",
-			proceededBlock.Hash, proceededBlock.Height)
+	default:
+		return nil, nil
 	}
+
+	return payment, nil
 }
 
 // fetchLastSyncedBlockHash returns hash of block which were handled in previous
@@ -918,7 +1768,7 @@ func (c *Connector) sync() error {
 		MethodSync, c.cfg.Metrics)
 	defer m.Finish()
 
-	if err := c.proceedNextBlock(); err != nil {
+	if err := c.proceedNextBlock(m); err != nil {
 		m.AddError(metrics.MiddleSeverity)
 		return errors.Errorf("unable to process blocks: %v", err)
 
@@ -931,6 +1781,34 @@ func (c *Connector) sync() error {
 		return errors.Errorf("unable to sync unconfirmed txs: %v", err)
 	}
 
+	// Recovery is run before the sync of sent payments, so that dropped
+	// transactions are sent again rather than marked as failed. Failed
+	// recovery is retried on the next interval, and shouldn't stop the
+	// sync of payments.
+	if time.Since(c.lastRecovery) >= c.cfg.RecoveryInterval {
+		if err := c.recoverPayments(); err != nil {
+			m.AddError(metrics.MiddleSeverity)
+			c.log.Errorf("unable to recover payments: %v", err)
+		}
+
+		c.lastRecovery = time.Now()
+	}
+
+	if err := c.syncSent(); err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return errors.Errorf("unable to sync sent txs: %v", err)
+	}
+
+	if err := c.syncDoubleSpends(); err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return errors.Errorf("unable to sync double-spent txs: %v", err)
+	}
+
+	if err := c.syncExpired(); err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return errors.Errorf("unable to sync expired txs: %v", err)
+	}
+
 	balance, err := c.ConfirmedBalance(connectors.SentAccount)
 	if err != nil {
 		m.AddError(metrics.MiddleSeverity)
@@ -1000,10 +1878,12 @@ func (c *Connector) getFeeRate() decimal.Decimal {
 	var respErr error
 
 	switch c.cfg.Asset {
-	case connectors.BCH, connectors.DASH:
+	case connectors.BCH, connectors.DASH, connectors.DOGE, connectors.ZEC:
 		// Bitcoin Cash removed estimatesmartfee in 17.2 version of their client,
 		// for that reason we need to have different behaviour for Bitcoin Cash
-		// asset, and use original estimatefee method.
+		// asset, and use original estimatefee method. Dogecoin and Zcash
+		// daemons are based on the old bitcoin version, in which
+		// estimatesmartfee is not available yet.
 		res, err := c.client.EstimateFee(2)
 		if err != nil {
 			respErr = err
@@ -1036,16 +1916,18 @@ func (c *Connector) getFeeRate() decimal.Decimal {
 		}
 	}
 
+	minFeeRate := c.minimumFeeRate()
+
 	var feeRateSatoshiPerByte decimal.Decimal
 	if respErr != nil {
-		if c.cfg.Net == "mainnet" {
+		if c.cfg.Net == "mainnet" && c.cfg.Asset != connectors.DOGE {
 			c.log.Errorf("unable get fee rate: %v", respErr)
 			m.AddError(metrics.HighSeverity)
 		}
 
 		feeRateSatoshiPerByte = decimal.New(int64(c.cfg.FeePerByte), 0).Round(8)
-		if feeRateSatoshiPerByte.LessThan(minimumFeeRate) {
-			feeRateSatoshiPerByte = minimumFeeRate
+		if feeRateSatoshiPerByte.LessThan(minFeeRate) {
+			feeRateSatoshiPerByte = minFeeRate
 		}
 
 		c.log.Debugf("Get fee rate(%v sat/byte) from config",
@@ -1056,8 +1938,8 @@ func (c *Connector) getFeeRate() decimal.Decimal {
 		bytesInKiloByte := decimal.NewFromFloat(1024)
 		feeRateSatoshiPerKiloByte := feeRateBtcPerKiloByte.Mul(satoshiPerBitcoin)
 		feeRateSatoshiPerByte = feeRateSatoshiPerKiloByte.Div(bytesInKiloByte).Round(8)
-		if feeRateSatoshiPerByte.LessThan(minimumFeeRate) {
-			feeRateSatoshiPerByte = minimumFeeRate
+		if feeRateSatoshiPerByte.LessThan(minFeeRate) {
+			feeRateSatoshiPerByte = minFeeRate
 		}
 
 		c.log.Debugf("Get fee rate(%v sat/byte) from daemon",
@@ -1067,6 +1949,43 @@ func (c *Connector) getFeeRate() decimal.Decimal {
 	return feeRateSatoshiPerByte
 }
 
+// minimumFeeRate returns the minimal fee rate in sat/byte of the asset.
+func (c *Connector) minimumFeeRate() decimal.Decimal {
+	// Dogecoin blocks are mostly empty, so daemon usually doesn't have
+	// enough data to make an estimation, and the fee rate is the
+	// recommended one rather than market driven. Zcash daemons reject
+	// transactions which doesn't pay the conventional ZIP 317 fee.
+	switch c.cfg.Asset {
+	case connectors.DOGE:
+		return dogeMinimumFeeRate
+	case connectors.ZEC:
+		return zecMinimumFeeRate
+	default:
+		return minimumFeeRate
+	}
+}
+
+// longTermFeeRate returns the fee rate in sat/byte with which inputs are
+// expected to be spent in the long term. Fee rate of the assets with high
+// minimum fee rate never goes lower than the minimum one.
+func (c *Connector) longTermFeeRate() btcutil.Amount {
+	minFeeRate := btcutil.Amount(c.minimumFeeRate().IntPart())
+	if minFeeRate > defaultLongTermFeeRate {
+		return minFeeRate
+	}
+
+	return defaultLongTermFeeRate
+}
+
+// dustLimit returns the minimal amount of the change output of the asset.
+func (c *Connector) dustLimit() btcutil.Amount {
+	if c.cfg.Asset == connectors.DOGE {
+		return dogeDustLimit
+	}
+
+	return defaultDustLimit
+}
+
 // reportMetrics is used to report necessary health metrics about internal
 // state of the connector.
 func (c *Connector) reportMetrics() error {
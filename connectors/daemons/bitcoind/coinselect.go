@@ -0,0 +1,339 @@
+package bitcoind
+
+import (
+	"sort"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+const (
+	// bnbMaxTries is the maximum number of branches which branch-and-bound
+	// algorithm explores before giving up.
+	bnbMaxTries = 100000
+
+	// defaultLongTermFeeRate is the fee rate in sat/byte which we expect to
+	// pay in the long term. If current fee rate is lower than this one,
+	// spending more inputs now is cheaper than spending them later, and
+	// the other way around.
+	defaultLongTermFeeRate btcutil.Amount = 10
+
+	// defaultDustLimit is the minimal amount of the change output, outputs
+	// which are lower than this limit are considered non-standard and
+	// aren't relayed by the nodes.
+	defaultDustLimit btcutil.Amount = 546
+)
+
+// utxo is unspent transaction output which might be used as an input of
+// the transaction during coin selection.
+type utxo struct {
+	// outPoint is the unique identifier of the output.
+	outPoint wire.OutPoint
+
+	// amount is the value of the output.
+	amount btcutil.Amount
+
+	// inputWeight is the weight which input spending this output adds to
+	// the transaction.
+	inputWeight int
+}
+
+// coinSelectParams describes the transaction for which coin selection is
+// performed.
+type coinSelectParams struct {
+	// amount is the number of funds which should be sent.
+	amount btcutil.Amount
+
+	// feeRate is the current fee rate in sat/byte.
+	feeRate btcutil.Amount
+
+	// longTermFeeRate is the fee rate in sat/byte with which inputs are
+	// expected to be spent in the long term.
+	longTermFeeRate btcutil.Amount
+
+	// baseWeight is the weight of the transaction without inputs and
+	// change output.
+	baseWeight int
+
+	// changeOutputWeight is the weight which change output adds to the
+	// transaction.
+	changeOutputWeight int
+
+	// changeSpendWeight is the weight of the input which will spend the
+	// change output in the future.
+	changeSpendWeight int
+
+	// dustLimit is the minimal amount of the change output.
+	dustLimit btcutil.Amount
+}
+
+// coinSelection is the result of the coin selection.
+type coinSelection struct {
+	// inputs are the outputs which should be spent by the transaction.
+	inputs []*utxo
+
+	// change is the amount of the change output, zero if transaction
+	// doesn't have change.
+	change btcutil.Amount
+
+	// fee is the fee which transaction pays.
+	fee btcutil.Amount
+
+	// waste is the metric which shows how much funds are spent
+	// excessively because of the selection, in comparison with ideal
+	// selection. Lower is better.
+	waste btcutil.Amount
+}
+
+// feeForWeight returns the fee which should be paid for the given weight
+// with the given fee rate in sat/byte.
+func feeForWeight(weight int, feeRate btcutil.Amount) btcutil.Amount {
+	vsize := (weight + witnessScaleFactor - 1) / witnessScaleFactor
+	return btcutil.Amount(vsize) * feeRate
+}
+
+// effectiveValue returns the value of the output minus the fee paid for
+// spending it.
+func (p *coinSelectParams) effectiveValue(u *utxo) btcutil.Amount {
+	return u.amount - feeForWeight(u.inputWeight, p.feeRate)
+}
+
+// inputWaste returns the difference between the fee paid for spending the
+// output now and the fee we would pay in the long term.
+func (p *coinSelectParams) inputWaste(u *utxo) btcutil.Amount {
+	return feeForWeight(u.inputWeight, p.feeRate) -
+		feeForWeight(u.inputWeight, p.longTermFeeRate)
+}
+
+// target returns the sum of effective values of inputs, which is needed to
+// pay the amount and the fee of the transaction without change output.
+func (p *coinSelectParams) target() btcutil.Amount {
+	return p.amount + feeForWeight(p.baseWeight, p.feeRate)
+}
+
+// changeFee returns the fee which is paid for adding change output.
+func (p *coinSelectParams) changeFee() btcutil.Amount {
+	return feeForWeight(p.changeOutputWeight, p.feeRate)
+}
+
+// costOfChange returns the fee which is paid for creation of the change
+// output now, and spending it in the future.
+func (p *coinSelectParams) costOfChange() btcutil.Amount {
+	return p.changeFee() +
+		feeForWeight(p.changeSpendWeight, p.longTermFeeRate)
+}
+
+// newSelection calculates the change, fee and waste of the selection.
+func (p *coinSelectParams) newSelection(inputs []*utxo,
+	withChange bool) *coinSelection {
+
+	var total, effective, waste btcutil.Amount
+	for _, u := range inputs {
+		total += u.amount
+		effective += p.effectiveValue(u)
+		waste += p.inputWaste(u)
+	}
+
+	var change btcutil.Amount
+	if withChange {
+		change = effective - p.target() - p.changeFee()
+		waste += p.costOfChange()
+	} else {
+		// Without change the excess is given to the miners.
+		waste += effective - p.target()
+	}
+
+	return &coinSelection{
+		inputs: inputs,
+		change: change,
+		fee:    total - p.amount - change,
+		waste:  waste,
+	}
+}
+
+// sortByEffectiveValue returns outputs with positive effective value
+// sorted in descending order. Outputs which are more expensive to spend
+// than their value are skipped. The order of the outputs with the same
+// value is deterministic.
+func (p *coinSelectParams) sortByEffectiveValue(utxos []*utxo) []*utxo {
+	var sorted []*utxo
+	for _, u := range utxos {
+		if p.effectiveValue(u) > 0 {
+			sorted = append(sorted, u)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		vi, vj := p.effectiveValue(sorted[i]), p.effectiveValue(sorted[j])
+		if vi != vj {
+			return vi > vj
+		}
+
+		hi, hj := sorted[i].outPoint.Hash, sorted[j].outPoint.Hash
+		if hi != hj {
+			return hi.String() < hj.String()
+		}
+
+		return sorted[i].outPoint.Index < sorted[j].outPoint.Index
+	})
+
+	return sorted
+}
+
+// selectBnB searches for the set of outputs which pays the transaction
+// without change output, with the minimal waste. The effective value of the
+// selection should be within the window [target, target + cost of change],
+// because otherwise creation of the change output is cheaper than giving
+// excess to the miners. Returns nil if such selection hasn't been found.
+//
+// NOTE: This is port of the Bitcoin Core branch-and-bound algorithm, which
+// explores the inclusion branch of the largest outputs first.
+func selectBnB(p *coinSelectParams, utxos []*utxo) *coinSelection {
+	pool := p.sortByEffectiveValue(utxos)
+	if len(pool) == 0 {
+		return nil
+	}
+
+	target := p.target()
+	costOfChange := p.costOfChange()
+
+	var available btcutil.Amount
+	for _, u := range pool {
+		available += p.effectiveValue(u)
+	}
+
+	if available < target {
+		return nil
+	}
+
+	// If fee rate is higher than long term one, than adding the inputs
+	// only increase the waste, and branch might be cut as soon as its
+	// waste exceeds the best one.
+	isFeeRateHigh := p.inputWaste(pool[0]) > 0
+
+	var (
+		value     btcutil.Amount
+		waste     btcutil.Amount
+		selection []int
+		best      []int
+		bestWaste btcutil.Amount
+		found     bool
+	)
+
+	index := 0
+	for tries := 0; tries < bnbMaxTries; tries, index = tries+1, index+1 {
+		backtrack := false
+
+		switch {
+		case value+available < target, value > target+costOfChange,
+			found && waste > bestWaste && isFeeRateHigh:
+			backtrack = true
+
+		case value >= target:
+			excessWaste := waste + value - target
+			if !found || excessWaste <= bestWaste {
+				best = append(best[:0], selection...)
+				bestWaste = excessWaste
+				found = true
+			}
+			backtrack = true
+		}
+
+		if backtrack {
+			if len(selection) == 0 {
+				break
+			}
+
+			// Return omitted outputs back to the available value, before
+			// exploring the omission branch of the last included one.
+			last := selection[len(selection)-1]
+			for index--; index > last; index-- {
+				available += p.effectiveValue(pool[index])
+			}
+
+			value -= p.effectiveValue(pool[index])
+			waste -= p.inputWaste(pool[index])
+			selection = selection[:len(selection)-1]
+			continue
+		}
+
+		u := pool[index]
+		available -= p.effectiveValue(u)
+
+		// Skip inclusion if previous output is the same and was
+		// excluded, because this branch has been already explored.
+		if len(selection) == 0 || selection[len(selection)-1] == index-1 ||
+			p.effectiveValue(u) != p.effectiveValue(pool[index-1]) ||
+			u.inputWeight != pool[index-1].inputWeight {
+
+			selection = append(selection, index)
+			value += p.effectiveValue(u)
+			waste += p.inputWaste(u)
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	inputs := make([]*utxo, len(best))
+	for i, index := range best {
+		inputs[i] = pool[index]
+	}
+
+	return p.newSelection(inputs, false)
+}
+
+// selectLargestFirst selects the largest outputs until they are enough to
+// pay the amount, fee and create change output. If change is lower than
+// the dust limit it is given to the miners.
+func selectLargestFirst(p *coinSelectParams, utxos []*utxo) (*coinSelection,
+	error) {
+
+	pool := p.sortByEffectiveValue(utxos)
+	target := p.target()
+
+	var inputs []*utxo
+	var effective btcutil.Amount
+	for _, u := range pool {
+		inputs = append(inputs, u)
+		effective += p.effectiveValue(u)
+
+		if effective >= target+p.changeFee()+p.dustLimit {
+			return p.newSelection(inputs, true), nil
+		}
+	}
+
+	// Outputs are not enough to create the change, but still might be
+	// enough to pay the amount.
+	if effective >= target {
+		return p.newSelection(inputs, false), nil
+	}
+
+	var available btcutil.Amount
+	for _, u := range utxos {
+		available += u.amount
+	}
+
+	return nil, &ErrInsufficientFunds{
+		amountNeeded:    p.amount + feeForWeight(p.baseWeight, p.feeRate),
+		amountAvailable: available,
+	}
+}
+
+// selectCoins performs coin selection trying to find changeless solution
+// with branch-and-bound algorithm first, and falling back to largest first
+// selection. Out of found selections the one with the least waste is
+// returned.
+func selectCoins(p *coinSelectParams, utxos []*utxo) (*coinSelection, error) {
+	fallback, err := selectLargestFirst(p, utxos)
+	if err != nil {
+		return nil, err
+	}
+
+	if bnb := selectBnB(p, utxos); bnb != nil && bnb.waste <= fallback.waste {
+		return bnb, nil
+	}
+
+	return fallback, nil
+}
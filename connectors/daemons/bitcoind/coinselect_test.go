@@ -0,0 +1,288 @@
+package bitcoind
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+const testInputWeight = 100 * witnessScaleFactor
+
+func newTestParams(amount btcutil.Amount) *coinSelectParams {
+	return &coinSelectParams{
+		amount:             amount,
+		feeRate:            1,
+		longTermFeeRate:    1,
+		baseWeight:         10 * witnessScaleFactor,
+		changeOutputWeight: 34 * witnessScaleFactor,
+		changeSpendWeight:  148 * witnessScaleFactor,
+		dustLimit:          546,
+	}
+}
+
+func newTestUtxos(amounts ...btcutil.Amount) []*utxo {
+	utxos := make([]*utxo, len(amounts))
+	for i, amount := range amounts {
+		// Outputs of the same transaction are used to ensure that they
+		// are distinguished by the index.
+		utxos[i] = &utxo{
+			outPoint:    *wire.NewOutPoint(&chainhash.Hash{}, uint32(i)),
+			amount:      amount,
+			inputWeight: testInputWeight,
+		}
+	}
+	return utxos
+}
+
+func selectionTotal(s *coinSelection) btcutil.Amount {
+	var total btcutil.Amount
+	for _, u := range s.inputs {
+		total += u.amount
+	}
+	return total
+}
+
+func TestSelectBnBExactMatch(t *testing.T) {
+	p := newTestParams(10000)
+	utxos := newTestUtxos(3100, 5100, 20100, 5110)
+
+	selection := selectBnB(p, utxos)
+	if selection == nil {
+		t.Fatalf("changeless solution hasn't been found")
+	}
+
+	if len(selection.inputs) != 2 {
+		t.Fatalf("wrong number of inputs: %v", len(selection.inputs))
+	}
+
+	if selection.change != 0 {
+		t.Fatalf("wrong change: %v", selection.change)
+	}
+
+	if selectionTotal(selection) != 10210 {
+		t.Fatalf("wrong inputs selected: %v", selectionTotal(selection))
+	}
+
+	if selection.fee != 210 {
+		t.Fatalf("wrong fee: %v", selection.fee)
+	}
+
+	if selection.waste != 0 {
+		t.Fatalf("wrong waste: %v", selection.waste)
+	}
+}
+
+func TestSelectBnBNoSolution(t *testing.T) {
+	p := newTestParams(10000)
+
+	// Outputs are enough to pay the amount, but any combination leaves
+	// too much excess.
+	if selectBnB(p, newTestUtxos(30000, 50000)) != nil {
+		t.Fatalf("solution shouldn't be found")
+	}
+
+	// Outputs aren't enough to pay the amount.
+	if selectBnB(p, newTestUtxos(1000, 2000)) != nil {
+		t.Fatalf("solution shouldn't be found")
+	}
+}
+
+func TestSelectCoinsWithChange(t *testing.T) {
+	p := newTestParams(10000)
+	utxos := newTestUtxos(30000, 50000, 200)
+
+	selection, err := selectCoins(p, utxos)
+	if err != nil {
+		t.Fatalf("unable to select coins: %v", err)
+	}
+
+	if len(selection.inputs) != 1 || selection.inputs[0].amount != 50000 {
+		t.Fatalf("largest output should be selected")
+	}
+
+	// Fee is paid for base transaction, one input and change output.
+	if selection.fee != 10+100+34 {
+		t.Fatalf("wrong fee: %v", selection.fee)
+	}
+
+	if selection.change != 50000-10000-selection.fee {
+		t.Fatalf("wrong change: %v", selection.change)
+	}
+}
+
+func TestSelectCoinsDustChange(t *testing.T) {
+	p := newTestParams(10000)
+	utxos := newTestUtxos(10400)
+
+	selection, err := selectCoins(p, utxos)
+	if err != nil {
+		t.Fatalf("unable to select coins: %v", err)
+	}
+
+	if selection.change != 0 {
+		t.Fatalf("dust change shouldn't be created: %v", selection.change)
+	}
+
+	if selection.fee != 400 {
+		t.Fatalf("wrong fee: %v", selection.fee)
+	}
+}
+
+func TestSelectCoinsInsufficientFunds(t *testing.T) {
+	p := newTestParams(10000)
+
+	// Last output is cheaper than the fee paid for spending it.
+	utxos := newTestUtxos(5000, 4000, 50)
+
+	_, err := selectCoins(p, utxos)
+	if _, ok := err.(*ErrInsufficientFunds); !ok {
+		t.Fatalf("wrong error: %v", err)
+	}
+}
+
+func TestSelectCoinsPreferLowWaste(t *testing.T) {
+	p := newTestParams(10000)
+
+	// Fee rate is higher than long term one, so changeless solution with
+	// the lower number of inputs should be preferred.
+	p.feeRate = 2
+	utxos := newTestUtxos(6000, 4220, 10220, 3000, 1220)
+
+	selection, err := selectCoins(p, utxos)
+	if err != nil {
+		t.Fatalf("unable to select coins: %v", err)
+	}
+
+	if len(selection.inputs) != 1 || selection.inputs[0].amount != 10220 {
+		t.Fatalf("wrong inputs selected")
+	}
+
+	if selection.change != 0 {
+		t.Fatalf("wrong change: %v", selection.change)
+	}
+}
+
+// TestSelectBnBExhaustive compares branch-and-bound with the brute force
+// search of the changeless solution with minimal waste.
+func TestSelectBnBExhaustive(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 300; i++ {
+		p := newTestParams(btcutil.Amount(r.Int63n(50000) + 1000))
+		p.feeRate = btcutil.Amount(r.Int63n(5) + 1)
+		p.longTermFeeRate = btcutil.Amount(r.Int63n(5) + 1)
+
+		utxos := newTestUtxos()
+		for j := 0; j < r.Intn(10)+1; j++ {
+			utxos = append(utxos, &utxo{
+				outPoint:    *wire.NewOutPoint(&chainhash.Hash{}, uint32(j)),
+				amount:      btcutil.Amount(r.Int63n(30000) + 1),
+				inputWeight: testInputWeight,
+			})
+		}
+
+		target := p.target()
+		costOfChange := p.costOfChange()
+
+		var found bool
+		var bestWaste btcutil.Amount
+		for mask := 1; mask < 1<<uint(len(utxos)); mask++ {
+			var inputs []*utxo
+			var effective btcutil.Amount
+			for j, u := range utxos {
+				if mask&(1<<uint(j)) != 0 && p.effectiveValue(u) > 0 {
+					inputs = append(inputs, u)
+					effective += p.effectiveValue(u)
+				}
+			}
+
+			if len(inputs) == 0 || effective < target ||
+				effective > target+costOfChange {
+				continue
+			}
+
+			waste := p.newSelection(inputs, false).waste
+			if !found || waste < bestWaste {
+				bestWaste = waste
+				found = true
+			}
+		}
+
+		selection := selectBnB(p, utxos)
+		if !found {
+			if selection != nil {
+				t.Fatalf("case %v: unexpected solution found", i)
+			}
+			continue
+		}
+
+		if selection == nil {
+			t.Fatalf("case %v: solution hasn't been found", i)
+		}
+
+		if selection.waste != bestWaste {
+			t.Fatalf("case %v: wrong waste, expected %v, got %v", i,
+				bestWaste, selection.waste)
+		}
+
+		if selectionTotal(selection) != p.amount+selection.fee {
+			t.Fatalf("case %v: selection isn't balanced", i)
+		}
+	}
+}
+
+func TestSelectCoinsDoge(t *testing.T) {
+	c := &Connector{cfg: &Config{Asset: connectors.DOGE}}
+
+	if c.dustLimit() != 1000000 {
+		t.Fatalf("wrong dust limit: %v", c.dustLimit())
+	}
+
+	if c.longTermFeeRate() != 1000 {
+		t.Fatalf("wrong long term fee rate: %v", c.longTermFeeRate())
+	}
+
+	p := newTestParams(100000000)
+	p.feeRate = 1000
+	p.longTermFeeRate = c.longTermFeeRate()
+	p.dustLimit = c.dustLimit()
+
+	// Fee of the transaction with one input and change output.
+	fee := btcutil.Amount((10 + 100 + 34) * 1000)
+
+	// Change which is lower than 0.01 DOGE is dust, so it is given to the
+	// miners instead.
+	utxos := newTestUtxos(100000000 + fee + 900000)
+
+	selection, err := selectCoins(p, utxos)
+	if err != nil {
+		t.Fatalf("unable to select coins: %v", err)
+	}
+
+	if selection.change != 0 {
+		t.Fatalf("dust change shouldn't be created: %v", selection.change)
+	}
+
+	// Change above the dust limit is returned.
+	utxos = newTestUtxos(100000000 + fee + 5000000)
+
+	selection, err = selectCoins(p, utxos)
+	if err != nil {
+		t.Fatalf("unable to select coins: %v", err)
+	}
+
+	if selection.fee != fee || selection.change != 5000000 {
+		t.Fatalf("wrong fee(%v) or change(%v)", selection.fee,
+			selection.change)
+	}
+
+	// Bitcoin parameters are left intact.
+	c.cfg.Asset = connectors.BTC
+	if c.dustLimit() != 546 || c.longTermFeeRate() != 10 {
+		t.Fatalf("wrong bitcoin parameters")
+	}
+}
@@ -0,0 +1,390 @@
+package bitcoind
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/btcjson"
+	"github.com/bitlum/connector/metrics"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/psbt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/go-errors/errors"
+)
+
+// A compile time check to ensure Connector implements the
+// ColdSigningConnector interface.
+var _ connectors.ColdSigningConnector = (*Connector)(nil)
+
+// isColdSpendable returns whether the output could be spent by the
+// externally signed transaction. Only P2WPKH outputs, either native or
+// nested in P2SH, are spent, because their signatures aren't part of the
+// transaction id, and because of that transaction id is known before
+// transaction is signed.
+func isColdSpendable(scriptPubKey, redeemScript string) bool {
+	script, err := hex.DecodeString(scriptPubKey)
+	if err != nil {
+		return false
+	}
+
+	switch txscript.GetScriptClass(script) {
+	case txscript.WitnessV0PubKeyHashTy:
+		return true
+
+	case txscript.ScriptHashTy:
+		redeem, err := hex.DecodeString(redeemScript)
+		if err != nil {
+			return false
+		}
+
+		return txscript.GetScriptClass(redeem) ==
+			txscript.WitnessV0PubKeyHashTy
+	}
+
+	return false
+}
+
+// unsignedTxID returns the id which transaction will have after it has been
+// signed. Signature script of the nested P2WPKH input consists only of
+// redeem script, and signatures are placed in the witness which isn't part
+// of the id.
+func unsignedTxID(packet *psbt.Packet) (chainhash.Hash, error) {
+	tx := packet.UnsignedTx.Copy()
+
+	for i, input := range packet.Inputs {
+		if input.RedeemScript == nil {
+			continue
+		}
+
+		scriptSig, err := txscript.NewScriptBuilder().
+			AddData(input.RedeemScript).Script()
+		if err != nil {
+			return chainhash.Hash{}, err
+		}
+		tx.TxIn[i].SignatureScript = scriptSig
+	}
+
+	return tx.TxHash(), nil
+}
+
+// decodePSBT decodes base64 encoded BIP174 transaction.
+func decodePSBT(encoded string) (*psbt.Packet, error) {
+	return psbt.NewFromRawBytes(strings.NewReader(encoded), true)
+}
+
+// createPSBT creates unsigned BIP174 transaction, and returns it in the
+// base64 encoding along with the id which transaction will have after it
+// has been signed. Only witness outputs are spent, so inputs carry the
+// spent output itself rather than the whole previous transaction.
+func (c *Connector) createPSBT(tx *wire.MsgTx,
+	prevOutputs map[wire.OutPoint]btcjson.ListUnspentResult) (string,
+	string, error) {
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return "", "", err
+	}
+
+	for i, txIn := range tx.TxIn {
+		u, ok := prevOutputs[txIn.PreviousOutPoint]
+		if !ok {
+			return "", "", errors.Errorf("unknown input(%v)",
+				txIn.PreviousOutPoint)
+		}
+
+		pkScript, err := hex.DecodeString(u.ScriptPubKey)
+		if err != nil {
+			return "", "", errors.Errorf("unable to decode script: %v", err)
+		}
+
+		amount, err := btcutil.NewAmount(u.Amount)
+		if err != nil {
+			return "", "", errors.Errorf("unable to decode amount: %v", err)
+		}
+
+		input := &packet.Inputs[i]
+		input.WitnessUtxo = wire.NewTxOut(int64(amount), pkScript)
+
+		if u.RedeemScript != "" {
+			input.RedeemScript, err = hex.DecodeString(u.RedeemScript)
+			if err != nil {
+				return "", "", errors.Errorf("unable to decode redeem "+
+					"script: %v", err)
+			}
+		}
+	}
+
+	txID, err := unsignedTxID(packet)
+	if err != nil {
+		return "", "", err
+	}
+
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return "", "", err
+	}
+
+	return encoded, txID.String(), nil
+}
+
+// reservedOutPoints returns outputs which are spent by the waiting payments
+// which haven't been signed or sent yet. Such outputs shouldn't be used by
+// other payments, as far as the payments might be finalized at any time.
+func (c *Connector) reservedOutPoints() (map[wire.OutPoint]struct{}, error) {
+	payments, err := c.cfg.PaymentStore.ListPayments(c.cfg.Asset,
+		connectors.Waiting, "", connectors.Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := make(map[wire.OutPoint]struct{})
+	for _, payment := range payments {
+		var txIns []*wire.TxIn
+
+		switch details := payment.Detail.(type) {
+		case *connectors.UnsignedTxDetails:
+			packet, err := decodePSBT(details.PSBT)
+			if err != nil {
+				return nil, errors.Errorf("unable to decode psbt of "+
+					"payment(%v): %v", payment.PaymentID, err)
+			}
+			txIns = packet.UnsignedTx.TxIn
+
+		case *connectors.GeneratedTxDetails:
+			tx, err := c.decodeTx(details.RawTx)
+			if err != nil {
+				return nil, errors.Errorf("unable to decode tx of "+
+					"payment(%v): %v", payment.PaymentID, err)
+			}
+			txIns = tx.TxIn
+
+		default:
+			continue
+		}
+
+		for _, txIn := range txIns {
+			reserved[txIn.PreviousOutPoint] = struct{}{}
+		}
+	}
+
+	return reserved, nil
+}
+
+// verifyInputs ensures that all inputs of the signed transaction have
+// valid signatures.
+func verifyInputs(tx *wire.MsgTx, packet *psbt.Packet) error {
+	sigHashes := txscript.NewTxSigHashes(tx)
+
+	for i := range tx.TxIn {
+		prevOutput := packet.Inputs[i].WitnessUtxo
+		if prevOutput == nil {
+			return errors.Errorf("input(%v): previous output is unknown", i)
+		}
+
+		vm, err := txscript.NewEngine(prevOutput.PkScript, tx, i,
+			txscript.StandardVerifyFlags, nil, sigHashes, prevOutput.Value)
+		if err != nil {
+			return errors.Errorf("input(%v): %v", i, err)
+		}
+
+		if err := vm.Execute(); err != nil {
+			return errors.Errorf("input(%v) has invalid signature: %v",
+				i, err)
+		}
+	}
+
+	return nil
+}
+
+// finalizePSBT finalizes the externally signed transaction, and extracts
+// it. Signed transaction should match the created one, and previous outputs
+// are taken from the created transaction, so that signatures are verified
+// against the outputs which we actually spend.
+func finalizePSBT(created, signed *psbt.Packet) (*wire.MsgTx, error) {
+	// Signed transaction should spend the same inputs and pay to the same
+	// outputs, as the created one.
+	var createdTx, signedTx bytes.Buffer
+	if err := created.UnsignedTx.SerializeNoWitness(&createdTx); err != nil {
+		return nil, err
+	}
+	if err := signed.UnsignedTx.SerializeNoWitness(&signedTx); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(createdTx.Bytes(), signedTx.Bytes()) {
+		return nil, errors.New("signed transaction doesn't match " +
+			"created transaction")
+	}
+
+	for i := range signed.Inputs {
+		input := &signed.Inputs[i]
+		input.WitnessUtxo = created.Inputs[i].WitnessUtxo
+		input.NonWitnessUtxo = nil
+		if input.FinalScriptSig == nil && input.FinalScriptWitness == nil {
+			input.RedeemScript = created.Inputs[i].RedeemScript
+		}
+	}
+
+	if err := psbt.MaybeFinalizeAll(signed); err != nil {
+		return nil, errors.Errorf("unable to finalize psbt: %v", err)
+	}
+
+	tx, err := psbt.Extract(signed)
+	if err != nil {
+		return nil, errors.Errorf("unable to extract transaction: %v", err)
+	}
+
+	if err := verifyInputs(tx, signed); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// FinalizePayment takes base64 encoded BIP174 transaction signed
+// externally, ensures that it matches the created payment, and sends it to
+// the blockchain network.
+//
+// NOTE: Part of the connectors.ColdSigningConnector interface.
+func (c *Connector) FinalizePayment(paymentID,
+	signedPSBT string) (*connectors.Payment, error) {
+
+	m := crypto.NewMetric(c.cfg.DaemonCfg.Name, string(c.cfg.Asset),
+		MethodFinalizePayment, c.cfg.Metrics)
+	defer m.Finish()
+
+	payment, err := c.cfg.PaymentStore.PaymentByID(paymentID)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable find payment(%v): %v", paymentID,
+			err)
+	}
+
+	details, ok := payment.Detail.(*connectors.UnsignedTxDetails)
+	if !ok || payment.Status != connectors.Waiting {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("payment(%v) isn't waiting to be "+
+			"signed", paymentID)
+	}
+
+	created, err := decodePSBT(details.PSBT)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to decode created psbt: %v", err)
+	}
+
+	signed, err := decodePSBT(signedPSBT)
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("unable to decode signed psbt: %v", err)
+	}
+
+	tx, err := finalizePSBT(created, signed)
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	if tx.TxHash().String() != details.TxID {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("signed transaction id(%v) doesn't "+
+			"match payment transaction id(%v)", tx.TxHash(), details.TxID)
+	}
+
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable serialize signed tx: %v", err)
+	}
+
+	if _, err := c.client.SendRawTransaction(tx, true); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to send payment(%v): %v",
+			paymentID, err)
+	}
+
+	payment.Status = connectors.Pending
+	payment.UpdatedAt = connectors.NowInMilliSeconds()
+	payment.Detail = &connectors.BlockchainPendingDetails{
+		ConfirmationsLeft: int64(c.cfg.MinConfirmations),
+		RawTx:             rawTx.Bytes(),
+	}
+
+	if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+		m.AddError(metrics.HighSeverity)
+		c.log.Errorf("unable update payment(%v) status to pending: %v",
+			paymentID, err)
+	}
+
+	c.log.Infof("Finalize payment %v", spew.Sdump(payment))
+
+	return payment, nil
+}
+
+// CancelPayment fails the payment, which is waiting to be signed
+// externally, and releases its inputs, so that they could be used by other
+// payments.
+//
+// NOTE: Part of the connectors.ColdSigningConnector interface.
+func (c *Connector) CancelPayment(paymentID string) (*connectors.Payment,
+	error) {
+
+	m := crypto.NewMetric(c.cfg.DaemonCfg.Name, string(c.cfg.Asset),
+		MethodCancelPayment, c.cfg.Metrics)
+	defer m.Finish()
+
+	payment, err := c.cfg.PaymentStore.PaymentByID(paymentID)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable find payment(%v): %v", paymentID,
+			err)
+	}
+
+	details, ok := payment.Detail.(*connectors.UnsignedTxDetails)
+	if !ok || payment.Status != connectors.Waiting {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("payment(%v) isn't waiting to be "+
+			"signed", paymentID)
+	}
+
+	created, err := decodePSBT(details.PSBT)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to decode created psbt: %v", err)
+	}
+
+	var rawTx bytes.Buffer
+	if err := created.UnsignedTx.Serialize(&rawTx); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable serialize unsigned tx: %v", err)
+	}
+
+	payment.Status = connectors.Failed
+	payment.UpdatedAt = connectors.NowInMilliSeconds()
+	payment.Detail = &connectors.BlockchainFailedDetails{
+		Reason: connectors.FailureReasonCancelled,
+	}
+
+	if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable update payment(%v) status to "+
+			"failed: %v", paymentID, err)
+	}
+
+	// Payment is already failed, and its inputs are not reserved anymore,
+	// so they will be unlocked on the next coin selection anyway.
+	if err := c.releaseInputs(rawTx.Bytes()); err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		c.log.Errorf("unable to release inputs of payment(%v): %v",
+			paymentID, err)
+	}
+
+	c.log.Infof("Cancel payment %v", spew.Sdump(payment))
+
+	return payment, nil
+}
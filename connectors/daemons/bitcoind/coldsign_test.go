@@ -0,0 +1,310 @@
+package bitcoind
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/rpcclient"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btclog"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/psbt"
+)
+
+func TestIsColdSpendable(t *testing.T) {
+	const (
+		p2wpkh = "00141d0f172a0ecb48aee1be1f2687d2963ae33f71a1"
+		p2sh   = "a914f815b036d9bbbce5e9f2a00abd1bf3dc91e9551087"
+		p2pkh  = "76a9141d0f172a0ecb48aee1be1f2687d2963ae33f71a188ac"
+	)
+
+	tests := []struct {
+		name         string
+		scriptPubKey string
+		redeemScript string
+		spendable    bool
+	}{
+		{
+			name:         "P2WPKH",
+			scriptPubKey: p2wpkh,
+			spendable:    true,
+		},
+		{
+			name:         "P2SH nested P2WPKH",
+			scriptPubKey: p2sh,
+			redeemScript: p2wpkh,
+			spendable:    true,
+		},
+		{
+			name:         "P2SH multisig",
+			scriptPubKey: p2sh,
+			redeemScript: "5121021d0f172a0ecb48aee1be1f2687d2963ae33f71a1" +
+				"1d0f172a0ecb48aee1be1f2687d2963ae351ae",
+			spendable: false,
+		},
+		{
+			name:         "P2PKH",
+			scriptPubKey: p2pkh,
+			spendable:    false,
+		},
+		{
+			name:         "invalid script",
+			scriptPubKey: "zz",
+			spendable:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spendable := isColdSpendable(test.scriptPubKey, test.redeemScript)
+			if spendable != test.spendable {
+				t.Fatalf("wrong result, expected: %v, got: %v",
+					test.spendable, spendable)
+			}
+		})
+	}
+}
+
+func TestFinalizePSBT(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+
+	witnessProgram := append([]byte{0x00, 0x14}, btcutil.Hash160(pubKey)...)
+	nestedAddress, err := btcutil.NewAddressScriptHash(witnessProgram,
+		&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	nestedScript, err := txscript.PayToAddrScript(nestedAddress)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		pkScript     []byte
+		redeemScript []byte
+	}{
+		{
+			name:     "P2WPKH",
+			pkScript: witnessProgram,
+		},
+		{
+			name:         "P2SH nested P2WPKH",
+			pkScript:     nestedScript,
+			redeemScript: witnessProgram,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prevOutput := wire.NewTxOut(100000, test.pkScript)
+
+			tx := wire.NewMsgTx(wire.TxVersion)
+			tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0),
+				nil, nil))
+			tx.AddTxOut(wire.NewTxOut(99000, witnessProgram))
+
+			created, err := psbt.NewFromUnsignedTx(tx)
+			if err != nil {
+				t.Fatalf("unable to create psbt: %v", err)
+			}
+			created.Inputs[0].WitnessUtxo = prevOutput
+			created.Inputs[0].RedeemScript = test.redeemScript
+
+			txID, err := unsignedTxID(created)
+			if err != nil {
+				t.Fatalf("unable to get tx id: %v", err)
+			}
+
+			// Signer returns the signature without the scripts of the
+			// input, those are taken from the created transaction.
+			sig, err := txscript.RawTxInWitnessSignature(tx,
+				txscript.NewTxSigHashes(tx), 0, prevOutput.Value,
+				witnessProgram, txscript.SigHashAll, privKey)
+			if err != nil {
+				t.Fatalf("unable to sign: %v", err)
+			}
+
+			signed, err := psbt.NewFromUnsignedTx(tx.Copy())
+			if err != nil {
+				t.Fatalf("unable to create psbt: %v", err)
+			}
+			signed.Inputs[0].PartialSigs = []*psbt.PartialSig{{
+				PubKey:    pubKey,
+				Signature: sig,
+			}}
+
+			signedTx, err := finalizePSBT(created, signed)
+			if err != nil {
+				t.Fatalf("unable to finalize psbt: %v", err)
+			}
+
+			if signedTx.TxHash() != txID {
+				t.Fatalf("signed transaction id(%v) doesn't match "+
+					"unsigned transaction id(%v)", signedTx.TxHash(), txID)
+			}
+
+			// Signature made for the other output shouldn't be accepted.
+			other, _ := psbt.NewFromUnsignedTx(tx.Copy())
+			other.Inputs[0].PartialSigs = signed.Inputs[0].PartialSigs
+			otherCreated, _ := psbt.NewFromUnsignedTx(tx.Copy())
+			otherCreated.Inputs[0].WitnessUtxo = wire.NewTxOut(200000,
+				test.pkScript)
+			otherCreated.Inputs[0].RedeemScript = test.redeemScript
+			if _, err := finalizePSBT(otherCreated, other); err == nil {
+				t.Fatalf("invalid signature is accepted")
+			}
+		})
+	}
+}
+
+func TestCancelPayment(t *testing.T) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 1), nil,
+		nil))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatalf("unable to create psbt: %v", err)
+	}
+
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		t.Fatalf("unable to encode psbt: %v", err)
+	}
+
+	// Input of the payment is locked by the daemon, along with the input of
+	// some other payment, which should stay locked.
+	var unlocked []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID     uint64            `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("unable to decode request: %v", err)
+				return
+			}
+
+			var result interface{}
+			switch request.Method {
+			case "listlockunspent":
+				result = []interface{}{
+					map[string]interface{}{
+						"txid": tx.TxIn[0].PreviousOutPoint.Hash.String(),
+						"vout": 1,
+					},
+					map[string]interface{}{
+						"txid": chainhash.Hash{2}.String(),
+						"vout": 0,
+					},
+				}
+
+			case "lockunspent":
+				var unlock bool
+				json.Unmarshal(request.Params[0], &unlock)
+				if !unlock {
+					t.Errorf("outputs are locked instead of unlocking")
+				}
+
+				json.Unmarshal(request.Params[1], &unlocked)
+				result = true
+
+			// Unspent outputs are refreshed after the inputs are released.
+			case "listunspent":
+				result = []interface{}{}
+
+			default:
+				t.Errorf("unexpected request: %v", request.Method)
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":     request.ID,
+				"result": result,
+				"error":  nil,
+			})
+		}))
+	defer server.Close()
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	defer client.Shutdown()
+
+	store := mock.NewPaymentsStore()
+	store.Payments["payment"] = &connectors.Payment{
+		PaymentID: "payment",
+		Status:    connectors.Waiting,
+		Direction: connectors.Outgoing,
+		Asset:     connectors.BTC,
+		Media:     connectors.Blockchain,
+		Detail: &connectors.UnsignedTxDetails{
+			PSBT: encoded,
+			TxID: tx.TxHash().String(),
+		},
+	}
+
+	c := &Connector{
+		cfg: &Config{
+			DaemonCfg:    &DaemonConfig{Name: "bitcoind"},
+			Asset:        connectors.BTC,
+			Metrics:      &crypto.EmptyBackend{},
+			PaymentStore: store,
+		},
+		client: &ExtendedRPCClient{Client: client},
+		log: &connectors.NamedLogger{
+			Name:   "BTC",
+			Logger: btclog.Disabled,
+		},
+	}
+
+	payment, err := c.CancelPayment("payment")
+	if err != nil {
+		t.Fatalf("unable to cancel payment: %v", err)
+	}
+
+	if payment.Status != connectors.Failed {
+		t.Fatalf("wrong payment status: %v", payment.Status)
+	}
+
+	details, ok := payment.Detail.(*connectors.BlockchainFailedDetails)
+	if !ok || details.Reason != connectors.FailureReasonCancelled {
+		t.Fatalf("wrong payment details: %v", payment.Detail)
+	}
+
+	if len(unlocked) != 1 ||
+		unlocked[0]["txid"] != tx.TxIn[0].PreviousOutPoint.Hash.String() {
+		t.Fatalf("wrong outputs are unlocked: %v", unlocked)
+	}
+
+	// Cancelled payment couldn't be cancelled or finalized again.
+	if _, err := c.CancelPayment("payment"); err == nil {
+		t.Fatalf("cancelled payment is cancelled again")
+	}
+
+	if _, err := c.FinalizePayment("payment", encoded); err == nil {
+		t.Fatalf("cancelled payment is finalized")
+	}
+}
@@ -0,0 +1,238 @@
+package bitcoind
+
+import (
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/btcjson"
+	"github.com/go-errors/errors"
+)
+
+// detectDoubleSpend checks whether the unconfirmed wallet transaction has
+// been double-spent, and returns the id of the replacement transaction if
+// it is known. Transaction is considered double-spent if it conflicts with
+// confirmed transaction, or if it has left the mempool and was replaced by
+// one of the conflicting transactions. Transaction which just has been
+// evicted from the mempool might be rebroadcasted, and isn't treated as
+// double-spent.
+func detectDoubleSpend(tx *WalletTransactionResult,
+	mempool map[string]struct{}) (string, bool) {
+
+	if tx.Confirmations > 0 {
+		return "", false
+	}
+
+	replacement := tx.ReplacedByTxID
+
+	// Negative number of confirmations means that transaction conflicts
+	// with the one in the main chain.
+	if tx.Confirmations < 0 {
+		if replacement == "" && len(tx.WalletConflicts) != 0 {
+			replacement = tx.WalletConflicts[0]
+		}
+		return replacement, true
+	}
+
+	if _, ok := mempool[tx.TxID]; ok {
+		return "", false
+	}
+
+	if replacement != "" {
+		return replacement, true
+	}
+
+	for _, conflict := range tx.WalletConflicts {
+		if _, ok := mempool[conflict]; ok {
+			return conflict, true
+		}
+	}
+
+	return "", false
+}
+
+// syncDoubleSpends marks pending incoming payments, which transactions have
+// been double-spent, as failed.
+func (c *Connector) syncDoubleSpends() error {
+	payments, err := c.cfg.PaymentStore.ListPayments(c.cfg.Asset,
+		connectors.Pending, connectors.Incoming, connectors.Blockchain)
+	if err != nil {
+		return errors.Errorf("unable to list pending payments: %v", err)
+	}
+
+	if len(payments) == 0 {
+		return nil
+	}
+
+	hashes, err := c.client.GetRawMempool()
+	if err != nil {
+		return errors.Errorf("unable to get mempool: %v", err)
+	}
+
+	mempool := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		mempool[hash.String()] = struct{}{}
+	}
+
+	for _, payment := range payments {
+		tx, err := c.client.GetWalletTransaction(payment.MediaID,
+			c.watchOnly())
+		if err != nil {
+			c.log.Errorf("unable to get tx(%v) of pending payment(%v): %v",
+				payment.MediaID, payment.PaymentID, err)
+			continue
+		}
+
+		replacement, doubleSpent := detectDoubleSpend(tx, mempool)
+		if !doubleSpent {
+			continue
+		}
+
+		details := &connectors.BlockchainFailedDetails{
+			Reason:          connectors.FailureReasonDoubleSpent,
+			ReplacementTxID: replacement,
+		}
+
+		if replacement != "" {
+			details.ReplacementPaymentIDs, err = c.replacementPayments(
+				replacement)
+			if err != nil {
+				return errors.Errorf("unable to get payments of "+
+					"replacement tx(%v): %v", replacement, err)
+			}
+		}
+
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		payment.Status = connectors.Failed
+		payment.Detail = details
+
+		if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+
+		c.log.Warnf("Payment(%v) has been double-spent, tx(%v), "+
+			"replacement tx(%v), replacement payments(%v)",
+			payment.PaymentID, payment.MediaID, replacement,
+			details.ReplacementPaymentIDs)
+	}
+
+	return nil
+}
+
+// failDoubleSpentSent marks the sent payment, which transaction conflicts
+// with the one in the main chain, as failed. Inputs of such transaction are
+// spent by the conflicting one, so there is nothing to release.
+func (c *Connector) failDoubleSpentSent(payment *connectors.Payment,
+	tx *WalletTransactionResult) error {
+
+	replacement, _ := detectDoubleSpend(tx, nil)
+
+	payment.UpdatedAt = connectors.NowInMilliSeconds()
+	payment.Status = connectors.Failed
+	payment.Detail = &connectors.BlockchainFailedDetails{
+		Reason:          connectors.FailureReasonDoubleSpent,
+		ReplacementTxID: replacement,
+	}
+
+	if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+		return errors.Errorf("unable to save payment(%v): %v",
+			payment.PaymentID, err)
+	}
+
+	c.log.Warnf("Sent payment(%v) has been double-spent, tx(%v), "+
+		"replacement tx(%v)", payment.PaymentID, payment.MediaID,
+		replacement)
+
+	return nil
+}
+
+// syncExpired marks pending payments, which transactions have been neither
+// in the mempool nor in the blockchain for too long, as failed. Pending
+// payments are updated on every sync while their outputs are listed by the
+// wallet, which skips unconfirmed transactions missing in the mempool. Sent
+// payments are updated while their transactions are known, and expired ones
+// are abandoned, so that their inputs could be spent again.
+func (c *Connector) syncExpired() error {
+	payments, err := connectors.ExpiredPayments(c.cfg.PaymentStore,
+		c.cfg.Asset, c.cfg.PendingExpiry)
+	if err != nil {
+		return errors.Errorf("unable to list expired payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		rawTx := connectors.SentRawTx(payment)
+
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		payment.Status = connectors.Failed
+		payment.Detail = &connectors.BlockchainFailedDetails{
+			Reason: connectors.FailureReasonExpired,
+		}
+
+		if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+
+		c.log.Warnf("Payment(%v) has expired, tx(%v)", payment.PaymentID,
+			payment.MediaID)
+
+		if rawTx != nil {
+			c.releaseSent(payment, rawTx)
+		}
+	}
+
+	return nil
+}
+
+// releaseSent abandons the wallet transaction of the expired sent payment
+// and releases its inputs. Payment is already failed, so errors are only
+// logged, transaction which isn't known by the wallet couldn't be abandoned.
+func (c *Connector) releaseSent(payment *connectors.Payment, rawTx []byte) {
+	err := c.client.AbandonTransaction(payment.MediaID)
+	rpcErr, ok := err.(*btcjson.RPCError)
+	unknown := ok && rpcErr.Code == btcjson.ErrRPCInvalidAddressOrKey
+	if err != nil && !unknown {
+		c.log.Errorf("Unable to abandon tx(%v) of payment(%v): %v",
+			payment.MediaID, payment.PaymentID, err)
+	}
+
+	if err := c.releaseInputs(rawTx); err != nil {
+		c.log.Errorf("Unable to release inputs of payment(%v): %v",
+			payment.PaymentID, err)
+	}
+}
+
+// replacementPayments returns ids of the payments, which were made to us
+// by the replacement transaction. Unconfirmed payments are saved right
+// away, so that they could be found by the returned ids.
+func (c *Connector) replacementPayments(txID string) ([]string, error) {
+	tx, err := c.client.GetWalletTransaction(txID, c.watchOnly())
+	if err != nil {
+		// Replacement doesn't pay to us, so it isn't known by the wallet.
+		if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+			rpcErr.Code == btcjson.ErrRPCInvalidAddressOrKey {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := c.syncWalletTx(txID); err != nil {
+		return nil, err
+	}
+
+	var paymentIDs []string
+	for _, detail := range tx.Details {
+		if detail.Category != "receive" {
+			continue
+		}
+
+		account, err := c.addressAccount(detail.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		payment := c.newPendingPayment(tx.TxID, detail.Address, account,
+			detail.Amount, tx.Confirmations)
+		paymentIDs = append(paymentIDs, payment.PaymentID)
+	}
+
+	return paymentIDs, nil
+}
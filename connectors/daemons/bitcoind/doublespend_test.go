@@ -0,0 +1,261 @@
+package bitcoind
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/rpcclient"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btclog"
+)
+
+func TestDetectDoubleSpend(t *testing.T) {
+	newTx := func(confirmations int64, replacedBy string,
+		conflicts ...string) *WalletTransactionResult {
+
+		return &WalletTransactionResult{
+			GetTransactionResult: btcjson.GetTransactionResult{
+				TxID:            "tx",
+				Confirmations:   confirmations,
+				WalletConflicts: conflicts,
+			},
+			ReplacedByTxID: replacedBy,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		tx          *WalletTransactionResult
+		mempool     map[string]struct{}
+		replacement string
+		doubleSpent bool
+	}{
+		{
+			name: "confirmed",
+			tx:   newTx(1, "", "conflict"),
+		},
+		{
+			name:    "in mempool",
+			tx:      newTx(0, "", "conflict"),
+			mempool: map[string]struct{}{"tx": {}, "conflict": {}},
+		},
+		{
+			name: "evicted from mempool",
+			tx:   newTx(0, ""),
+		},
+		{
+			name:        "conflicts with confirmed tx",
+			tx:          newTx(-1, "", "conflict"),
+			replacement: "conflict",
+			doubleSpent: true,
+		},
+		{
+			name:        "conflicts with unknown confirmed tx",
+			tx:          newTx(-2, ""),
+			doubleSpent: true,
+		},
+		{
+			name:        "replaced by fee",
+			tx:          newTx(0, "replacement", "replacement"),
+			mempool:     map[string]struct{}{"replacement": {}},
+			replacement: "replacement",
+			doubleSpent: true,
+		},
+		{
+			name:        "conflict in mempool",
+			tx:          newTx(0, "", "other", "conflict"),
+			mempool:     map[string]struct{}{"conflict": {}},
+			replacement: "conflict",
+			doubleSpent: true,
+		},
+		{
+			name: "conflict not in mempool",
+			tx:   newTx(0, "", "conflict"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			replacement, doubleSpent := detectDoubleSpend(test.tx,
+				test.mempool)
+
+			if doubleSpent != test.doubleSpent {
+				t.Fatalf("wrong double-spent flag: expected %v, got %v",
+					test.doubleSpent, doubleSpent)
+			}
+
+			if replacement != test.replacement {
+				t.Fatalf("wrong replacement: expected %v, got %v",
+					test.replacement, replacement)
+			}
+		})
+	}
+}
+
+func TestSyncSentPayments(t *testing.T) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(10000, []byte{0x51}))
+
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+
+	// Transaction of the conflicted payment is double-spent by the
+	// confirmed one, transaction of the dropped payment has left the
+	// mempool and couldn't be rebroadcasted.
+	var abandoned []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID     uint64            `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("unable to decode request: %v", err)
+				return
+			}
+
+			var txID string
+			if len(request.Params) != 0 {
+				json.Unmarshal(request.Params[0], &txID)
+			}
+
+			var result, rpcErr interface{}
+			switch request.Method {
+			case "gettransaction":
+				tx := map[string]interface{}{
+					"txid":          txID,
+					"confirmations": 0,
+					"details":       []interface{}{},
+				}
+				if txID == "conflicted" {
+					tx["confirmations"] = -1
+					tx["walletconflicts"] = []string{"replacement"}
+				}
+				result = tx
+
+			case "getmempoolentry":
+				if txID == "mempool" {
+					result = map[string]interface{}{}
+				} else {
+					rpcErr = map[string]interface{}{
+						"code":    btcjson.ErrRPCInvalidAddressOrKey,
+						"message": "Transaction not in mempool",
+					}
+				}
+
+			case "abandontransaction":
+				abandoned = append(abandoned, txID)
+
+			case "listlockunspent", "listunspent":
+				result = []interface{}{}
+
+			default:
+				t.Errorf("unexpected request: %v", request.Method)
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":     request.ID,
+				"result": result,
+				"error":  rpcErr,
+			})
+		}))
+	defer server.Close()
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	defer client.Shutdown()
+
+	stale := connectors.ConvertTimeToMilliSeconds(time.Now().Add(-time.Hour))
+
+	store := mock.NewPaymentsStore()
+	for _, id := range []string{"conflicted", "dropped", "mempool"} {
+		store.Payments[id] = &connectors.Payment{
+			PaymentID: id,
+			UpdatedAt: stale,
+			Status:    connectors.Pending,
+			Direction: connectors.Outgoing,
+			Asset:     connectors.BTC,
+			Media:     connectors.Blockchain,
+			MediaID:   id,
+			Detail: &connectors.BlockchainPendingDetails{
+				ConfirmationsLeft: 1,
+				RawTx:             rawTx.Bytes(),
+			},
+		}
+	}
+
+	c := &Connector{
+		cfg: &Config{
+			DaemonCfg:        &DaemonConfig{Name: "bitcoind"},
+			Asset:            connectors.BTC,
+			MinConfirmations: 1,
+			Metrics:          &crypto.EmptyBackend{},
+			PaymentStore:     store,
+			PendingExpiry:    time.Minute,
+		},
+		client: &ExtendedRPCClient{Client: client},
+		log: &connectors.NamedLogger{
+			Name:   "BTC",
+			Logger: btclog.Disabled,
+		},
+	}
+
+	if err := c.syncSent(); err != nil {
+		t.Fatalf("unable to sync sent payments: %v", err)
+	}
+
+	conflicted := store.Payments["conflicted"]
+	details, ok := conflicted.Detail.(*connectors.BlockchainFailedDetails)
+	if conflicted.Status != connectors.Failed || !ok ||
+		details.Reason != connectors.FailureReasonDoubleSpent ||
+		details.ReplacementTxID != "replacement" {
+		t.Fatalf("conflicted payment isn't failed as double-spent: %v",
+			conflicted.Detail)
+	}
+
+	if store.Payments["mempool"].UpdatedAt == stale {
+		t.Fatalf("payment in the mempool isn't updated")
+	}
+
+	if store.Payments["dropped"].UpdatedAt != stale {
+		t.Fatalf("dropped payment is updated")
+	}
+
+	if err := c.syncExpired(); err != nil {
+		t.Fatalf("unable to sync expired payments: %v", err)
+	}
+
+	dropped := store.Payments["dropped"]
+	details, ok = dropped.Detail.(*connectors.BlockchainFailedDetails)
+	if dropped.Status != connectors.Failed || !ok ||
+		details.Reason != connectors.FailureReasonExpired {
+		t.Fatalf("dropped payment isn't expired: %v", dropped.Detail)
+	}
+
+	if store.Payments["mempool"].Status != connectors.Pending {
+		t.Fatalf("payment in the mempool is expired")
+	}
+
+	if len(abandoned) != 1 || abandoned[0] != "dropped" {
+		t.Fatalf("wrong transactions are abandoned: %v", abandoned)
+	}
+}
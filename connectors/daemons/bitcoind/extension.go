@@ -1,7 +1,9 @@
 package bitcoind
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/bitlum/connector/connectors/daemons/bitcoind/rpcclient"
@@ -39,3 +41,277 @@ func ReceiveDashInfo(r rpcclient.FutureGetBlockChainInfoResult) (
 	}
 	return &chainInfo, nil
 }
+
+// AddressType is the type of address which daemon generates for the wallet.
+type AddressType string
+
+const (
+	// AddressLegacy is the P2PKH address.
+	AddressLegacy AddressType = "legacy"
+
+	// AddressP2SHSegwit is the P2WPKH address nested in P2SH.
+	AddressP2SHSegwit AddressType = "p2sh-segwit"
+
+	// AddressBech32 is the native P2WPKH address.
+	AddressBech32 AddressType = "bech32"
+)
+
+// GetNewAddressType returns new address of the given type for the account.
+// If address type is empty, daemon default type is used.
+//
+// NOTE: Address is returned as is, because rpcclient decodes it with the
+// mainnet parameters, which fails for bech32 addresses of other networks.
+func (c *ExtendedRPCClient) GetNewAddressType(account string,
+	addressType AddressType) (string, error) {
+
+	params := []interface{}{account}
+	if addressType != "" {
+		params = append(params, addressType)
+	}
+
+	res, err := c.rawRequest("getnewaddress", params...)
+	if err != nil {
+		return "", err
+	}
+
+	var address string
+	if err := json.Unmarshal(res, &address); err != nil {
+		return "", err
+	}
+
+	return address, nil
+}
+
+// WalletTransactionResult is the result of gettransaction command, which
+// includes fields missing in btcjson.GetTransactionResult.
+type WalletTransactionResult struct {
+	btcjson.GetTransactionResult
+
+	// ReplacedByTxID is the id of the transaction which replaced this one
+	// using replace-by-fee.
+	ReplacedByTxID string `json:"replaced_by_txid"`
+}
+
+// GetWalletTransaction returns the wallet transaction with the given id,
+// details of watch-only addresses are included if requested.
+func (c *ExtendedRPCClient) GetWalletTransaction(txID string,
+	watchOnly bool) (*WalletTransactionResult, error) {
+
+	res, err := c.rawRequest("gettransaction", txID, watchOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx WalletTransactionResult
+	if err := json.Unmarshal(res, &tx); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// ImportAddressLabel adds the watch-only address to the wallet and assigns
+// it to the given account, without rescanning the blockchain.
+func (c *ExtendedRPCClient) ImportAddressLabel(address, account string) error {
+	_, err := c.rawRequest("importaddress", address, account, false)
+	return err
+}
+
+// AddressLabelResult is the address of the wallet along with its account
+// or label. Account is returned by daemons which support deprecated
+// accounts API, and label by the newer ones.
+type AddressLabelResult struct {
+	Address string `json:"address"`
+	Account string `json:"account"`
+	Label   string `json:"label"`
+}
+
+// ListAddressLabels returns all addresses of the wallet, including the
+// watch-only and not used ones, along with their accounts or labels.
+func (c *ExtendedRPCClient) ListAddressLabels() ([]AddressLabelResult,
+	error) {
+
+	res, err := c.rawRequest("listreceivedbyaddress", 0, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []AddressLabelResult
+	if err := json.Unmarshal(res, &addresses); err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// AddressOwnershipResult is the part of getaddressinfo or validateaddress
+// command result, which denotes whether address belongs to the wallet.
+type AddressOwnershipResult struct {
+	IsMine      bool `json:"ismine"`
+	IsWatchOnly bool `json:"iswatchonly"`
+}
+
+// GetAddressOwnership returns information whether address belongs to the
+// wallet. Daemons which don't support getaddressinfo command return this
+// information in the result of validateaddress command, so it should be used
+// instead in case of method not found error.
+func (c *ExtendedRPCClient) GetAddressOwnership(address string,
+	legacy bool) (*AddressOwnershipResult, error) {
+
+	method := "getaddressinfo"
+	if legacy {
+		method = "validateaddress"
+	}
+
+	res, err := c.rawRequest(method, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var ownership AddressOwnershipResult
+	if err := json.Unmarshal(res, &ownership); err != nil {
+		return nil, err
+	}
+
+	return &ownership, nil
+}
+
+// rawRequest marshals the params and sends the request with the given
+// method, it is used for commands and params not supported by rpcclient.
+func (c *ExtendedRPCClient) rawRequest(method string,
+	params ...interface{}) (json.RawMessage, error) {
+
+	rawParams := make([]json.RawMessage, len(params))
+	for i, param := range params {
+		rawParam, err := json.Marshal(param)
+		if err != nil {
+			return nil, err
+		}
+		rawParams[i] = rawParam
+	}
+
+	return c.RawRequest(method, rawParams)
+}
+
+// BlockHeaderResult is the result of verbose getblockheader command. Unlike
+// btcjson.GetBlockHeaderVerboseResult number of confirmations is signed,
+// because it is negative for blocks which are not in the main chain.
+type BlockHeaderResult struct {
+	Hash          string `json:"hash"`
+	Confirmations int64  `json:"confirmations"`
+	Height        int64  `json:"height"`
+	PreviousHash  string `json:"previousblockhash"`
+}
+
+// GetBlockHeaders returns headers of the blocks with the given hashes,
+// headers are requested in one batch request.
+func (c *ExtendedRPCClient) GetBlockHeaders(hashes []string) (
+	[]*BlockHeaderResult, error) {
+
+	requests := make([]rpcclient.BatchRequest, len(hashes))
+	for i, hash := range hashes {
+		rawHash, err := json.Marshal(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		requests[i] = rpcclient.BatchRequest{
+			Method: "getblockheader",
+			Params: []json.RawMessage{rawHash},
+		}
+	}
+
+	responses, err := c.RawBatchRequest(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*BlockHeaderResult, len(responses))
+	for i, response := range responses {
+		if response.Err != nil {
+			return nil, response.Err
+		}
+
+		var header BlockHeaderResult
+		if err := json.Unmarshal(response.Result, &header); err != nil {
+			return nil, err
+		}
+		headers[i] = &header
+	}
+
+	return headers, nil
+}
+
+// ZcashBlockChainInfoResult is the result of getblockchaininfo command of
+// Zcash daemon, which includes consensus branch ids.
+type ZcashBlockChainInfoResult struct {
+	Chain  string `json:"chain"`
+	Blocks int64  `json:"blocks"`
+
+	Consensus struct {
+		// ChainTip is the hex encoded consensus branch id of the best
+		// block.
+		ChainTip string `json:"chaintip"`
+
+		// NextBlock is the hex encoded consensus branch id of the next
+		// block, at which new transaction will be mined.
+		NextBlock string `json:"nextblock"`
+	} `json:"consensus"`
+}
+
+// GetZcashBlockChainInfo returns the blockchain info of Zcash daemon.
+func (c *ExtendedRPCClient) GetZcashBlockChainInfo() (
+	*ZcashBlockChainInfoResult, error) {
+
+	res, err := c.rawRequest("getblockchaininfo")
+	if err != nil {
+		return nil, err
+	}
+
+	var info ZcashBlockChainInfoResult
+	if err := json.Unmarshal(res, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// SignZcashTransaction signs Zcash transaction with the wallet keys for the
+// network upgrade with the given consensus branch id. Returns the signed
+// transaction and whether all inputs have been signed.
+func (c *ExtendedRPCClient) SignZcashTransaction(rawTx []byte,
+	branchID uint32) ([]byte, bool, error) {
+
+	res, err := c.rawRequest("signrawtransaction", hex.EncodeToString(rawTx),
+		nil, nil, "ALL", fmt.Sprintf("%08x", branchID))
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result btcjson.SignRawTransactionResult
+	if err := json.Unmarshal(res, &result); err != nil {
+		return nil, false, err
+	}
+
+	signedTx, err := hex.DecodeString(result.Hex)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return signedTx, result.Complete, nil
+}
+
+// SendRawTransactionBytes sends the serialized transaction as is, it is
+// used for transactions which couldn't be decoded as bitcoin ones.
+func (c *ExtendedRPCClient) SendRawTransactionBytes(rawTx []byte) error {
+	_, err := c.rawRequest("sendrawtransaction", hex.EncodeToString(rawTx))
+	return err
+}
+
+// AbandonTransaction marks the wallet transaction, which is neither in the
+// mempool nor in the blockchain, as abandoned, so that its inputs could be
+// spent by other transactions.
+func (c *ExtendedRPCClient) AbandonTransaction(txID string) error {
+	_, err := c.rawRequest("abandontransaction", txID)
+	return err
+}
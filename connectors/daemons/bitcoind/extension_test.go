@@ -0,0 +1,145 @@
+package bitcoind
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/rpcclient"
+)
+
+func TestGetBlockHeaders(t *testing.T) {
+	confirmations := map[string]int64{
+		"a": 2,
+		"b": -1,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var requests []struct {
+				ID     uint64   `json:"id"`
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+				t.Errorf("unable to decode batch: %v", err)
+				return
+			}
+
+			// Reply in the reversed order to ensure that responses are
+			// matched with requests by id.
+			var responses []interface{}
+			for i := len(requests) - 1; i >= 0; i-- {
+				hash := requests[i].Params[0]
+				responses = append(responses, map[string]interface{}{
+					"id": requests[i].ID,
+					"result": &BlockHeaderResult{
+						Hash:          hash,
+						Confirmations: confirmations[hash],
+					},
+					"error": nil,
+				})
+			}
+
+			json.NewEncoder(w).Encode(responses)
+		}))
+	defer server.Close()
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	defer client.Shutdown()
+
+	c := &ExtendedRPCClient{Client: client}
+	headers, err := c.GetBlockHeaders([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unable to get headers: %v", err)
+	}
+
+	if len(headers) != 2 {
+		t.Fatalf("wrong number of headers: %v", len(headers))
+	}
+
+	for i, hash := range []string{"a", "b"} {
+		if headers[i].Hash != hash {
+			t.Fatalf("wrong header(%v) hash: %v", i, headers[i].Hash)
+		}
+
+		if headers[i].Confirmations != confirmations[hash] {
+			t.Fatalf("wrong header(%v) confirmations: %v", i,
+				headers[i].Confirmations)
+		}
+	}
+}
+
+func TestIsWalletAddressLegacy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID     uint64   `json:"id"`
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("unable to decode request: %v", err)
+				return
+			}
+
+			response := map[string]interface{}{
+				"id":     request.ID,
+				"result": nil,
+				"error":  nil,
+			}
+
+			// Emulate daemon which doesn't support getaddressinfo.
+			switch request.Method {
+			case "getaddressinfo":
+				response["error"] = map[string]interface{}{
+					"code":    -32601,
+					"message": "Method not found",
+				}
+			case "validateaddress":
+				response["result"] = &AddressOwnershipResult{
+					IsWatchOnly: request.Params[0] == "watch",
+				}
+			}
+
+			json.NewEncoder(w).Encode(response)
+		}))
+	defer server.Close()
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	defer client.Shutdown()
+
+	c := &Connector{client: &ExtendedRPCClient{Client: client}}
+
+	isWalletAddress, err := c.isWalletAddress("watch")
+	if err != nil {
+		t.Fatalf("unable to check address: %v", err)
+	}
+	if !isWalletAddress {
+		t.Fatalf("watch-only address should belong to wallet")
+	}
+
+	isWalletAddress, err = c.isWalletAddress("external")
+	if err != nil {
+		t.Fatalf("unable to check address: %v", err)
+	}
+	if isWalletAddress {
+		t.Fatalf("external address shouldn't belong to wallet")
+	}
+}
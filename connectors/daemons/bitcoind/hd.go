@@ -0,0 +1,146 @@
+package bitcoind
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/go-errors/errors"
+)
+
+const (
+	// externalChain is the index of the BIP44 chain of the receiving
+	// addresses.
+	externalChain = 0
+)
+
+// extendedKeyVersion describes the known version bytes of the extended
+// public key.
+type extendedKeyVersion struct {
+	// addressType is the type of addresses which are derived from the key.
+	addressType AddressType
+
+	// testnet denotes that key is used with test networks.
+	testnet bool
+}
+
+// extendedKeyVersions is the map of the known extended public key versions
+// and types of addresses which they imply, i.e. BIP44 (xpub), BIP49 (ypub)
+// and BIP84 (zpub), and their testnet counterparts.
+var extendedKeyVersions = map[[4]byte]extendedKeyVersion{
+	{0x04, 0x88, 0xb2, 0x1e}: {AddressLegacy, false},
+	{0x04, 0x35, 0x87, 0xcf}: {AddressLegacy, true},
+	{0x04, 0x9d, 0x7c, 0xb2}: {AddressP2SHSegwit, false},
+	{0x04, 0x4a, 0x52, 0x62}: {AddressP2SHSegwit, true},
+	{0x04, 0xb2, 0x47, 0x46}: {AddressBech32, false},
+	{0x04, 0x5f, 0x1c, 0xf6}: {AddressBech32, true},
+}
+
+// extendedPubKey is the BIP32 extended public key, which is used to derive
+// deposit addresses without having private keys on the daemon.
+type extendedPubKey struct {
+	*hdkeychain.ExtendedKey
+
+	version     [4]byte
+	addressType AddressType
+}
+
+// parseExtendedPubKey decodes base58 encoded extended public key. Type of
+// derived addresses is determined by the key version, unknown versions,
+// e.g. coin specific ones, are treated as BIP44 keys.
+func parseExtendedPubKey(key string) (*extendedPubKey, error) {
+	extendedKey, err := hdkeychain.NewKeyFromString(key)
+	if err != nil {
+		return nil, errors.Errorf("invalid extended key: %v", err)
+	}
+
+	if extendedKey.IsPrivate() {
+		return nil, errors.New("private extended key is given, only " +
+			"public one should be used")
+	}
+
+	k := &extendedPubKey{
+		ExtendedKey: extendedKey,
+		addressType: AddressLegacy,
+	}
+
+	// Key is already validated, so it is safe to take the version from
+	// the decoded key.
+	copy(k.version[:], base58.Decode(key)[:4])
+
+	if version, ok := extendedKeyVersions[k.version]; ok {
+		k.addressType = version.addressType
+	}
+
+	return k, nil
+}
+
+// isTestnet returns whether the key is known to be used with test networks.
+// For the unknown key versions ok is false.
+func (k *extendedPubKey) isTestnet() (testnet bool, ok bool) {
+	version, ok := extendedKeyVersions[k.version]
+	return version.testnet, ok
+}
+
+// child derives the non-hardened child extended public key with the given
+// index.
+func (k *extendedPubKey) child(i uint32) (*extendedPubKey, error) {
+	child, err := k.Child(i)
+	if err != nil {
+		return nil, errors.Errorf("unable to derive child key(%v): %v",
+			i, err)
+	}
+
+	return &extendedPubKey{
+		ExtendedKey: child,
+		version:     k.version,
+		addressType: k.addressType,
+	}, nil
+}
+
+// address returns the address of the key public key, with the type implied
+// by the extended key version.
+func (k *extendedPubKey) address(params *chaincfg.Params) (btcutil.Address,
+	error) {
+
+	pubKey, err := k.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+
+	switch k.addressType {
+	case AddressLegacy:
+		return btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+
+	case AddressP2SHSegwit:
+		witnessProgram := append([]byte{0x00, 0x14}, pubKeyHash...)
+		return btcutil.NewAddressScriptHash(witnessProgram, params)
+
+	case AddressBech32:
+		return btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+
+	default:
+		return nil, errors.Errorf("unsupported address type(%v)",
+			k.addressType)
+	}
+}
+
+// depositAddress derives the deposit address with the given index from the
+// BIP44 external chain of the account extended public key, i.e. from
+// the <xpub>/0/<index> path.
+func (k *extendedPubKey) depositAddress(index uint32,
+	params *chaincfg.Params) (btcutil.Address, error) {
+
+	chain, err := k.child(externalChain)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := chain.child(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.address(params)
+}
@@ -0,0 +1,127 @@
+package bitcoind
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+func TestExtendedPubKeyChild(t *testing.T) {
+	// BIP32 test vector 1, chain m/0H/1 is derived from the public m/0H.
+	parent := "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEj" +
+		"WgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"
+	child := "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3U" +
+		"FHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"
+
+	key, err := parseExtendedPubKey(parent)
+	if err != nil {
+		t.Fatalf("unable to parse key: %v", err)
+	}
+
+	if key.String() != parent {
+		t.Fatalf("wrong serialization: expected %v, got %v", parent,
+			key.String())
+	}
+
+	childKey, err := key.child(1)
+	if err != nil {
+		t.Fatalf("unable to derive child key: %v", err)
+	}
+
+	if childKey.String() != child {
+		t.Fatalf("wrong child key: expected %v, got %v", child,
+			childKey.String())
+	}
+
+	if _, err := key.child(hdkeychain.HardenedKeyStart); err == nil {
+		t.Fatalf("hardened key shouldn't be derived")
+	}
+}
+
+func TestExtendedPubKeyDepositAddress(t *testing.T) {
+	// BIP84 test vector, account m/84'/0'/0'.
+	key, err := parseExtendedPubKey("zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtym" +
+		"D9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2" +
+		"oz2AGutZYs")
+	if err != nil {
+		t.Fatalf("unable to parse key: %v", err)
+	}
+
+	if key.addressType != AddressBech32 {
+		t.Fatalf("wrong address type: %v", key.addressType)
+	}
+
+	if testnet, ok := key.isTestnet(); !ok || testnet {
+		t.Fatalf("key should be known mainnet key")
+	}
+
+	addresses := []string{
+		"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		"bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g",
+	}
+
+	for i, expected := range addresses {
+		address, err := key.depositAddress(uint32(i), &chaincfg.MainNetParams)
+		if err != nil {
+			t.Fatalf("unable to derive address: %v", err)
+		}
+
+		if address.EncodeAddress() != expected {
+			t.Fatalf("wrong address(%v): expected %v, got %v", i,
+				expected, address.EncodeAddress())
+		}
+	}
+}
+
+func TestParseExtendedPubKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		addressType AddressType
+		valid       bool
+	}{
+		{
+			name: "BIP44 public key",
+			key: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGh" +
+				"ePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+			addressType: AddressLegacy,
+			valid:       true,
+		},
+		{
+			name: "private key",
+			key: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jP" +
+				"PqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+		},
+		{
+			name: "wrong checksum",
+			key: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGh" +
+				"ePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet9",
+		},
+		{
+			name: "wrong length",
+			key:  "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8Nqtwyb",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := parseExtendedPubKey(test.key)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("key shouldn't be valid")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unable to parse key: %v", err)
+			}
+
+			if key.addressType != test.addressType {
+				t.Fatalf("wrong address type: expected %v, got %v",
+					test.addressType, key.addressType)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package bitcoind
+
+import (
+	"net"
+	"time"
+
+	"github.com/lightninglabs/gozmq"
+)
+
+const (
+	// topicHashBlock is the ZMQ topic of the new block hash notifications.
+	topicHashBlock = "hashblock"
+
+	// topicRawBlock is the ZMQ topic of the new serialized block
+	// notifications.
+	topicRawBlock = "rawblock"
+
+	// topicRawTx is the ZMQ topic of the serialized transaction
+	// notifications, which are sent when transaction enters mempool or is
+	// included in the block.
+	topicRawTx = "rawtx"
+
+	// zmqDialTimeout is the timeout of connection and subscription to the
+	// ZMQ publisher.
+	zmqDialTimeout = 10 * time.Second
+
+	// zmqReconnectDelay is the delay before reconnecting to the ZMQ
+	// publisher, after subscription has failed.
+	zmqReconnectDelay = 5 * time.Second
+)
+
+// zmqSubscription is the subscription on ZMQ topics from which
+// notifications are received.
+type zmqSubscription interface {
+	// Receive blocks until notification is received, and returns its
+	// frames.
+	Receive(bufs [][]byte) ([][]byte, error)
+
+	// Close closes the subscription and unblocks receive.
+	Close() error
+}
+
+// subscribe connects to the daemon ZMQ publisher and passes the received
+// notifications to the handler. Connection is re-established until
+// connector is stopped, in the meantime connector relies on polling.
+//
+// NOTE: Should be run as goroutine.
+func (c *Connector) subscribe(address string, topics []string,
+	handler func(topic string, body []byte)) {
+
+	defer func() {
+		c.log.Infof("Quit ZMQ subscription goroutine, address(%v)", address)
+		c.wg.Done()
+	}()
+
+	for {
+		sub, err := gozmq.Subscribe(address, topics, zmqDialTimeout)
+		if err != nil {
+			c.log.Errorf("unable to subscribe on ZMQ topics(%v), "+
+				"address(%v): %v", topics, address, err)
+		} else {
+			c.log.Infof("Subscribed on ZMQ topics(%v), address(%v)",
+				topics, address)
+			c.receiveNotifications(sub, handler)
+		}
+
+		select {
+		case <-time.After(zmqReconnectDelay):
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// receiveNotifications passes notifications to the handler until
+// subscription fails or connector is stopped.
+func (c *Connector) receiveNotifications(sub zmqSubscription,
+	handler func(topic string, body []byte)) {
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// Close subscription on connector stop, in order to unblock receive.
+	go func() {
+		select {
+		case <-c.quit:
+		case <-done:
+		}
+		sub.Close()
+	}()
+
+	for {
+		frames, err := sub.Receive(nil)
+		if err != nil {
+			select {
+			case <-c.quit:
+				return
+			default:
+			}
+
+			// Subscription re-establishes the lost connection by itself,
+			// and reports it with timeout error.
+			if err, ok := err.(net.Error); ok && err.Timeout() {
+				c.log.Warnf("ZMQ connection has been lost: %v", err)
+				continue
+			}
+
+			c.log.Errorf("unable to receive ZMQ notification: %v", err)
+			return
+		}
+
+		// Notification consists of topic, body and sequence number.
+		if len(frames) < 2 {
+			c.log.Warnf("malformed ZMQ notification, frames(%v)",
+				len(frames))
+			continue
+		}
+
+		handler(string(frames[0]), frames[1])
+	}
+}
+
+// triggerSync triggers sync, if sync has been already triggered and
+// hasn't yet started, it is skipped.
+func (c *Connector) triggerSync() {
+	select {
+	case c.syncTrigger <- struct{}{}:
+	default:
+	}
+}
+
+// handleBlockNotification triggers sync as soon as new block is received.
+func (c *Connector) handleBlockNotification(topic string, body []byte) {
+	c.log.Debugf("Received ZMQ notification, topic(%v)", topic)
+	c.triggerSync()
+}
+
+// handleTxNotification records the incoming transaction as pending, if it
+// belongs to our wallet.
+func (c *Connector) handleTxNotification(topic string, body []byte) {
+	txID, err := c.decodeTxID(body)
+	if err != nil {
+		c.log.Debugf("unable to decode ZMQ transaction: %v", err)
+		return
+	}
+
+	if err := c.syncWalletTx(txID); err != nil {
+		c.log.Errorf("unable to sync transaction(%v): %v", txID, err)
+	}
+}
@@ -0,0 +1,102 @@
+package bitcoind
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/btcsuite/btclog"
+)
+
+// timeoutError is the error returned by subscription after connection has
+// been re-established.
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+var _ net.Error = (*timeoutError)(nil)
+
+// mockSubscription is the ZMQ subscription which returns the notifications
+// sent in the channel.
+type mockSubscription struct {
+	notifications chan [][]byte
+	errors        chan error
+	quit          chan struct{}
+}
+
+func newMockSubscription() *mockSubscription {
+	return &mockSubscription{
+		notifications: make(chan [][]byte),
+		errors:        make(chan error),
+		quit:          make(chan struct{}),
+	}
+}
+
+func (s *mockSubscription) Receive(bufs [][]byte) ([][]byte, error) {
+	select {
+	case frames := <-s.notifications:
+		return frames, nil
+	case err := <-s.errors:
+		return nil, err
+	case <-s.quit:
+		return nil, io.EOF
+	}
+}
+
+func (s *mockSubscription) Close() error {
+	close(s.quit)
+	return nil
+}
+
+func TestBlockNotificationTriggersSync(t *testing.T) {
+	c := &Connector{
+		cfg:         &Config{},
+		quit:        make(chan struct{}),
+		syncTrigger: make(chan struct{}, 1),
+		log: &connectors.NamedLogger{
+			Name:   "BTC",
+			Logger: btclog.Disabled,
+		},
+	}
+
+	sub := newMockSubscription()
+	done := make(chan struct{})
+	go func() {
+		c.receiveNotifications(sub, c.handleBlockNotification)
+		close(done)
+	}()
+
+	// Lost connection is re-established by subscription, so
+	// notifications should be still received.
+	select {
+	case sub.errors <- &timeoutError{}:
+	case <-time.After(time.Second):
+		t.Fatalf("notification hasn't been received")
+	}
+
+	notification := [][]byte{[]byte(topicHashBlock), make([]byte, 32),
+		{0, 0, 0, 0}}
+	select {
+	case sub.notifications <- notification:
+	case <-time.After(time.Second):
+		t.Fatalf("notification hasn't been received")
+	}
+
+	select {
+	case <-c.syncTrigger:
+	case <-time.After(time.Second):
+		t.Fatalf("sync hasn't been triggered")
+	}
+
+	close(c.quit)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("notifications are received after connector stop")
+	}
+}
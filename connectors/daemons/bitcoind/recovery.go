@@ -0,0 +1,190 @@
+package bitcoind
+
+import (
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/go-errors/errors"
+)
+
+// recoverPayments rebroadcasts transactions of the sent payments, which
+// have been dropped by the daemon, for example after restart or mempool
+// eviction, and resolves stale waiting payments according to the policy.
+func (c *Connector) recoverPayments() error {
+	if err := c.rebroadcastSent(); err != nil {
+		return errors.Errorf("unable to rebroadcast sent payments: %v", err)
+	}
+
+	if err := c.resolveWaiting(); err != nil {
+		return errors.Errorf("unable to resolve waiting payments: %v", err)
+	}
+
+	return nil
+}
+
+// rebroadcastSent sends again transactions of the sent payments, which are
+// neither in the mempool nor in the blockchain.
+func (c *Connector) rebroadcastSent() error {
+	payments, err := connectors.SentPayments(c.cfg.PaymentStore, c.cfg.Asset)
+	if err != nil {
+		return errors.Errorf("unable to list sent payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		known, err := c.isTxKnown(payment.MediaID)
+		if err != nil {
+			return err
+		}
+
+		if known {
+			continue
+		}
+
+		// Transaction might be rejected if its inputs have been spent by
+		// other transaction, such payment will be failed as double-spent
+		// once the conflicting transaction is confirmed, or as expired
+		// otherwise, so only log the error and proceed with others.
+		err = c.broadcastTx(connectors.SentRawTx(payment))
+		if err != nil {
+			c.log.Errorf("Unable to rebroadcast payment(%v) tx(%v): %v",
+				payment.PaymentID, payment.MediaID, err)
+			continue
+		}
+
+		c.log.Warnf("Payment(%v) tx(%v) has been dropped by daemon, "+
+			"rebroadcast it", payment.PaymentID, payment.MediaID)
+	}
+
+	return nil
+}
+
+// isTxKnown returns whether transaction is known by the daemon, i.e. it is
+// either in the mempool or in the blockchain.
+func (c *Connector) isTxKnown(txID string) (bool, error) {
+	txHash, err := chainhash.NewHashFromStr(txID)
+	if err != nil {
+		return false, errors.Errorf("unable to decode tx hash(%v): %v",
+			txID, err)
+	}
+
+	tx, err := c.client.GetTransactionWatchOnly(txHash, c.watchOnly())
+	if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+		rpcErr.Code == btcjson.ErrRPCInvalidAddressOrKey {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Errorf("unable to get tx(%v): %v", txID, err)
+	}
+
+	// Wallet keeps unconfirmed transactions even if they have been
+	// dropped from the mempool, so mempool is checked as well. Negative
+	// number of confirmations means that transaction conflicts with the
+	// one in the main chain, and there is no sense to send it again.
+	if tx.Confirmations != 0 {
+		return true, nil
+	}
+
+	return c.inMempool(txID)
+}
+
+// inMempool returns whether transaction is in the mempool of the daemon.
+func (c *Connector) inMempool(txID string) (bool, error) {
+	_, err := c.client.GetMempoolEntry(txID)
+	if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+		rpcErr.Code == btcjson.ErrRPCInvalidAddressOrKey {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Errorf("unable to get mempool entry of "+
+			"tx(%v): %v", txID, err)
+	}
+
+	return true, nil
+}
+
+// resolveWaiting sends or cancels the waiting payments, which haven't been
+// sent for too long. Inputs of the cancelled payments are released, so that
+// they could be used by other payments.
+func (c *Connector) resolveWaiting() error {
+	payments, err := connectors.StaleWaitingPayments(c.cfg.PaymentStore,
+		c.cfg.Asset, c.cfg.WaitingTimeout)
+	if err != nil {
+		return errors.Errorf("unable to list stale waiting payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		details, ok := payment.Detail.(*connectors.GeneratedTxDetails)
+		if !ok {
+			continue
+		}
+
+		if c.cfg.WaitingPolicy == connectors.WaitingBroadcast {
+			// In case of failure payment is marked as failed by send, and
+			// its inputs have to be released.
+			if _, err := c.SendPayment(payment.PaymentID); err == nil {
+				c.log.Warnf("Stale waiting payment(%v) has been sent",
+					payment.PaymentID)
+				continue
+			} else {
+				c.log.Errorf("Unable to send stale waiting payment(%v): %v",
+					payment.PaymentID, err)
+			}
+		} else {
+			payment.Status = connectors.Failed
+			payment.UpdatedAt = connectors.NowInMilliSeconds()
+			payment.Detail = &connectors.BlockchainFailedDetails{
+				Reason: connectors.FailureReasonCancelled,
+			}
+
+			if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+				return errors.Errorf("unable to save payment(%v): %v",
+					payment.PaymentID, err)
+			}
+
+			c.log.Warnf("Stale waiting payment(%v) has been cancelled",
+				payment.PaymentID)
+		}
+
+		if err := c.releaseInputs(details.RawTx); err != nil {
+			return errors.Errorf("unable to release inputs of "+
+				"payment(%v): %v", payment.PaymentID, err)
+		}
+	}
+
+	return nil
+}
+
+// releaseInputs unlocks outputs spent by the transaction, which will never
+// be sent, and makes them available for the coin selection.
+func (c *Connector) releaseInputs(rawTx []byte) error {
+	tx, err := c.decodeTx(rawTx)
+	if err != nil {
+		return errors.Errorf("unable to decode tx: %v", err)
+	}
+
+	spent := make(map[wire.OutPoint]struct{}, len(tx.TxIn))
+	for _, txIn := range tx.TxIn {
+		spent[txIn.PreviousOutPoint] = struct{}{}
+	}
+
+	// Daemon keeps locks only in memory and fails to unlock output which
+	// isn't locked, so only currently locked outputs are unlocked.
+	locked, err := c.client.ListLockUnspent()
+	if err != nil {
+		return errors.Errorf("unable to list locked outputs: %v", err)
+	}
+
+	var outPoints []*wire.OutPoint
+	for _, outPoint := range locked {
+		if _, ok := spent[*outPoint]; ok {
+			outPoints = append(outPoints, outPoint)
+		}
+	}
+
+	if len(outPoints) != 0 {
+		if err := c.client.LockUnspent(true, outPoints); err != nil {
+			return errors.Errorf("unable to unlock outputs: %v", err)
+		}
+	}
+
+	return c.syncUnspent()
+}
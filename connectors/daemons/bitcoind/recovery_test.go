@@ -0,0 +1,117 @@
+package bitcoind
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/rpcclient"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btclog"
+)
+
+func TestSendCancelledPayment(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID     uint64 `json:"id"`
+				Method string `json:"method"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("unable to decode request: %v", err)
+				return
+			}
+			methods = append(methods, request.Method)
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":     request.ID,
+				"result": []interface{}{},
+				"error":  nil,
+			})
+		}))
+	defer server.Close()
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	defer client.Shutdown()
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+
+	store := mock.NewPaymentsStore()
+	store.Payments["payment"] = &connectors.Payment{
+		PaymentID: "payment",
+		UpdatedAt: connectors.ConvertTimeToMilliSeconds(
+			time.Now().Add(-time.Hour)),
+		Status:    connectors.Waiting,
+		Direction: connectors.Outgoing,
+		Asset:     connectors.BTC,
+		Media:     connectors.Blockchain,
+		Detail:    &connectors.GeneratedTxDetails{RawTx: rawTx.Bytes()},
+	}
+
+	c := &Connector{
+		cfg: &Config{
+			DaemonCfg:      &DaemonConfig{Name: "bitcoind"},
+			Asset:          connectors.BTC,
+			Metrics:        &crypto.EmptyBackend{},
+			PaymentStore:   store,
+			WaitingTimeout: time.Minute,
+			WaitingPolicy:  connectors.WaitingCancel,
+		},
+		client: &ExtendedRPCClient{Client: client},
+		log: &connectors.NamedLogger{
+			Name:   "BTC",
+			Logger: btclog.Disabled,
+		},
+	}
+
+	if err := c.resolveWaiting(); err != nil {
+		t.Fatalf("unable to resolve waiting payments: %v", err)
+	}
+
+	if store.Payments["payment"].Status != connectors.Failed {
+		t.Fatalf("stale payment isn't cancelled")
+	}
+
+	// Inputs of the cancelled payment are released, so payment shouldn't
+	// be sent after that.
+	if _, err := c.SendPayment("payment"); err == nil {
+		t.Fatalf("cancelled payment is sent")
+	}
+
+	// Payment which failed to be broadcasted keeps the transaction, but
+	// shouldn't be sent either.
+	store.Payments["payment"].Detail = &connectors.GeneratedTxDetails{
+		RawTx: rawTx.Bytes(),
+	}
+	if _, err := c.SendPayment("payment"); err == nil {
+		t.Fatalf("failed payment is sent")
+	}
+
+	for _, method := range methods {
+		if method == "sendrawtransaction" {
+			t.Fatalf("transaction of cancelled payment is broadcasted")
+		}
+	}
+}
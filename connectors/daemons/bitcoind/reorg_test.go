@@ -0,0 +1,154 @@
+package bitcoind
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/btcjson"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/rpcclient"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btclog"
+)
+
+type mockAccountStorage struct {
+	AccountsStorage
+}
+
+func (s *mockAccountStorage) GetAccountByAddress(address string) (string,
+	error) {
+	return "", nil
+}
+
+func TestHandleReorgRevertsSentPayment(t *testing.T) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(10000, []byte{0x51}))
+
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	txID := tx.TxHash().String()
+
+	// Transaction is returned in the mempool after its block has been
+	// orphaned.
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID     uint64 `json:"id"`
+				Method string `json:"method"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("unable to decode request: %v", err)
+				return
+			}
+
+			if request.Method != "gettransaction" {
+				t.Errorf("unexpected request: %v", request.Method)
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": request.ID,
+				"result": map[string]interface{}{
+					"txid":          txID,
+					"confirmations": 0,
+					"details":       []interface{}{},
+				},
+				"error": nil,
+			})
+		}))
+	defer server.Close()
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	defer client.Shutdown()
+
+	address := "bcrt1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq"
+	paymentID := generatePaymentID(txID, address, connectors.Outgoing)
+
+	store := mock.NewPaymentsStore()
+	store.Payments[paymentID] = &connectors.Payment{
+		PaymentID: paymentID,
+		Status:    connectors.Pending,
+		Direction: connectors.Outgoing,
+		Receipt:   address,
+		Asset:     connectors.BTC,
+		Media:     connectors.Blockchain,
+		MediaID:   txID,
+		Detail: &connectors.BlockchainPendingDetails{
+			RawTx: rawTx.Bytes(),
+		},
+	}
+
+	c := &Connector{
+		cfg: &Config{
+			DaemonCfg:        &DaemonConfig{Name: "bitcoind"},
+			Asset:            connectors.BTC,
+			MinConfirmations: 1,
+			Metrics:          &crypto.EmptyBackend{},
+			PaymentStore:     store,
+			AccountStorage:   &mockAccountStorage{},
+		},
+		client: &ExtendedRPCClient{Client: client},
+		log: &connectors.NamedLogger{
+			Name:   "BTC",
+			Logger: btclog.Disabled,
+		},
+	}
+
+	m := crypto.NewMetric(c.cfg.DaemonCfg.Name, string(c.cfg.Asset),
+		MethodReorg, c.cfg.Metrics)
+
+	// Transaction of the sent payment is confirmed in the block, which is
+	// orphaned later.
+	payment, err := c.confirmedPayment(btcjson.ListTransactionsResult{
+		Category: "send",
+		TxID:     txID,
+		Address:  address,
+		Amount:   -0.0001,
+	}, &BlockHeaderResult{Hash: "orphaned", Height: 100}, m)
+	if err != nil {
+		t.Fatalf("unable to confirm payment: %v", err)
+	}
+
+	if err := store.SavePayment(payment); err != nil {
+		t.Fatalf("unable to save payment: %v", err)
+	}
+
+	err = c.handleReorg(&btcjson.GetBlockVerboseResult{Hash: "fork"},
+		map[string]struct{}{"orphaned": {}})
+	if err != nil {
+		t.Fatalf("unable to handle reorg: %v", err)
+	}
+
+	reverted := store.Payments[paymentID]
+	if reverted.Status != connectors.Pending {
+		t.Fatalf("wrong status of reverted payment: %v", reverted.Status)
+	}
+
+	if !bytes.Equal(connectors.SentRawTx(reverted), rawTx.Bytes()) {
+		t.Fatalf("transaction of reverted payment is lost")
+	}
+
+	sent, err := connectors.SentPayments(store, connectors.BTC)
+	if err != nil {
+		t.Fatalf("unable to get sent payments: %v", err)
+	}
+
+	if len(sent) != 1 || sent[0].PaymentID != paymentID {
+		t.Fatalf("reverted payment isn't tracked as sent one")
+	}
+}
@@ -0,0 +1,123 @@
+package rpcclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/btcjson"
+)
+
+var (
+	// ErrBatchNotSupported is an error to describe the condition where the
+	// caller is trying to send a batch of requests when the client is not
+	// running in HTTP POST mode.
+	ErrBatchNotSupported = errors.New("batch requests are supported only " +
+		"in HTTP POST mode")
+)
+
+// BatchRequest is a raw or custom request which is sent as a part of the
+// JSON-RPC batch.
+type BatchRequest struct {
+	Method string
+	Params []json.RawMessage
+}
+
+// batchResponse is a partially-unmarshaled JSON-RPC response of the batch,
+// which is matched with the request by its ID.
+type batchResponse struct {
+	ID *uint64 `json:"id"`
+	rawResponse
+}
+
+// RawBatchRequest sends the passed requests to the server as a single
+// JSON-RPC batch, which is much faster than sending them one by one, and
+// returns the responses in the order of requests. Error of the request is
+// returned in the response, while returned error means that the whole batch
+// has failed.
+//
+// NOTE: Batch requests are supported only in HTTP POST mode.
+func (c *Client) RawBatchRequest(requests []BatchRequest) ([]*Response, error) {
+	if !c.config.HTTPPostMode {
+		return nil, ErrBatchNotSupported
+	}
+
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	rawRequests := make([]*btcjson.Request, len(requests))
+	indexes := make(map[uint64]int, len(requests))
+	for i, request := range requests {
+		// Method may not be empty.
+		if request.Method == "" {
+			return nil, errors.New("no method")
+		}
+
+		// Marshal parameters as "[]" instead of "null" when no
+		// parameters are passed.
+		params := request.Params
+		if params == nil {
+			params = []json.RawMessage{}
+		}
+
+		id := c.NextID()
+		rawRequests[i] = &btcjson.Request{
+			Jsonrpc: "1.0",
+			ID:      id,
+			Method:  request.Method,
+			Params:  params,
+		}
+		indexes[id] = i
+	}
+
+	marshalledJSON, err := json.Marshal(rawRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	responseChan := make(chan *Response, 1)
+	c.sendPost(&jsonRequest{
+		id:             rawRequests[0].ID.(uint64),
+		method:         "batch",
+		marshalledJSON: marshalledJSON,
+		responseChan:   responseChan,
+		batch:          true,
+	})
+
+	res, err := ReceiveFuture(responseChan)
+	if err != nil {
+		return nil, err
+	}
+
+	var batchResponses []batchResponse
+	if err := json.Unmarshal(res, &batchResponses); err != nil {
+		return nil, err
+	}
+
+	responses := make([]*Response, len(requests))
+	for _, batchResp := range batchResponses {
+		if batchResp.ID == nil {
+			continue
+		}
+
+		i, ok := indexes[*batchResp.ID]
+		if !ok {
+			continue
+		}
+
+		result, err := batchResp.result()
+		responses[i] = &Response{Result: result, Err: err}
+	}
+
+	for i, response := range responses {
+		if response == nil {
+			responses[i] = &Response{
+				Err: fmt.Errorf("no response for the request %v",
+					rawRequests[i].ID),
+			}
+		}
+	}
+
+	return responses, nil
+}
@@ -101,6 +101,10 @@ type jsonRequest struct {
 	cmd            interface{}
 	marshalledJSON []byte
 	responseChan   chan *Response
+
+	// batch denotes that marshalled JSON is a batch of requests, in which
+	// case the raw response is delivered as is.
+	batch bool
 }
 
 // Client represents a Bitcoin RPC client which allows easy access to the
@@ -705,6 +709,21 @@ func (c *Client) handleSendPostMessage(details *sendPostDetails) {
 		return
 	}
 
+	// Responses of the batch are matched with requests by the caller, so
+	// only ensure that reply is a batch response.
+	if jReq.batch {
+		var batchResp []json.RawMessage
+		if err := json.Unmarshal(respBytes, &batchResp); err != nil {
+			err = fmt.Errorf("status code: %d, response: %q",
+				httpResponse.StatusCode, string(respBytes))
+			jReq.responseChan <- &Response{Err: err}
+			return
+		}
+
+		jReq.responseChan <- &Response{Result: respBytes}
+		return
+	}
+
 	// Try to unmarshal the response as a regular JSON-RPC response.
 	var resp rawResponse
 	err = json.Unmarshal(respBytes, &resp)
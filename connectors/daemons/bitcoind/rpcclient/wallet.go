@@ -63,6 +63,32 @@ func (c *Client) GetTransaction(txHash *chainhash.Hash) (*btcjson.GetTransaction
 	return c.GetTransactionAsync(txHash).Receive()
 }
 
+// GetTransactionWatchOnlyAsync returns an instance of a type that can be used
+// to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetTransactionWatchOnly for the blocking version and more details.
+func (c *Client) GetTransactionWatchOnlyAsync(txHash *chainhash.Hash,
+	watchOnly bool) FutureGetTransactionResult {
+
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := btcjson.NewGetTransactionCmd(hash, &watchOnly)
+	return c.sendCmd(cmd)
+}
+
+// GetTransactionWatchOnly returns detailed information about a wallet
+// transaction, and includes the details of watch-only addresses if
+// requested.
+func (c *Client) GetTransactionWatchOnly(txHash *chainhash.Hash,
+	watchOnly bool) (*btcjson.GetTransactionResult, error) {
+
+	return c.GetTransactionWatchOnlyAsync(txHash, watchOnly).Receive()
+}
+
 // FutureListTransactionsResult is a future promise to deliver the result of a
 // ListTransactionsAsync, ListTransactionsCountAsync, or
 // ListTransactionsCountFromAsync RPC invocation (or an applicable error).
@@ -312,6 +338,34 @@ func (c *Client) ListSinceBlockMinConf(blockHash *chainhash.Hash, minConfirms in
 	return c.ListSinceBlockMinConfAsync(blockHash, minConfirms).Receive()
 }
 
+// ListSinceBlockWatchOnlyAsync returns an instance of a type that can be used
+// to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See ListSinceBlockWatchOnly for the blocking version and more details.
+func (c *Client) ListSinceBlockWatchOnlyAsync(blockHash *chainhash.Hash,
+	minConfirms int, watchOnly bool) FutureListSinceBlockResult {
+
+	var hash *string
+	if blockHash != nil {
+		hash = btcjson.String(blockHash.String())
+	}
+
+	cmd := btcjson.NewListSinceBlockCmd(hash, &minConfirms, &watchOnly)
+	return c.sendCmd(cmd)
+}
+
+// ListSinceBlockWatchOnly returns all transactions added in blocks since the
+// specified block hash, or all transactions if it is nil, using the specified
+// number of minimum confirmations as a filter, and includes transactions of
+// watch-only addresses if requested.
+func (c *Client) ListSinceBlockWatchOnly(blockHash *chainhash.Hash,
+	minConfirms int, watchOnly bool) (*btcjson.ListSinceBlockResult, error) {
+
+	return c.ListSinceBlockWatchOnlyAsync(blockHash, minConfirms,
+		watchOnly).Receive()
+}
+
 // **************************
 // Transaction Send Functions
 // **************************
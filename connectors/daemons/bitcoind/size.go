@@ -1,8 +1,14 @@
 package bitcoind
 
 import (
+	"encoding/hex"
+
+	"github.com/bitlum/connector/connectors/assets/bitcoin"
+	"github.com/bitlum/connector/connectors/assets/zcash"
 	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 )
 
 const (
@@ -61,6 +67,18 @@ const (
 	//      - pkscript (p2sh): 23 bytes
 	P2SHOutputSize = 8 + 1 + 23
 
+	// P2TRSize 34 bytes
+	//	- OP_1: 1 byte
+	//	- OP_DATA: 1 byte (TaprootOutputKey length)
+	//	- TaprootOutputKey: 32 bytes
+	P2TRSize = 1 + 1 + 32
+
+	// P2TROutputSize 43 bytes
+	//      - value: 8 bytes
+	//      - var_int: 1 byte (pkscript_length)
+	//      - pkscript (p2tr): 34 bytes
+	P2TROutputSize = 8 + 1 + P2TRSize
+
 	// P2PKHScriptSigSize 108 bytes
 	//      - OP_DATA: 1 byte (signature length)
 	//      - signature
@@ -95,10 +113,30 @@ const (
 	//	- Marker: 1 byte
 	WitnessHeaderSize = 1 + 1
 
+	// p2pkhInputWeight is the weight of the input spending P2PKH output.
+	p2pkhInputWeight = (InputSize + P2PKHScriptSigSize) * witnessScaleFactor
+
+	// p2wkhInputWeight is the weight of the input spending native P2WKH
+	// output.
+	p2wkhInputWeight = InputSize*witnessScaleFactor + P2WKHWitnessSize
+
+	// nestedP2WKHInputWeight is the weight of the input spending P2SH output
+	// with nested P2WKH redeem script, script sig contains push of the
+	// redeem script.
+	nestedP2WKHInputWeight = (InputSize+1+P2WPKHSize)*witnessScaleFactor +
+		P2WKHWitnessSize
+
 	// BaseTxSize 8 bytes
 	// - Version: 4 bytes
 	// - LockTime: 4 bytes
 	BaseTxSize = 4 + 4
+
+	// ZcashOverheadSize 19 bytes
+	// - VersionGroupID: 4 bytes
+	// - ExpiryHeight: 4 bytes
+	// - ValueBalance: 8 bytes
+	// - Number of shielded spends, shielded outputs and joinsplits: 3 bytes
+	ZcashOverheadSize = 4 + 4 + 8 + 1 + 1 + 1
 )
 
 // TxWeightEstimator is able to calculate weight estimates for transactions
@@ -111,6 +149,7 @@ type TxWeightEstimator struct {
 	inputSize        int
 	inputWitnessSize int
 	outputSize       int
+	overheadSize     int
 }
 
 // AddP2PKHInput updates the weight estimate to account for an additional input
@@ -143,6 +182,7 @@ func (twe *TxWeightEstimator) AddNestedP2WKHInput() {
 	twe.inputSize += InputSize + P2WPKHSize
 	twe.inputWitnessSize += P2WKHWitnessSize
 	twe.inputSize++
+	twe.inputCount++
 	twe.hasWitness = true
 }
 
@@ -152,6 +192,7 @@ func (twe *TxWeightEstimator) AddNestedP2WSHInput(witnessSize int) {
 	twe.inputSize += InputSize + P2WSHSize
 	twe.inputWitnessSize += witnessSize
 	twe.inputSize++
+	twe.inputCount++
 	twe.hasWitness = true
 }
 
@@ -183,9 +224,16 @@ func (twe *TxWeightEstimator) AddP2SHOutput() {
 	twe.outputCount++
 }
 
+// AddP2TROutput updates the weight estimate to account for an additional
+// native P2TR output.
+func (twe *TxWeightEstimator) AddP2TROutput() {
+	twe.outputSize += P2TROutputSize
+	twe.outputCount++
+}
+
 // Weight gets the estimated weight of the transaction.
 func (twe *TxWeightEstimator) Weight() int {
-	txSizeStripped := BaseTxSize +
+	txSizeStripped := BaseTxSize + twe.overheadSize +
 		wire.VarIntSerializeSize(uint64(twe.inputCount)) + twe.inputSize +
 		wire.VarIntSerializeSize(uint64(twe.outputCount)) + twe.outputSize
 	weight := txSizeStripped * witnessScaleFactor
@@ -194,3 +242,73 @@ func (twe *TxWeightEstimator) Weight() int {
 	}
 	return weight
 }
+
+// AddZcashOverhead updates the weight estimate to account for the fields of
+// Zcash transaction, which are absent in bitcoin one.
+func (twe *TxWeightEstimator) AddZcashOverhead() {
+	twe.overheadSize += ZcashOverheadSize
+}
+
+// AddOutput updates the weight estimate to account for an additional output
+// paying to the given address.
+func (twe *TxWeightEstimator) AddOutput(address btcutil.Address) {
+	twe.outputSize += outputSize(address)
+	twe.outputCount++
+}
+
+// outputSize returns the size of the output paying to the given address.
+// Unknown addresses are treated as P2PKH.
+func outputSize(address btcutil.Address) int {
+	switch address.(type) {
+	case *btcutil.AddressScriptHash, *zcash.AddressScriptHash:
+		return P2SHOutputSize
+	case *btcutil.AddressWitnessPubKeyHash:
+		return P2WKHOutputSize
+	case *btcutil.AddressWitnessScriptHash:
+		return P2WSHOutputSize
+	case *bitcoin.AddressTaproot:
+		return P2TROutputSize
+	default:
+		return P2PKHOutputSize
+	}
+}
+
+// inputWeight returns the weight of the input spending the output with the
+// given hex encoded public key script. Redeem script is used to determine
+// the type of P2SH output. Outputs of the unknown type are treated as P2PKH.
+func inputWeight(scriptPubKey, redeemScript string) int {
+	script, err := hex.DecodeString(scriptPubKey)
+	if err != nil {
+		return p2pkhInputWeight
+	}
+
+	switch txscript.GetScriptClass(script) {
+	case txscript.WitnessV0PubKeyHashTy:
+		return p2wkhInputWeight
+
+	case txscript.ScriptHashTy:
+		redeem, err := hex.DecodeString(redeemScript)
+		if err != nil {
+			return p2pkhInputWeight
+		}
+
+		if txscript.GetScriptClass(redeem) == txscript.WitnessV0PubKeyHashTy {
+			return nestedP2WKHInputWeight
+		}
+	}
+
+	return p2pkhInputWeight
+}
+
+// addressTypeWeights returns the weight of the output paying to the address
+// of the given type, and the weight of the input spending it.
+func addressTypeWeights(addressType AddressType) (int, int) {
+	switch addressType {
+	case AddressP2SHSegwit:
+		return P2SHOutputSize * witnessScaleFactor, nestedP2WKHInputWeight
+	case AddressBech32:
+		return P2WKHOutputSize * witnessScaleFactor, p2wkhInputWeight
+	default:
+		return P2PKHOutputSize * witnessScaleFactor, p2pkhInputWeight
+	}
+}
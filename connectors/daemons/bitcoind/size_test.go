@@ -0,0 +1,179 @@
+package bitcoind
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/assets/bitcoin"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestInputWeight(t *testing.T) {
+	tests := []struct {
+		name         string
+		scriptPubKey string
+		redeemScript string
+		weight       int
+	}{
+		{
+			name:         "P2PKH",
+			scriptPubKey: "76a914" + "0358dfa6b7f4ba8e1ec71b40e1dc2e7fab1f29e4" + "88ac",
+			weight:       p2pkhInputWeight,
+		},
+		{
+			name:         "P2WKH",
+			scriptPubKey: "0014" + "0358dfa6b7f4ba8e1ec71b40e1dc2e7fab1f29e4",
+			weight:       p2wkhInputWeight,
+		},
+		{
+			name:         "P2SH nested P2WKH",
+			scriptPubKey: "a914" + "4733f37cf4db86fbc2efed2500b4f4e49f312023" + "87",
+			redeemScript: "0014" + "0358dfa6b7f4ba8e1ec71b40e1dc2e7fab1f29e4",
+			weight:       nestedP2WKHInputWeight,
+		},
+		{
+			name:         "P2SH unknown redeem script",
+			scriptPubKey: "a914" + "4733f37cf4db86fbc2efed2500b4f4e49f312023" + "87",
+			weight:       p2pkhInputWeight,
+		},
+		{
+			name:         "malformed script",
+			scriptPubKey: "zz",
+			weight:       p2pkhInputWeight,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			weight := inputWeight(test.scriptPubKey, test.redeemScript)
+			if weight != test.weight {
+				t.Fatalf("wrong weight, expected %v, got %v", test.weight,
+					weight)
+			}
+		})
+	}
+}
+
+// TestInputWeightEstimator checks that weight of the inputs is consistent
+// with the weight estimator.
+func TestInputWeightEstimator(t *testing.T) {
+	var base, p2pkh, p2wkh, nested TxWeightEstimator
+	p2pkh.AddP2PKHInput()
+	p2wkh.AddP2WKHInput()
+	nested.AddNestedP2WKHInput()
+
+	// Witness inputs make the estimator account for the witness header,
+	// which isn't part of the input weight.
+	if p2pkh.Weight()-base.Weight() != p2pkhInputWeight {
+		t.Fatalf("wrong P2PKH input weight")
+	}
+
+	if p2wkh.Weight()-base.Weight()-WitnessHeaderSize != p2wkhInputWeight {
+		t.Fatalf("wrong P2WKH input weight")
+	}
+
+	if nested.Weight()-base.Weight()-WitnessHeaderSize !=
+		nestedP2WKHInputWeight {
+		t.Fatalf("wrong nested P2WKH input weight")
+	}
+}
+
+func TestOutputSize(t *testing.T) {
+	hash := make([]byte, 20)
+	scriptHash := make([]byte, 32)
+	params := &chaincfg.RegressionNetParams
+
+	p2pkh, _ := btcutil.NewAddressPubKeyHash(hash, params)
+	p2sh, _ := btcutil.NewAddressScriptHashFromHash(hash, params)
+	p2wkh, _ := btcutil.NewAddressWitnessPubKeyHash(hash, params)
+	p2wsh, _ := btcutil.NewAddressWitnessScriptHash(scriptHash, params)
+	p2tr, _ := bitcoin.NewAddressTaproot(scriptHash, params)
+
+	tests := []struct {
+		address btcutil.Address
+		size    int
+	}{
+		{p2pkh, P2PKHOutputSize},
+		{p2sh, P2SHOutputSize},
+		{p2wkh, P2WKHOutputSize},
+		{p2wsh, P2WSHOutputSize},
+		{p2tr, P2TROutputSize},
+	}
+
+	for _, test := range tests {
+		if size := outputSize(test.address); size != test.size {
+			t.Fatalf("wrong size of %T, expected %v, got %v", test.address,
+				test.size, size)
+		}
+	}
+}
+
+// TestTaprootOutput checks that the taproot address is decoded in the
+// witness program, which bitcoind puts in the output of the transaction
+// made by createrawtransaction, and that size of such output is estimated
+// properly.
+func TestTaprootOutput(t *testing.T) {
+	// Address and its public key script are taken from BIP-350 test
+	// vectors.
+	address := "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"
+	program := "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+
+	// Transaction in the form returned by createrawtransaction, which
+	// pays 0.001 BTC to the address.
+	rawTx := "02000000" + "01" +
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" +
+		"00000000" + "00" + "ffffffff" + "01" +
+		"a086010000000000" + "22" + "5120" + program +
+		"00000000"
+
+	decoded, err := decodeAddress(connectors.BTC, address, "mainnet")
+	if err != nil {
+		t.Fatalf("unable to decode address: %v", err)
+	}
+
+	if _, ok := decoded.(*bitcoin.AddressTaproot); !ok {
+		t.Fatalf("wrong type of taproot address: %T", decoded)
+	}
+
+	data, _ := hex.DecodeString(rawTx)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(data)); err != nil {
+		t.Fatalf("unable to deserialize tx: %v", err)
+	}
+
+	output := tx.TxOut[0]
+	version, witnessProgram, err := txscript.ExtractWitnessProgramInfo(
+		output.PkScript)
+	if err != nil {
+		t.Fatalf("output isn't witness program: %v", err)
+	}
+
+	if version != 1 {
+		t.Fatalf("wrong witness version: %v", version)
+	}
+
+	if !bytes.Equal(witnessProgram, decoded.ScriptAddress()) {
+		t.Fatalf("wrong witness program, expected %x, got %x",
+			decoded.ScriptAddress(), witnessProgram)
+	}
+
+	if len(output.PkScript) != P2TRSize {
+		t.Fatalf("wrong script size, expected %v, got %v", P2TRSize,
+			len(output.PkScript))
+	}
+
+	if output.SerializeSize() != P2TROutputSize {
+		t.Fatalf("wrong output size, expected %v, got %v",
+			P2TROutputSize, output.SerializeSize())
+	}
+
+	if size := outputSize(decoded); size != output.SerializeSize() {
+		t.Fatalf("wrong estimated output size, expected %v, got %v",
+			output.SerializeSize(), size)
+	}
+}
@@ -0,0 +1,40 @@
+package bitcoind
+
+// HDAccountsStorage is used to keep track of the addresses, which are
+// derived from the extended public key, because of the reason of daemon not
+// having the private keys and derivation indexes of such addresses.
+//
+// NOTE: This storage has to be persistent.
+type HDAccountsStorage interface {
+	// NextDerivationIndex returns the index of the next not used address
+	// derived from the given extended public key.
+	NextDerivationIndex(xpub string) (uint32, error)
+
+	// AddDerivedAddress assigns address derived from the extended public
+	// key with the given index to the account.
+	AddDerivedAddress(xpub string, index uint32, address,
+		account string) error
+
+	// DerivedAddressAccount returns account of the address derived from
+	// the extended public key, empty string is returned if address hasn't
+	// been derived.
+	DerivedAddressAccount(address string) (string, error)
+}
+
+// AccountsStorage is used to keep track connections between addresses and
+// accounts, because of the reason of accounts API being removed from the
+// newer versions of bitcoind wallet, and forks behaving differently.
+//
+// NOTE: This storage has to be persistent.
+type AccountsStorage interface {
+	// GetAccountByAddress returns account by given address, empty string
+	// is returned if address isn't assigned to any account.
+	GetAccountByAddress(address string) (string, error)
+
+	// GetLastAccountAddress returns last address which were assigned to
+	// account.
+	GetLastAccountAddress(account string) (string, error)
+
+	// AddAddressToAccount assigns new address to account.
+	AddAddressToAccount(address, account string) error
+}
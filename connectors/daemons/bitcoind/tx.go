@@ -1,17 +1,19 @@
 package bitcoind
 
 import (
+	"bytes"
 	"fmt"
 
 	"math"
 
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/assets/zcash"
 	"github.com/bitlum/connector/connectors/daemons/bitcoind/btcjson"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
 	"github.com/shopspring/decimal"
-	"github.com/btcsuite/btcd/blockchain"
 )
 
 // ErrInsufficientFunds is a type matching the error interface which is
@@ -28,33 +30,6 @@ func (e *ErrInsufficientFunds) Error() string {
 		printAmount(e.amountAvailable))
 }
 
-// selectInputs selects a slice of inputs necessary to meet the specified
-// selection amount. If input selection is unable to succeed to to insufficient
-// funds, a non-nil error is returned.
-// TODO(andrew.shvv) Probably sort inputs, before selecting them.
-// TODO(andrew.shvv) Develop hierstic algorithm of choosing the outputs
-// efficiently.
-func selectInputs(amt btcutil.Amount,
-	inputsMap map[string]btcjson.ListUnspentResult) (btcutil.Amount,
-	[]btcjson.ListUnspentResult, error) {
-
-	var inputs []btcjson.ListUnspentResult
-	satSelected := btcutil.Amount(0)
-	for _, input := range inputsMap {
-		amount, err := btcutil.NewAmount(input.Amount)
-		if err != nil {
-			return 0, nil, err
-		}
-
-		inputs = append(inputs, input)
-		satSelected += amount
-		if satSelected >= amt {
-			return satSelected, inputs, nil
-		}
-	}
-	return 0, nil, &ErrInsufficientFunds{amt, satSelected}
-}
-
 // syncUnspent populates local map of confirmed from our POV unspent outputs
 // so that later we could construct transaction in a fast manner.
 // Otherwise construction of transaction might take couple of seconds.
@@ -73,25 +48,44 @@ func (c *Connector) syncUnspent() error {
 	}
 
 	var amount decimal.Decimal
-	c.unspent = make(map[string]btcjson.ListUnspentResult, len(unspent))
+	c.unspent = make(map[wire.OutPoint]btcjson.ListUnspentResult, len(unspent))
 	for _, u := range unspent {
-		c.unspent[u.TxID] = u
+		if c.cfg.ColdSigning {
+			if !isColdSpendable(u.ScriptPubKey, u.RedeemScript) {
+				continue
+			}
+		} else if !u.Spendable {
+			// Outputs of watch-only deposit addresses couldn't be signed
+			// by the daemon, so only hot wallet outputs are used for
+			// withdrawals.
+			continue
+		}
+
+		txid, err := chainhash.NewHashFromStr(u.TxID)
+		if err != nil {
+			return errors.Errorf("unable to decode tx id: %v", err)
+		}
+
+		// Transaction might have several outputs belonging to us, for
+		// that reason outputs are identified by outpoint rather than by
+		// the transaction id.
+		c.unspent[*wire.NewOutPoint(txid, u.Vout)] = u
 		a := decimal.NewFromFloat(u.Amount)
 		amount = amount.Add(a)
 	}
 
 	c.log.Debugf("Sync %v unspent inputs, with overall %v %v amount",
-		len(unspent), amount.String(), c.cfg.Asset)
+		len(c.unspent), amount.String(), c.cfg.Asset)
 
 	return nil
 }
 
 // craftTransaction performs coin selection in order to obtain outputs which sum
 // to at least 'numCoins' amount of satoshis. If necessary, a change address will
-// also be generated.
+// also be generated. Outputs spent by the transaction are returned as well.
 func (c *Connector) craftTransaction(feeRatePerByte uint64,
 	amtSat btcutil.Amount, address btcutil.Address) (*wire.MsgTx,
-	btcutil.Amount, error) {
+	map[wire.OutPoint]btcjson.ListUnspentResult, btcutil.Amount, error) {
 
 	// We hold the coin select mutex while querying for outputs, and
 	// performing coin selection in order to avoid inadvertent double
@@ -110,7 +104,29 @@ func (c *Connector) craftTransaction(feeRatePerByte uint64,
 	// unlock them.
 	c.log.Debugf("Unlocking unspent inputs...")
 	if err := c.client.LockUnspent(true, nil); err != nil {
-		return nil, 0, errors.Errorf("unable to unlock unspent outputs")
+		return nil, nil, 0, errors.Errorf("unable to unlock unspent outputs")
+	}
+
+	// Outputs spent by the payments, which are waiting to be signed
+	// externally or sent, are locked back, because these payments might be
+	// finalized or sent at any time.
+	reserved, err := c.reservedOutPoints()
+	if err != nil {
+		return nil, nil, 0, errors.Errorf("unable to get reserved "+
+			"outputs: %v", err)
+	}
+
+	outPoints := make([]*wire.OutPoint, 0, len(reserved))
+	for outPoint := range reserved {
+		outPoint := outPoint
+		outPoints = append(outPoints, &outPoint)
+	}
+
+	if len(outPoints) != 0 {
+		if err := c.client.LockUnspent(false, outPoints); err != nil {
+			return nil, nil, 0, errors.Errorf("unable to lock "+
+				"reserved outputs: %v", err)
+		}
 	}
 
 	// Try to get unspent outputs from local cache,
@@ -120,7 +136,7 @@ func (c *Connector) craftTransaction(feeRatePerByte uint64,
 		c.unspentSyncMtx.Unlock()
 
 		if err := c.syncUnspent(); err != nil {
-			return nil, 0, errors.Errorf("unable to sync unspent: %v", err)
+			return nil, nil, 0, errors.Errorf("unable to sync unspent: %v", err)
 		}
 	}
 	c.unspentSyncMtx.Unlock()
@@ -129,38 +145,73 @@ func (c *Connector) craftTransaction(feeRatePerByte uint64,
 	// in order to find enough coins to meet the funding amount
 	// requirements.
 	c.unspentSyncMtx.Lock()
-	selectedInputs, changeAmt, requiredFee, err := coinSelect(feeRatePerByte,
-		amtSat, c.unspent)
+	utxos := make([]*utxo, 0, len(c.unspent))
+	for outPoint, u := range c.unspent {
+		if _, ok := reserved[outPoint]; ok {
+			continue
+		}
+
+		amount, err := btcutil.NewAmount(u.Amount)
+		if err != nil {
+			c.unspentSyncMtx.Unlock()
+			return nil, nil, 0, errors.Errorf("unable to decode amount: %v", err)
+		}
+
+		utxos = append(utxos, &utxo{
+			outPoint:    outPoint,
+			amount:      amount,
+			inputWeight: inputWeight(u.ScriptPubKey, u.RedeemScript),
+		})
+	}
 	c.unspentSyncMtx.Unlock()
 
+	// This is usual transaction and it will contain one output to pay to
+	// someone else. Change output is of the configured address type.
+	var baseWeight TxWeightEstimator
+	baseWeight.AddOutput(address)
+	if c.cfg.Asset == connectors.ZEC {
+		baseWeight.AddZcashOverhead()
+	}
+
+	changeOutputWeight, changeSpendWeight := addressTypeWeights(
+		c.cfg.AddressType)
+
+	selection, err := selectCoins(&coinSelectParams{
+		amount:             amtSat,
+		feeRate:            btcutil.Amount(feeRatePerByte),
+		longTermFeeRate:    c.longTermFeeRate(),
+		baseWeight:         baseWeight.Weight(),
+		changeOutputWeight: changeOutputWeight,
+		changeSpendWeight:  changeSpendWeight,
+		dustLimit:          c.dustLimit(),
+	}, utxos)
 	if err != nil {
-		return nil, 0, errors.Errorf("unable to select inputs: %v", err)
+		return nil, nil, 0, errors.Errorf("unable to select inputs: %v", err)
 	}
 
-	c.log.Debugf("Selected %v unspent inputs, amount(%v), change(%v), fee(%v)",
-		len(selectedInputs), printAmount(amtSat), printAmount(changeAmt),
-		printAmount(requiredFee))
+	changeAmt := selection.change
+	requiredFee := selection.fee
+
+	c.log.Debugf("Selected %v unspent inputs, amount(%v), change(%v), "+
+		"fee(%v), waste(%v)", len(selection.inputs), printAmount(amtSat),
+		printAmount(changeAmt), printAmount(requiredFee),
+		printAmount(selection.waste))
 
 	// Lock the selected coins. These coins are now "reserved", this
 	// prevents concurrent funding requests from referring to and this
 	// double-spending the same set of coins.
-	inputs := make([]btcjson.TransactionInput, len(selectedInputs))
-
-	for i, input := range selectedInputs {
-		txid, err := chainhash.NewHashFromStr(input.TxID)
-		if err != nil {
-			return nil, 0, err
-		}
+	inputs := make([]btcjson.TransactionInput, len(selection.inputs))
 
-		outpoint := wire.NewOutPoint(txid, input.Vout)
-		err = c.client.LockUnspent(false, []*wire.OutPoint{outpoint})
+	for i, input := range selection.inputs {
+		outpoint := input.outPoint
+		err = c.client.LockUnspent(false, []*wire.OutPoint{&outpoint})
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, 0, err
 		}
 
 		inputs[i] = btcjson.TransactionInput{
-			Txid: input.TxID,
-			Vout: input.Vout,
+			Txid: outpoint.Hash.String(),
+			Vout: outpoint.Index,
 		}
 	}
 
@@ -171,80 +222,99 @@ func (c *Connector) craftTransaction(feeRatePerByte uint64,
 	if changeAmt != 0 {
 		// Create loopback output with remaining amount which point out to the
 		// default account of the wallet.
-		changeAddr, err := c.client.GetNewAddress(defaultAccount)
+		rawChangeAddr, err := c.client.GetNewAddressType(defaultAccount,
+			c.cfg.AddressType)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, 0, err
+		}
+
+		changeAddr, err := decodeAddress(c.cfg.Asset, rawChangeAddr,
+			c.cfg.Net)
+		if err != nil {
+			return nil, nil, 0, errors.Errorf("unable to decode change "+
+				"address: %v", err)
 		}
 		outputs[changeAddr] = changeAmt
 	}
 
-	lockTime := int64(0)
-	tx, err := c.client.CreateRawTransaction(inputs, outputs, &lockTime)
+	var tx *wire.MsgTx
+	if c.cfg.Asset == connectors.ZEC {
+		tx, err = createZcashTx(inputs, outputs)
+	} else {
+		lockTime := int64(0)
+		tx, err = c.client.CreateRawTransaction(inputs, outputs, &lockTime)
+	}
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, 0, err
 	}
 
+	prevOutputs := make(map[wire.OutPoint]btcjson.ListUnspentResult,
+		len(selection.inputs))
+
 	c.unspentSyncMtx.Lock()
-	for _, input := range selectedInputs {
-		delete(c.unspent, input.TxID)
+	for _, input := range selection.inputs {
+		prevOutputs[input.outPoint] = c.unspent[input.outPoint]
+		delete(c.unspent, input.outPoint)
 	}
 	c.unspentSyncMtx.Unlock()
 
-	return tx, requiredFee, nil
+	return tx, prevOutputs, requiredFee, nil
 }
 
-// coinSelect attempts to select a sufficient amount of coins, including a
-// change output to fund amt satoshis, adhering to the specified fee rate. The
-// specified fee rate should be expressed in sat/byte for coin selection to
-// function properly.
-func coinSelect(feeRatePerByte uint64, amtSat btcutil.Amount,
-	unspent map[string]btcjson.ListUnspentResult) ([]btcjson.ListUnspentResult,
-	btcutil.Amount, btcutil.Amount, error) {
-
-	amtNeeded := amtSat
-	for {
-		// First perform an initial round of coin selection to estimate
-		// the required fee.
-		totalSat, selectedUtxos, err := selectInputs(amtNeeded, unspent)
-		if err != nil {
-			return nil, 0, 0, err
-		}
+// broadcastTx sends the serialized transaction in the network.
+func (c *Connector) broadcastTx(rawTx []byte) error {
+	// Zcash transactions couldn't be decoded as bitcoin ones, for that
+	// reason they are sent as is.
+	if c.cfg.Asset == connectors.ZEC {
+		return c.client.SendRawTransactionBytes(rawTx)
+	}
 
-		var weightEstimate TxWeightEstimator
+	tx, err := c.decodeTx(rawTx)
+	if err != nil {
+		return errors.Errorf("unable to deserialize raw tx: %v", err)
+	}
 
-		// For every input add weight
-		for i := 0; i < len(selectedUtxos); i++ {
-			weightEstimate.AddP2PKHInput()
-		}
+	_, err = c.client.SendRawTransaction(tx, true)
+	return err
+}
 
-		// This is usual transaction and it will contain one P2PKH output to
-		// pay to someone else, add weight for it.
-		weightEstimate.AddP2PKHOutput()
+// decodeTx deserializes raw transaction. For Zcash only transparent part of
+// the transaction is returned, which shouldn't be serialized back.
+func (c *Connector) decodeTx(rawTx []byte) (*wire.MsgTx, error) {
+	if c.cfg.Asset == connectors.ZEC {
+		tx := &zcash.Tx{}
+		if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+			return nil, err
+		}
 
-		// Assume that change output is a P2PKH output.
-		weightEstimate.AddP2PKHOutput()
+		return tx.MsgTx(), nil
+	}
 
-		// The difference between the selected amount and the amount
-		// requested will be used to pay fees, and generate a change
-		// output with the remaining.
-		overShootAmt := totalSat - amtSat
+	tx := new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, err
+	}
 
-		// Based on the estimated size and fee rate, if the excess
-		// amount isn't enough to pay fees, then increase the requested
-		// coin amount by the estimate required fee, performing another
-		// round of coin selection.
-		size := uint64(weightEstimate.Weight() / blockchain.WitnessScaleFactor)
-		requiredFee := btcutil.Amount(size * feeRatePerByte)
+	return tx, nil
+}
 
-		if overShootAmt < requiredFee {
-			amtNeeded = amtSat + requiredFee
-			continue
+// decodeTxID returns the hash of the raw transaction. Zcash transaction
+// hash covers the version group and expiry, so it couldn't be taken from
+// the decoded bitcoin transaction.
+func (c *Connector) decodeTxID(rawTx []byte) (string, error) {
+	if c.cfg.Asset == connectors.ZEC {
+		tx := &zcash.Tx{}
+		if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+			return "", err
 		}
 
-		// If the fee is sufficient, then calculate the amount of the
-		// change output.
-		changeAmt := overShootAmt - requiredFee
+		return tx.TxHash().String(), nil
+	}
 
-		return selectedUtxos, changeAmt, requiredFee, nil
+	tx := new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return "", err
 	}
+
+	return tx.TxHash().String(), nil
 }
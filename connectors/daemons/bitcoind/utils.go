@@ -9,6 +9,8 @@ import (
 	"github.com/bitlum/connector/connectors/assets/litecoin"
 	"github.com/bitlum/connector/connectors/assets/bitcoincash"
 	"github.com/bitlum/connector/connectors/assets/dash"
+	"github.com/bitlum/connector/connectors/assets/dogecoin"
+	"github.com/bitlum/connector/connectors/assets/zcash"
 	"github.com/go-errors/errors"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/bitlum/connector/connectors"
@@ -62,6 +64,10 @@ network string) (btcutil.Address, error) {
 		return bitcoincash.DecodeAddress(address, network)
 	case connectors.DASH:
 		return dash.DecodeAddress(address, network)
+	case connectors.DOGE:
+		return dogecoin.DecodeAddress(address, network)
+	case connectors.ZEC:
+		return zcash.DecodeAddress(address, network)
 	default:
 		return nil, errors.Errorf("unsupported asset asset(%v)", asset)
 	}
@@ -77,6 +83,10 @@ func getParams(asset connectors.Asset, network string) (*chaincfg.Params, error)
 		return bitcoincash.GetParams(network)
 	case connectors.DASH:
 		return dash.GetParams(network)
+	case connectors.DOGE:
+		return dogecoin.GetParams(network)
+	case connectors.ZEC:
+		return zcash.GetParams(network)
 	default:
 		return nil, errors.Errorf("unsupported asset asset(%v)", asset)
 	}
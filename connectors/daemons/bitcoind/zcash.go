@@ -0,0 +1,121 @@
+package bitcoind
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/bitlum/connector/connectors/assets/zcash"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+)
+
+// zcashExpiryDelta is the number of blocks after which unmined Zcash
+// transaction expires, it is equal to the default one of Zcash daemon.
+const zcashExpiryDelta = 40
+
+// createZcashTx creates transparent part of Zcash transaction. Zcash daemon
+// creates transactions of its own versioned format, which couldn't be
+// decoded as bitcoin ones, so transaction is created locally.
+func createZcashTx(inputs []btcjson.TransactionInput,
+	outputs map[btcutil.Address]btcutil.Amount) (*wire.MsgTx, error) {
+
+	tx := wire.NewMsgTx(zcash.SaplingTxVersion)
+	for _, input := range inputs {
+		hash, err := chainhash.NewHashFromStr(input.Txid)
+		if err != nil {
+			return nil, errors.Errorf("unable to decode tx hash(%v): %v",
+				input.Txid, err)
+		}
+
+		outPoint := wire.NewOutPoint(hash, input.Vout)
+		tx.AddTxIn(wire.NewTxIn(outPoint, nil, nil))
+	}
+
+	for address, amount := range outputs {
+		pkScript, err := zcash.PayToAddrScript(address)
+		if err != nil {
+			return nil, errors.Errorf("unable to create output script: %v",
+				err)
+		}
+
+		tx.AddTxOut(wire.NewTxOut(int64(amount), pkScript))
+	}
+
+	return tx, nil
+}
+
+// signZcashTx signs the transparent Zcash transaction with the daemon
+// wallet keys, and returns the serialized signed transaction and its id.
+// Transaction is signed for the network upgrade of the next block, and
+// signatures are verified locally, so that transaction signed for the
+// wrong upgrade is never stored.
+func (c *Connector) signZcashTx(tx *wire.MsgTx,
+	prevOutputs map[wire.OutPoint]btcjson.ListUnspentResult) ([]byte,
+	string, error) {
+
+	info, err := c.client.GetZcashBlockChainInfo()
+	if err != nil {
+		return nil, "", errors.Errorf("unable to get blockchain info: %v",
+			err)
+	}
+
+	branchID, err := strconv.ParseUint(info.Consensus.NextBlock, 16, 32)
+	if err != nil {
+		return nil, "", errors.Errorf("unable to decode consensus branch "+
+			"id(%v): %v", info.Consensus.NextBlock, err)
+	}
+
+	unsignedTx := zcash.NewTx(tx, uint32(info.Blocks)+zcashExpiryDelta)
+
+	var rawTx bytes.Buffer
+	if err := unsignedTx.Serialize(&rawTx); err != nil {
+		return nil, "", errors.Errorf("unable to serialize tx: %v", err)
+	}
+
+	signedRawTx, isSigned, err := c.client.SignZcashTransaction(
+		rawTx.Bytes(), uint32(branchID))
+	if err != nil {
+		return nil, "", errors.Errorf("unable to sign tx: %v", err)
+	}
+
+	if !isSigned {
+		return nil, "", errors.New("unable to sign all tx inputs")
+	}
+
+	signedTx := &zcash.Tx{}
+	if err := signedTx.Deserialize(bytes.NewReader(signedRawTx)); err != nil {
+		return nil, "", errors.Errorf("unable to decode signed tx: %v", err)
+	}
+
+	for i, txIn := range signedTx.TxIn {
+		prevOutput, ok := prevOutputs[txIn.PreviousOutPoint]
+		if !ok {
+			return nil, "", errors.Errorf("unknown output(%v) is spent",
+				txIn.PreviousOutPoint)
+		}
+
+		pkScript, err := hex.DecodeString(prevOutput.ScriptPubKey)
+		if err != nil {
+			return nil, "", errors.Errorf("unable to decode script of "+
+				"output(%v): %v", txIn.PreviousOutPoint, err)
+		}
+
+		amount, err := btcutil.NewAmount(prevOutput.Amount)
+		if err != nil {
+			return nil, "", errors.Errorf("unable to decode amount of "+
+				"output(%v): %v", txIn.PreviousOutPoint, err)
+		}
+
+		err = signedTx.VerifyInput(i, pkScript, int64(amount),
+			uint32(branchID))
+		if err != nil {
+			return nil, "", errors.Errorf("input(%v): %v", i, err)
+		}
+	}
+
+	return signedRawTx, signedTx.TxHash().String(), nil
+}
@@ -32,6 +32,20 @@ var (
 
 	allAccounts = "all"
 
+	// defaultPendingExpiry is the default time after which pending payment,
+	// which transaction is neither in the mempool nor in the blockchain, is
+	// considered to be expired. It is equal to the default transaction
+	// lifetime in the geth transaction pool.
+	defaultPendingExpiry = 3 * time.Hour
+
+	// defaultRecoveryInterval is the default interval between checks of
+	// dropped transactions and stale waiting payments.
+	defaultRecoveryInterval = 10 * time.Minute
+
+	// defaultWaitingTimeout is the default time after which payment, which
+	// has been created but hasn't been sent, is resolved by the recovery.
+	defaultWaitingTimeout = time.Hour
+
 	// defaultTxGas is the number of gas in ethereum which is needed to
 	// propagate the transaction.
 	defaultTxGas = int64(90000)
@@ -49,6 +63,7 @@ const (
 	MethodSync                = "Sync"
 	MethodEstimateFee         = "MethodEstimateFee"
 	MethodValidateAddress     = "MethodValidateAddress"
+	MethodReorg               = "Reorg"
 )
 
 type DaemonConfig struct {
@@ -103,6 +118,30 @@ type Config struct {
 	// StateStorage is used to keep data which is needed for connector to
 	// properly synchronise and track transactions.
 	StateStorage connectors.StateStorage
+
+	// OnReorg is called after chain re-organisation has been handled.
+	// Optional.
+	OnReorg connectors.ReorgHandler
+
+	// PendingExpiry is the time after which pending payment, which
+	// transaction is neither in the mempool nor in the blockchain, is
+	// marked as failed. Default transaction pool lifetime of geth is used
+	// if not specified.
+	PendingExpiry time.Duration
+
+	// RecoveryInterval is the interval between checks of outgoing payments,
+	// which transactions have been dropped by the daemon, and of stale
+	// waiting payments. Check is also done on startup.
+	RecoveryInterval time.Duration
+
+	// WaitingTimeout is the time after which payment, which has been
+	// created but hasn't been sent, is resolved according to the
+	// WaitingPolicy.
+	WaitingTimeout time.Duration
+
+	// WaitingPolicy defines whether stale waiting payments are sent or
+	// cancelled. Payments are cancelled if not specified.
+	WaitingPolicy connectors.WaitingPolicy
 }
 
 func (c *Config) validate() error {
@@ -122,6 +161,26 @@ func (c *Config) validate() error {
 		c.SyncTickDelay = 5
 	}
 
+	if c.PendingExpiry == 0 {
+		c.PendingExpiry = defaultPendingExpiry
+	}
+
+	if c.RecoveryInterval == 0 {
+		c.RecoveryInterval = defaultRecoveryInterval
+	}
+
+	if c.WaitingTimeout == 0 {
+		c.WaitingTimeout = defaultWaitingTimeout
+	}
+
+	switch c.WaitingPolicy {
+	case "":
+		c.WaitingPolicy = connectors.WaitingCancel
+	case connectors.WaitingBroadcast, connectors.WaitingCancel:
+	default:
+		return errors.Errorf("unknown waiting policy(%v)", c.WaitingPolicy)
+	}
+
 	if c.Asset == "" {
 		return errors.New("asset should be specified")
 	}
@@ -162,14 +221,9 @@ type Connector struct {
 	// from it.
 	defaultAddress string
 
-	// memPoolTxs contains transaction which are not yet in the blockchain
-	// and still waiting to be included in the blocks.
-	memPoolTxs pendingMap
-
-	// unconfirmedTxs is the map of transaction which are already in the
-	// blockchains, but not yet confirmed from our pov.
-	unconfirmedTxs pendingMap
-	pendingLock    sync.Mutex
+	// lastRecovery is the time of the last check of dropped transactions
+	// and stale waiting payments.
+	lastRecovery time.Time
 
 	log *connectors.NamedLogger
 }
@@ -184,10 +238,8 @@ func NewConnector(cfg *Config) (*Connector, error) {
 	}
 
 	return &Connector{
-		cfg:            cfg,
-		quit:           make(chan struct{}),
-		memPoolTxs:     make(pendingMap),
-		unconfirmedTxs: make(pendingMap),
+		cfg:  cfg,
+		quit: make(chan struct{}),
 		log: &connectors.NamedLogger{
 			Name:   string(cfg.Asset),
 			Logger: cfg.Logger,
@@ -383,18 +435,10 @@ func (c *Connector) PendingTransactions(accountAlias connectors.AccountAlias) (
 		MethodPendingTransactions, c.cfg.Metrics)
 	defer m.Finish()
 
-	c.pendingLock.Lock()
-	defer c.pendingLock.Unlock()
-
-	account := aliasToAccount(accountAlias)
-
-	var payments []*connectors.Payment
-	for _, tx := range c.memPoolTxs[account] {
-		payments = append(payments, tx)
-	}
-
-	for _, tx := range c.unconfirmedTxs[account] {
-		payments = append(payments, tx)
+	payments, err := c.pendingPayments(aliasToAccount(accountAlias))
+	if err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return nil, err
 	}
 
 	return payments, nil
@@ -404,8 +448,8 @@ func (c *Connector) PendingTransactions(accountAlias connectors.AccountAlias) (
 // instead returns the payment id and waits for it to be approved.
 //
 // NOTE: Part of the connectors.BlockchainConnector interface.
-func (c *Connector) CreatePayment(toAddress, amountStr string) (
-	*connectors.Payment, error) {
+func (c *Connector) CreatePayment(toAddress, amountStr string,
+	opts *connectors.PaymentOptions) (*connectors.Payment, error) {
 	m := crypto.NewMetric(c.cfg.DaemonCfg.Name, string(c.cfg.Asset),
 		MethodCreatePayment, c.cfg.Metrics)
 	defer m.Finish()
@@ -430,11 +474,16 @@ func (c *Connector) CreatePayment(toAddress, amountStr string) (
 		return nil, err
 	}
 
+	direction := connectors.Outgoing
+	if opts != nil && opts.Internal {
+		direction = connectors.Internal
+	}
+
 	payment := &connectors.Payment{
 		PaymentID: generatePaymentID(details.TxID, toAddress, connectors.Outgoing),
 		UpdatedAt: connectors.NowInMilliSeconds(),
 		Status:    connectors.Waiting,
-		Direction: connectors.Outgoing,
+		Direction: direction,
 		Receipt:   toAddress,
 		Asset:     connectors.Asset(c.cfg.Asset),
 		Media:     connectors.Blockchain,
@@ -530,6 +579,14 @@ func (c *Connector) SendPayment(paymentID string) (*connectors.Payment, error) {
 			err)
 	}
 
+	// Payment which has been already sent, or cancelled, shouldn't be
+	// sent again, otherwise nonce of the default address would be shifted.
+	if payment.Status != connectors.Waiting {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("payment(%v) couldn't be sent in "+
+			"status(%v)", paymentID, payment.Status)
+	}
+
 	// Extract the detail about payment, which were putter on the stage
 	// of creation of the payment, in order to use raw transaction
 	// to send it in blockchain.
@@ -554,10 +611,17 @@ func (c *Connector) SendPayment(paymentID string) (*connectors.Payment, error) {
 
 	payment.Status = connectors.Pending
 	payment.UpdatedAt = connectors.NowInMilliSeconds()
+	payment.Detail = &connectors.BlockchainPendingDetails{
+		ConfirmationsLeft: int64(c.cfg.MinConfirmations),
+		RawTx:             details.RawTx,
+	}
 
 	// Only if transaction is going from our default address we need increase
-	// default nonce counter.
-	if payment.Direction == connectors.Outgoing {
+	// default nonce counter. All payments except redirects, including
+	// internal ones to our cold wallet, are sent from default address.
+	isRedirect := payment.Direction == connectors.Internal &&
+		payment.Account == defaultAccount
+	if !isRedirect {
 		nonce, err := c.cfg.AccountStorage.DefaultAddressNonce()
 		if err != nil {
 			m.AddError(metrics.HighSeverity)
@@ -637,59 +701,109 @@ func (c *Connector) PendingBalance(accountAlias connectors.AccountAlias) (decima
 		MethodPendingBalance, c.cfg.Metrics)
 	defer m.Finish()
 
-	c.pendingLock.Lock()
-	defer c.pendingLock.Unlock()
-
-	account := aliasToAccount(accountAlias)
+	payments, err := c.pendingPayments(aliasToAccount(accountAlias))
+	if err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return decimal.Zero, err
+	}
 
 	var amount decimal.Decimal
+	for _, payment := range payments {
+		amount = amount.Add(payment.Amount)
+	}
+
+	return amount.Round(8), nil
+}
+
+// pendingPayments returns stored pending payments of the given account,
+// or of all accounts.
+func (c *Connector) pendingPayments(account string) ([]*connectors.Payment,
+	error) {
+
+	payments, err := connectors.PendingPayments(c.cfg.PaymentStorage,
+		c.cfg.Asset)
+	if err != nil {
+		return nil, errors.Errorf("unable to list pending payments: %v", err)
+	}
+
 	if account == allAccounts {
-		for _, accounts := range c.memPoolTxs {
-			for _, payment := range accounts {
-				amount = amount.Add(payment.Amount)
-			}
-		}
+		return payments, nil
+	}
 
-		for _, accounts := range c.unconfirmedTxs {
-			for _, payment := range accounts {
-				amount = amount.Add(payment.Amount)
-			}
+	var accountPayments []*connectors.Payment
+	for _, payment := range payments {
+		if payment.Account == account {
+			accountPayments = append(accountPayments, payment)
 		}
-	} else {
-		for _, tx := range c.memPoolTxs[account] {
-			amount = amount.Add(tx.Amount)
+	}
+
+	return accountPayments, nil
+}
+
+// savePending saves pending payment and reports whether the payment
+// hasn't been pending before.
+func (c *Connector) savePending(payment *connectors.Payment) (bool, error) {
+	prev, err := c.cfg.PaymentStorage.PaymentByID(payment.PaymentID)
+	isNew := err != nil || prev.Status != connectors.Pending
+
+	if err := c.cfg.PaymentStorage.SavePayment(payment); err != nil {
+		return false, errors.Errorf("unable to save payment(%v): %v",
+			payment.PaymentID, err)
+	}
+
+	return isNew, nil
+}
+
+// syncExpired marks pending payments, which transactions have been neither
+// in the mempool nor in the blockchain for too long, as failed. Pending
+// payments are updated on every sync while transaction is seen.
+func (c *Connector) syncExpired() error {
+	payments, err := connectors.ExpiredPayments(c.cfg.PaymentStorage,
+		c.cfg.Asset, c.cfg.PendingExpiry)
+	if err != nil {
+		return errors.Errorf("unable to list expired payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		payment.Status = connectors.Failed
+		payment.Detail = &connectors.BlockchainFailedDetails{
+			Reason: connectors.FailureReasonExpired,
 		}
 
-		for _, tx := range c.unconfirmedTxs[account] {
-			amount = amount.Add(tx.Amount)
+		if err := c.cfg.PaymentStorage.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
 		}
+
+		c.log.Warnf("Payment(%v) has expired, tx(%v)", payment.PaymentID,
+			payment.MediaID)
 	}
 
-	return amount.Round(8), nil
+	return nil
 }
 
 // syncUnconfirmed process blocks above the minimum confirmations threshold
-// and creates the in-memory map of unconfirmed transactions.
+// and saves unconfirmed transactions as pending payments.
 func (c *Connector) syncUnconfirmed(bestBlockNumber,
-lastSyncedBlockNumber int) (pendingMap, error) {
+lastSyncedBlockNumber int) error {
 
-	unconfirmedTxs := make(pendingMap)
 	for {
 		select {
 		case <-c.quit:
-			return nil, errors.Errorf("sync unconfirmed quit")
+			return errors.Errorf("sync unconfirmed quit")
 		default:
 		}
 
 		if lastSyncedBlockNumber >= bestBlockNumber {
-			return unconfirmedTxs, nil
+			return nil
 		}
 
 		nextBlockNumber := lastSyncedBlockNumber + 1
 		confirmations := int64(bestBlockNumber - nextBlockNumber)
 		block, err := c.client.EthGetBlockByNumber(nextBlockNumber, true)
 		if err != nil {
-			return nil, errors.Errorf("unable to get last sync block "+
+			return errors.Errorf("unable to get last sync block "+
 				"from daemon: %v", err)
 		}
 
@@ -698,7 +812,7 @@ lastSyncedBlockNumber int) (pendingMap, error) {
 			// transaction going to our service.
 			account, err := c.cfg.AccountStorage.GetAccountByAddress(tx.To)
 			if err != nil {
-				return nil, err
+				return err
 			}
 
 			if account == "" {
@@ -723,6 +837,7 @@ lastSyncedBlockNumber int) (pendingMap, error) {
 				Detail: &connectors.BlockchainPendingDetails{
 					Confirmations:     confirmations,
 					ConfirmationsLeft: int64(c.cfg.MinConfirmations) - confirmations,
+					BlockHash:         block.Hash,
 				},
 			}
 
@@ -736,26 +851,75 @@ lastSyncedBlockNumber int) (pendingMap, error) {
 				payment.MediaFee = decimal.Zero
 			}
 
-			if err := c.cfg.PaymentStorage.SavePayment(payment); err != nil {
-				return nil, errors.Errorf("unable to save payment(%v): %v",
-					payment.PaymentID, err)
+			isNew, err := c.savePending(payment)
+			if err != nil {
+				return err
 			}
 
-			unconfirmedTxs.add(payment)
+			if isNew {
+				c.log.Infof("Unconfirmed tx(%v) were added, "+
+					"account(%v), amount(%v), confirmations(%v), "+
+					"left(%v)", payment.PaymentID, payment.Account,
+					payment.Amount, confirmations,
+					int64(c.cfg.MinConfirmations)-confirmations)
+			}
 		}
 
 		lastSyncedBlockNumber = nextBlockNumber
 	}
 }
 
-// syncPending creates the in-memory map of transactions which
-// are in the memory pool of the ethereum blockchain daemon.
-func (c *Connector) syncPending() (pendingMap, error) {
-	mempoolTxs := make(pendingMap)
+// syncSent updates confirmations of the payments sent by the connector,
+// which transactions haven't reached the minimum confirmations threshold
+// yet. Confirmations are counted the same way as for incoming payments.
+func (c *Connector) syncSent(bestBlockNumber int) error {
+	payments, err := connectors.SentPayments(c.cfg.PaymentStorage,
+		c.cfg.Asset)
+	if err != nil {
+		return errors.Errorf("unable to list sent payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		tx, err := c.client.EthGetTransactionByHash(payment.MediaID)
+		if err != nil {
+			return errors.Errorf("unable to get tx(%v): %v",
+				payment.MediaID, err)
+		}
+
+		// Transaction is unknown by the daemon.
+		if tx == nil {
+			continue
+		}
+
+		var confirmations int64
+		if tx.BlockNumber != nil {
+			confirmations = int64(bestBlockNumber - *tx.BlockNumber)
+		}
+
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		payment.Detail = &connectors.BlockchainPendingDetails{
+			Confirmations: confirmations,
+			ConfirmationsLeft: int64(c.cfg.MinConfirmations) -
+				confirmations,
+			BlockHash: tx.BlockHash,
+			RawTx:     connectors.SentRawTx(payment),
+		}
+
+		if err := c.cfg.PaymentStorage.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+	}
+
+	return nil
+}
 
+// syncPending saves transactions which are in the memory pool of the
+// ethereum blockchain daemon as pending payments.
+func (c *Connector) syncPending() error {
 	txs, err := c.client.EthGetPendingTxs()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	for _, tx := range txs {
@@ -763,7 +927,7 @@ func (c *Connector) syncPending() (pendingMap, error) {
 		// transaction going to our service.
 		account, err := c.cfg.AccountStorage.GetAccountByAddress(tx.To)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		if account == "" {
@@ -803,15 +967,19 @@ func (c *Connector) syncPending() (pendingMap, error) {
 			payment.MediaFee = decimal.Zero
 		}
 
-		if err := c.cfg.PaymentStorage.SavePayment(payment); err != nil {
-			return nil, errors.Errorf("unable to save payment(%v): %v",
-				payment.PaymentID, err)
+		isNew, err := c.savePending(payment)
+		if err != nil {
+			return err
 		}
 
-		mempoolTxs.add(payment)
+		if isNew {
+			c.log.Infof("Mempool tx(%v) were added, account(%v), "+
+				"amount(%v)", payment.PaymentID, payment.Account,
+				payment.Amount)
+		}
 	}
 
-	return mempoolTxs, nil
+	return nil
 }
 
 // syncConfirmed process new blocks and notify subscribed clients that
@@ -843,6 +1011,16 @@ func (c *Connector) syncConfirmed(bestBlockNumber int,
 			return nil, err
 		}
 
+		// If next block isn't built on top of the last synced one,
+		// than re-organisation has happened in the meantime, stop syncing
+		// so that it will be handled on the next sync.
+		if block.ParentHash != lastSyncedBlock.Hash {
+			c.log.Warnf("Block(%v) parent hash(%v) differs from last "+
+				"synced block hash(%v)", block.Hash, block.ParentHash,
+				lastSyncedBlock.Hash)
+			return lastSyncedBlock, nil
+		}
+
 		for _, confirmedTx := range block.Transactions {
 			// By the given address identify is sender address belongs
 			// to our system.
@@ -957,6 +1135,10 @@ func (c *Connector) syncConfirmed(bestBlockNumber int,
 				Amount:    amount,
 				MediaFee:  fee,
 				MediaID:   confirmedTx.Hash,
+				Detail: &connectors.BlockchainConfirmedDetails{
+					BlockHash:   block.Hash,
+					BlockNumber: int64(block.Number),
+				},
 			}
 
 			if needSaveInternal {
@@ -982,6 +1164,23 @@ func (c *Connector) syncConfirmed(bestBlockNumber int,
 					confirmedTx.To, connectors.Outgoing)
 				outgoingPayment.Direction = connectors.Outgoing
 
+				// Payments to our own wallets, for example sweep in the
+				// cold wallet, are created as outgoing ones but marked as
+				// internal, so direction is taken from the stored payment.
+				storedPayment, err := c.cfg.PaymentStorage.PaymentByID(
+					outgoingPayment.PaymentID)
+				if err == nil {
+					outgoingPayment.Direction = storedPayment.Direction
+
+					// Transaction is kept in order to broadcast it again,
+					// if block is orphaned.
+					outgoingPayment.Detail = &connectors.BlockchainConfirmedDetails{
+						BlockHash:   block.Hash,
+						BlockNumber: int64(block.Number),
+						RawTx:       connectors.SentRawTx(storedPayment),
+					}
+				}
+
 				if err := c.cfg.PaymentStorage.SavePayment(&outgoingPayment); err != nil {
 					return nil, errors.Errorf("unable to add payment to storage: %v",
 						outgoingPayment.PaymentID)
@@ -1040,6 +1239,123 @@ func (c *Connector) syncConfirmed(bestBlockNumber int,
 	}
 }
 
+// findForkBlock walks back from the given block until the block of the main
+// chain is found, and returns it along with the hashes of the orphaned
+// blocks.
+func (c *Connector) findForkBlock(block *ethrpc.Block) (*ethrpc.Block,
+	map[string]struct{}, error) {
+
+	orphanedBlocks := make(map[string]struct{})
+	for {
+		canonicalBlock, err := c.client.EthGetBlockByNumber(block.Number,
+			false)
+		if err != nil {
+			return nil, nil, errors.Errorf("unable to get block(%v): %v",
+				block.Number, err)
+		}
+
+		// New chain might be shorter than the orphaned one, in this
+		// case there is no block with such number.
+		if canonicalBlock != nil && canonicalBlock.Hash == block.Hash {
+			return block, orphanedBlocks, nil
+		}
+
+		orphanedBlocks[block.Hash] = struct{}{}
+
+		parentBlock, err := c.client.EthGetBlockByHash(block.ParentHash,
+			false)
+		if err != nil {
+			return nil, nil, errors.Errorf("unable to get parent block("+
+				"%v): %v", block.ParentHash, err)
+		}
+
+		if parentBlock == nil {
+			return nil, nil, errors.Errorf("parent block(%v) not found",
+				block.ParentHash)
+		}
+
+		block = parentBlock
+	}
+}
+
+// handleReorg reverts payments which were confirmed in the orphaned blocks.
+// If transaction of the payment is still known by the daemon, payment is
+// reverted to pending, and will be completed again after transaction is
+// confirmed in the new chain, otherwise payment is marked as failed.
+func (c *Connector) handleReorg(bestBlockNumber int, forkBlock *ethrpc.Block,
+	orphanedBlocks map[string]struct{}) error {
+
+	m := crypto.NewMetric(c.cfg.DaemonCfg.Name, string(c.cfg.Asset),
+		MethodReorg, c.cfg.Metrics)
+	defer m.Finish()
+
+	m.ReorgDepth(len(orphanedBlocks))
+
+	payments, err := connectors.OrphanedPayments(c.cfg.PaymentStorage,
+		c.cfg.Asset, orphanedBlocks)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to get orphaned payments: %v", err)
+	}
+
+	event := &connectors.ReorgEvent{
+		Asset:           c.cfg.Asset,
+		ForkBlockHash:   forkBlock.Hash,
+		ForkBlockNumber: int64(forkBlock.Number),
+		Depth:           len(orphanedBlocks),
+	}
+
+	for _, payment := range payments {
+		tx, err := c.client.EthGetTransactionByHash(payment.MediaID)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to get tx(%v): %v",
+				payment.MediaID, err)
+		}
+
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+
+		// Daemon returns empty transaction if it isn't known.
+		if tx == nil || tx.Hash == "" {
+			payment.Status = connectors.Failed
+			payment.Detail = nil
+			event.FailedPayments = append(event.FailedPayments,
+				payment.PaymentID)
+		} else {
+			var confirmations int64
+			if tx.BlockNumber != nil {
+				confirmations = int64(bestBlockNumber - *tx.BlockNumber)
+			}
+
+			payment.Status = connectors.Pending
+			payment.Detail = &connectors.BlockchainPendingDetails{
+				Confirmations: confirmations,
+				ConfirmationsLeft: int64(c.cfg.MinConfirmations) -
+					confirmations,
+				RawTx: connectors.SentRawTx(payment),
+			}
+			event.RevertedPayments = append(event.RevertedPayments,
+				payment.PaymentID)
+		}
+
+		if err := c.cfg.PaymentStorage.SavePayment(payment); err != nil {
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+	}
+
+	c.log.Warnf("Chain re-organisation, fork block(%v), depth(%v), "+
+		"reverted payments(%v), failed payments(%v)", event.ForkBlockHash,
+		event.Depth, event.RevertedPayments, event.FailedPayments)
+
+	if c.cfg.OnReorg != nil {
+		c.cfg.OnReorg(event)
+	}
+
+	return nil
+}
+
 // makeRedirect is used to make a redirect of previously received money on
 // default address. Such aggregation is needed so that later we could use
 // default address to send money with one transaction.
@@ -1153,11 +1469,41 @@ func (c *Connector) sync(lastSyncedBlockHash string) (string, error) {
 
 	lastSyncedBlock, err := c.client.EthGetBlockByHash(lastSyncedBlockHash, false)
 	if err != nil {
-		// TODO(andrew.shvv) Check reoginizations
 		m.AddError(metrics.HighSeverity)
 		return lastSyncedBlockHash, errors.Errorf("unable to get last sync block from daemon: %v", err)
 	}
 
+	// If last synced block isn't in the main chain anymore, than chain
+	// re-organisation has happened, and syncing should continue from the
+	// fork block.
+	forkBlock, orphanedBlocks, err := c.findForkBlock(lastSyncedBlock)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return lastSyncedBlockHash, errors.Errorf("unable to find fork "+
+			"block: %v", err)
+	}
+
+	if len(orphanedBlocks) != 0 {
+		c.log.Infof("Fork have been detected on block(%v) using it as "+
+			"last synced block", forkBlock.Hash)
+
+		err := c.handleReorg(bestBlockNumber, forkBlock, orphanedBlocks)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return lastSyncedBlockHash, errors.Errorf("unable to handle "+
+				"re-organisation: %v", err)
+		}
+
+		hash := []byte(forkBlock.Hash)
+		if err := c.cfg.StateStorage.PutLastSyncedHash(hash); err != nil {
+			m.AddError(metrics.HighSeverity)
+			return lastSyncedBlockHash, errors.Errorf("unable to put "+
+				"block hash in db: %v", err)
+		}
+
+		lastSyncedBlock = forkBlock
+	}
+
 	// Sync block below minimum confirmations threshold
 	lastSyncedBlock, err = c.syncConfirmed(bestBlockNumber, lastSyncedBlock)
 	if err != nil {
@@ -1166,44 +1512,45 @@ func (c *Connector) sync(lastSyncedBlockHash string) (string, error) {
 	}
 	lastSyncedBlockHash = lastSyncedBlock.Hash
 
-	// Sync block above minimum confirmation threshold and
-	// populate unconfirmed pending map with transactions.
-	unconfirmedTxs, err := c.syncUnconfirmed(bestBlockNumber,
-		lastSyncedBlock.Number)
+	// Sync block above minimum confirmation threshold and save
+	// unconfirmed transactions as pending payments.
+	err = c.syncUnconfirmed(bestBlockNumber, lastSyncedBlock.Number)
 	if err != nil {
 		m.AddError(metrics.MiddleSeverity)
 		return lastSyncedBlockHash, errors.Errorf("unable to sync unconfirmed txs: %v", err)
 	}
 
-	c.pendingLock.Lock()
-	c.unconfirmedTxs.merge(unconfirmedTxs,
-		func(payment *connectors.Payment) {
-			details, ok := payment.Detail.(*connectors.BlockchainPendingDetails)
-			if !ok {
-				c.log.Warn("unable get payment(%v) pending tx details", payment.PaymentID)
-				return
-			}
+	if err := c.syncPending(); err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return lastSyncedBlockHash,
+			errors.Errorf("unable to fetch mempool txs: %v", err)
+	}
 
-			c.log.Infof("Unconfirmed tx(%v) were added, "+
-				"account(%v), amount(%v), confirmations(%v), "+
-				"left(%v)", payment.PaymentID, payment.Account, payment.Amount,
-				details.Confirmations, details.ConfirmationsLeft)
-		})
-	c.pendingLock.Unlock()
+	// Recovery is run before the sync of sent payments, so that dropped
+	// transactions are sent again before they are expired. Failed recovery
+	// is retried on the next interval, and shouldn't stop the sync of
+	// payments.
+	if time.Since(c.lastRecovery) >= c.cfg.RecoveryInterval {
+		if err := c.recoverPayments(); err != nil {
+			m.AddError(metrics.MiddleSeverity)
+			c.log.Errorf("unable to recover payments: %v", err)
+		}
 
-	memPoolTxs, err := c.syncPending()
-	if err != nil {
+		c.lastRecovery = time.Now()
+	}
+
+	if err := c.syncSent(bestBlockNumber); err != nil {
 		m.AddError(metrics.MiddleSeverity)
 		return lastSyncedBlockHash,
-			errors.Errorf("unable to fetch mempool txs: %v", err)
+			errors.Errorf("unable to sync sent txs: %v", err)
 	}
 
-	c.pendingLock.Lock()
-	c.memPoolTxs.merge(memPoolTxs, func(tx *connectors.Payment) {
-		c.log.Infof("Mempool tx(%v) were added, "+
-			"account(%v), amount(%v)", tx.PaymentID, tx.Account, tx.Amount)
-	})
-	c.pendingLock.Unlock()
+	// Pending payments which haven't been seen for too long are failed.
+	if err := c.syncExpired(); err != nil {
+		m.AddError(metrics.MiddleSeverity)
+		return lastSyncedBlockHash, errors.Errorf("unable to sync "+
+			"expired payments: %v", err)
+	}
 
 	// Check number of funds available and track this metric in metric
 	// backend for farther analysis.
@@ -0,0 +1,101 @@
+package geth
+
+import (
+	"github.com/bitlum/connector/connectors"
+	"github.com/go-errors/errors"
+)
+
+// recoverPayments rebroadcasts transactions of the sent payments, which
+// have been dropped by the daemon, for example after restart or transaction
+// pool eviction, and resolves stale waiting payments according to the
+// policy.
+func (c *Connector) recoverPayments() error {
+	if err := c.rebroadcastSent(); err != nil {
+		return errors.Errorf("unable to rebroadcast sent payments: %v", err)
+	}
+
+	if err := c.resolveWaiting(); err != nil {
+		return errors.Errorf("unable to resolve waiting payments: %v", err)
+	}
+
+	return nil
+}
+
+// rebroadcastSent sends again transactions of the sent payments, which are
+// neither in the transaction pool nor in the blockchain.
+func (c *Connector) rebroadcastSent() error {
+	payments, err := connectors.SentPayments(c.cfg.PaymentStorage,
+		c.cfg.Asset)
+	if err != nil {
+		return errors.Errorf("unable to list sent payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		tx, err := c.client.EthGetTransactionByHash(payment.MediaID)
+		if err != nil {
+			return errors.Errorf("unable to get tx(%v): %v",
+				payment.MediaID, err)
+		}
+
+		if tx != nil {
+			continue
+		}
+
+		// Transaction might be rejected if its nonce has been used by
+		// other transaction, such payment will be failed on expiration, so
+		// only log the error and proceed with others.
+		rawTx := connectors.SentRawTx(payment)
+		if _, err := c.client.EthSendRawTransaction(string(rawTx)); err != nil {
+			c.log.Errorf("Unable to rebroadcast payment(%v) tx(%v): %v",
+				payment.PaymentID, payment.MediaID, err)
+			continue
+		}
+
+		c.log.Warnf("Payment(%v) tx(%v) has been dropped by daemon, "+
+			"rebroadcast it", payment.PaymentID, payment.MediaID)
+	}
+
+	return nil
+}
+
+// resolveWaiting sends or cancels the waiting payments, which haven't been
+// sent for too long. Default address nonce is increased only on send, so
+// cancelled payments leave no gaps in the nonce sequence.
+func (c *Connector) resolveWaiting() error {
+	payments, err := connectors.StaleWaitingPayments(c.cfg.PaymentStorage,
+		c.cfg.Asset, c.cfg.WaitingTimeout)
+	if err != nil {
+		return errors.Errorf("unable to list stale waiting payments: %v", err)
+	}
+
+	for _, payment := range payments {
+		if c.cfg.WaitingPolicy == connectors.WaitingBroadcast {
+			// In case of failure payment is marked as failed by send.
+			if _, err := c.SendPayment(payment.PaymentID); err != nil {
+				c.log.Errorf("Unable to send stale waiting payment(%v): %v",
+					payment.PaymentID, err)
+				continue
+			}
+
+			c.log.Warnf("Stale waiting payment(%v) has been sent",
+				payment.PaymentID)
+			continue
+		}
+
+		payment.Status = connectors.Failed
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		payment.Detail = &connectors.BlockchainFailedDetails{
+			Reason: connectors.FailureReasonCancelled,
+		}
+
+		if err := c.cfg.PaymentStorage.SavePayment(payment); err != nil {
+			return errors.Errorf("unable to save payment(%v): %v",
+				payment.PaymentID, err)
+		}
+
+		c.log.Warnf("Stale waiting payment(%v) has been cancelled",
+			payment.PaymentID)
+	}
+
+	return nil
+}
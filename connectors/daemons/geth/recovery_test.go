@@ -0,0 +1,63 @@
+package geth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btclog"
+)
+
+func TestSendCancelledPayment(t *testing.T) {
+	store := mock.NewPaymentsStore()
+	store.Payments["payment"] = &connectors.Payment{
+		PaymentID: "payment",
+		UpdatedAt: connectors.ConvertTimeToMilliSeconds(
+			time.Now().Add(-time.Hour)),
+		Status:    connectors.Waiting,
+		Direction: connectors.Outgoing,
+		Asset:     connectors.ETH,
+		Media:     connectors.Blockchain,
+		Detail:    &connectors.GeneratedTxDetails{RawTx: []byte("0x00")},
+	}
+
+	// Client isn't specified, so connector would panic if payment was
+	// actually sent.
+	c := &Connector{
+		cfg: &Config{
+			DaemonCfg:      &DaemonConfig{Name: "geth"},
+			Asset:          connectors.ETH,
+			Metrics:        &crypto.EmptyBackend{},
+			PaymentStorage: store,
+			WaitingTimeout: time.Minute,
+			WaitingPolicy:  connectors.WaitingCancel,
+		},
+		log: &connectors.NamedLogger{
+			Name:   "ETH",
+			Logger: btclog.Disabled,
+		},
+	}
+
+	if err := c.resolveWaiting(); err != nil {
+		t.Fatalf("unable to resolve waiting payments: %v", err)
+	}
+
+	if store.Payments["payment"].Status != connectors.Failed {
+		t.Fatalf("stale payment isn't cancelled")
+	}
+
+	if _, err := c.SendPayment("payment"); err == nil {
+		t.Fatalf("cancelled payment is sent")
+	}
+
+	// Payment which failed to be broadcasted keeps the transaction, but
+	// shouldn't be sent either.
+	store.Payments["payment"].Detail = &connectors.GeneratedTxDetails{
+		RawTx: []byte("0x00"),
+	}
+	if _, err := c.SendPayment("payment"); err == nil {
+		t.Fatalf("failed payment is sent")
+	}
+}
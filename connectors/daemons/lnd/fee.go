@@ -0,0 +1,210 @@
+package lnd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+// feeLimit returns the fee limit of the payment, options of the payment
+// take precedence over the defaults of the connector.
+func (c *Connector) feeLimit(opts *connectors.SendOptions) *lnrpc.FeeLimit {
+	maxFee, maxFeePercent := c.cfg.MaxFee, c.cfg.MaxFeePercent
+	if opts != nil && (opts.MaxFee.GreaterThan(decimal.Zero) ||
+		opts.MaxFeePercent > 0) {
+		maxFee, maxFeePercent = opts.MaxFee, opts.MaxFeePercent
+	}
+
+	if maxFee.GreaterThan(decimal.Zero) {
+		return &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Fixed{
+				Fixed: maxFee.Mul(satoshiPerBitcoin).IntPart(),
+			},
+		}
+	}
+
+	return &lnrpc.FeeLimit{
+		Limit: &lnrpc.FeeLimit_Percent{
+			Percent: maxFeePercent,
+		},
+	}
+}
+
+// paymentTimeout returns the time which is given for payment to be sent.
+func (c *Connector) paymentTimeout(opts *connectors.SendOptions) time.Duration {
+	if opts != nil && opts.Timeout > 0 {
+		return opts.Timeout
+	}
+
+	return c.cfg.PaymentTimeout
+}
+
+// errPaymentInFlight is returned if payment hasn't been finished in the
+// given time, in this case payment might be still delivered.
+var errPaymentInFlight = errors.New("payment is in flight")
+
+// maxFeeMsat returns the maximum fee in milli-satoshis which is allowed by
+// the fee limit for the payment of the given amount.
+func maxFeeMsat(limit *lnrpc.FeeLimit, amountSat int64) int64 {
+	switch l := limit.Limit.(type) {
+	case *lnrpc.FeeLimit_Fixed:
+		return l.Fixed * 1000
+	case *lnrpc.FeeLimit_FixedMsat:
+		return l.FixedMsat
+	case *lnrpc.FeeLimit_Percent:
+		return amountSat * 1000 * l.Percent / 100
+	default:
+		return amountSat * 1000
+	}
+}
+
+// checkFeeLimit queries the best route to the destination without fee
+// limit, and returns the error if the fee of this route exceeds the limit.
+// If route can't be found the check is skipped, and the reason of the
+// failure is left for the payment itself to report.
+func (c *Connector) checkFeeLimit(dest string, amountSat int64,
+	limit *lnrpc.FeeLimit) error {
+
+	req := &lnrpc.QueryRoutesRequest{
+		PubKey: dest,
+		Amt:    amountSat,
+	}
+
+	resp, err := c.client.QueryRoutes(context.Background(), req)
+	if err != nil {
+		log.Debugf("Unable to query route to %v: %v", dest, err)
+		return nil
+	}
+
+	if len(resp.Routes) == 0 {
+		return nil
+	}
+
+	fee := resp.Routes[0].TotalFeesMsat
+	if max := maxFeeMsat(limit, amountSat); fee > max {
+		return &connectors.ErrFeeLimitExceeded{
+			Reason: fmt.Sprintf("route fee(%v msat) exceeds fee "+
+				"limit(%v msat)", fee, max),
+		}
+	}
+
+	return nil
+}
+
+// isNoRouteError returns true if lnd failed to send payment because route
+// to the receiver hasn't been found, in this case funds haven't been sent.
+func isNoRouteError(paymentError string) bool {
+	paymentError = strings.ToLower(paymentError)
+	return strings.Contains(paymentError, "no_route") ||
+		strings.Contains(paymentError, "no route") ||
+		strings.Contains(paymentError, "unable to find a path")
+}
+
+// lookupPayment returns the payment with the given hash from lnd, or nil
+// if lnd doesn't know about such payment.
+func (c *Connector) lookupPayment(paymentHash string) (*lnrpc.Payment,
+	error) {
+
+	req := &lnrpc.ListPaymentsRequest{
+		IncludeIncomplete: true,
+	}
+
+	resp, err := c.client.ListPayments(context.Background(), req)
+	if err != nil {
+		return nil, errors.Errorf("unable to list payments: %v", err)
+	}
+
+	for _, payment := range resp.Payments {
+		if payment.PaymentHash == paymentHash {
+			return payment, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// sendPayment sends the payment with fee limit and timeout taken from the
+// options, and waits for it to be finished. If payment has definitely
+// failed, than typed error is returned, if payment hasn't been finished in
+// time or is still in flight errPaymentInFlight is returned.
+func (c *Connector) sendPayment(req *lnrpc.SendRequest, dest string,
+	amountSat int64, paymentHash string, opts *connectors.SendOptions) (
+	*lnrpc.SendResponse, error) {
+
+	req.FeeLimit = c.feeLimit(opts)
+
+	// lnd doesn't report that payment has failed because of the fee limit,
+	// for that reason fee of the route is checked before sending.
+	if err := c.checkFeeLimit(dest, amountSat, req.FeeLimit); err != nil {
+		return nil, err
+	}
+
+	timeout := c.paymentTimeout(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := c.client.SendPaymentSync(ctx, req)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			log.Warnf("Payment hasn't been sent in %v, it might be still "+
+				"in flight", timeout)
+			return nil, errPaymentInFlight
+		}
+
+		return nil, errors.Errorf("unable to send payment: %v", err)
+	}
+
+	if resp.PaymentError == "" {
+		return resp, nil
+	}
+
+	if isNoRouteError(resp.PaymentError) {
+		return nil, &connectors.ErrRoutingFailed{
+			Reason: resp.PaymentError,
+		}
+	}
+
+	// Payment error doesn't guarantee that funds haven't been sent, for
+	// example invoice might be already paid or payment might be in
+	// transition, for that reason the real state of the payment is checked.
+	payment, err := c.lookupPayment(paymentHash)
+	if err != nil {
+		return nil, errors.Errorf("unable to send payment: %v, and check "+
+			"its status: %v", resp.PaymentError, err)
+	}
+
+	if payment == nil {
+		return nil, errors.Errorf("unable to send payment: %v",
+			resp.PaymentError)
+	}
+
+	switch payment.Status {
+	case lnrpc.Payment_SUCCEEDED:
+		return &lnrpc.SendResponse{
+			PaymentRoute: &lnrpc.Route{
+				TotalFees:     payment.FeeSat,
+				TotalFeesMsat: payment.FeeMsat,
+			},
+		}, nil
+
+	case lnrpc.Payment_IN_FLIGHT:
+		log.Warnf("Payment(%v) is still in flight: %v", paymentHash,
+			resp.PaymentError)
+		return nil, errPaymentInFlight
+
+	case lnrpc.Payment_FAILED:
+		return nil, &connectors.ErrRoutingFailed{
+			Reason: resp.PaymentError,
+		}
+
+	default:
+		return nil, errors.Errorf("unable to send payment: %v, status of "+
+			"payment is unknown", resp.PaymentError)
+	}
+}
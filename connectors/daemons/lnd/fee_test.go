@@ -0,0 +1,276 @@
+package lnd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+func TestFeeLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxFee  decimal.Decimal
+		opts    *connectors.SendOptions
+		fixed   int64
+		percent int64
+	}{
+		{
+			name:    "default percent",
+			percent: 3,
+		},
+		{
+			name:   "default fixed",
+			maxFee: decimal.New(1, -5),
+			fixed:  1000,
+		},
+		{
+			name:   "options percent",
+			maxFee: decimal.New(1, -5),
+			opts: &connectors.SendOptions{
+				MaxFeePercent: 1,
+			},
+			percent: 1,
+		},
+		{
+			name: "options fixed",
+			opts: &connectors.SendOptions{
+				MaxFee: decimal.New(2, -5),
+			},
+			fixed: 2000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestConnector(&mockClient{})
+			c.cfg.MaxFee = test.maxFee
+
+			limit := c.feeLimit(test.opts)
+			if test.fixed != 0 {
+				if limit.GetFixed() != test.fixed {
+					t.Fatalf("wrong fixed limit: %v", limit)
+				}
+			} else if limit.GetPercent() != test.percent {
+				t.Fatalf("wrong percent limit: %v", limit)
+			}
+		})
+	}
+}
+
+func TestPaymentTimeout(t *testing.T) {
+	c := newTestConnector(&mockClient{})
+
+	if c.paymentTimeout(nil) != time.Minute {
+		t.Fatalf("wrong default timeout: %v", c.paymentTimeout(nil))
+	}
+
+	opts := &connectors.SendOptions{Timeout: time.Second}
+	if c.paymentTimeout(opts) != time.Second {
+		t.Fatalf("options timeout isn't used: %v", c.paymentTimeout(opts))
+	}
+}
+
+const testPaymentHash = "0001020304050607080900010203040506070809000102030" +
+	"405060708090102"
+
+// isUntypedError returns true if error doesn't guarantee that payment has
+// failed, so that it couldn't be sent by other means.
+func isUntypedError(err error) bool {
+	switch err.(type) {
+	case *connectors.ErrRoutingFailed, *connectors.ErrFeeLimitExceeded:
+		return false
+	}
+	return err != nil && err != errPaymentInFlight
+}
+
+func TestSendPaymentErrors(t *testing.T) {
+	route := &lnrpc.Route{TotalFees: 10, TotalFeesMsat: 10000}
+
+	tests := []struct {
+		name   string
+		client *mockClient
+		check  func(err error) bool
+		sent   bool
+	}{
+		{
+			name: "fee limit exceeded",
+			client: &mockClient{
+				routes: []*lnrpc.Route{{TotalFeesMsat: 40000}},
+			},
+			check: func(err error) bool {
+				_, ok := err.(*connectors.ErrFeeLimitExceeded)
+				return ok
+			},
+			sent: false,
+		},
+		{
+			name: "route not found",
+			client: &mockClient{
+				routesErr: errors.New("unable to find a path"),
+				sendResp: &lnrpc.SendResponse{
+					PaymentError: "unable to find a path to destination",
+				},
+			},
+			check: func(err error) bool {
+				_, ok := err.(*connectors.ErrRoutingFailed)
+				return ok
+			},
+			sent: true,
+		},
+		{
+			name: "no route",
+			client: &mockClient{
+				routes: []*lnrpc.Route{route},
+				sendResp: &lnrpc.SendResponse{
+					PaymentError: "no_route",
+				},
+			},
+			check: func(err error) bool {
+				_, ok := err.(*connectors.ErrRoutingFailed)
+				return ok
+			},
+			sent: true,
+		},
+		{
+			name: "already paid",
+			client: &mockClient{
+				routes: []*lnrpc.Route{route},
+				sendResp: &lnrpc.SendResponse{
+					PaymentError: "invoice is already paid",
+				},
+				payments: []*lnrpc.Payment{{
+					PaymentHash: testPaymentHash,
+					Status:      lnrpc.Payment_SUCCEEDED,
+				}},
+			},
+			check: func(err error) bool {
+				return err == nil
+			},
+			sent: true,
+		},
+		{
+			name: "payment in transition",
+			client: &mockClient{
+				routes: []*lnrpc.Route{route},
+				sendResp: &lnrpc.SendResponse{
+					PaymentError: "payment is in transition",
+				},
+				payments: []*lnrpc.Payment{{
+					PaymentHash: testPaymentHash,
+					Status:      lnrpc.Payment_IN_FLIGHT,
+				}},
+			},
+			check: func(err error) bool {
+				return err == errPaymentInFlight
+			},
+			sent: true,
+		},
+		{
+			name: "failed payment",
+			client: &mockClient{
+				routes: []*lnrpc.Route{route},
+				sendResp: &lnrpc.SendResponse{
+					PaymentError: "incorrect_payment_details",
+				},
+				payments: []*lnrpc.Payment{{
+					PaymentHash: testPaymentHash,
+					Status:      lnrpc.Payment_FAILED,
+				}},
+			},
+			check: func(err error) bool {
+				_, ok := err.(*connectors.ErrRoutingFailed)
+				return ok
+			},
+			sent: true,
+		},
+		{
+			name: "unknown payment",
+			client: &mockClient{
+				routes: []*lnrpc.Route{route},
+				sendResp: &lnrpc.SendResponse{
+					PaymentError: "invoice is already paid",
+				},
+			},
+			check: isUntypedError,
+			sent:  true,
+		},
+		{
+			name: "rpc failure",
+			client: &mockClient{
+				routes:  []*lnrpc.Route{route},
+				sendErr: errors.New("rpc failure"),
+			},
+			check: isUntypedError,
+			sent:  true,
+		},
+		{
+			name: "success",
+			client: &mockClient{
+				routes: []*lnrpc.Route{route},
+				sendResp: &lnrpc.SendResponse{
+					PaymentRoute: route,
+				},
+			},
+			check: func(err error) bool {
+				return err == nil
+			},
+			sent: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestConnector(test.client)
+
+			// With 3 percent limit the fee of 1000 sat payment is
+			// limited by 30 sat.
+			_, err := c.sendPayment(&lnrpc.SendRequest{}, c.nodeAddr, 1000,
+				testPaymentHash, nil)
+			if !test.check(err) {
+				t.Fatalf("wrong error: %v", err)
+			}
+
+			if (len(test.client.sendRequests) != 0) != test.sent {
+				t.Fatalf("expected payment to be sent(%v)", test.sent)
+			}
+		})
+	}
+}
+
+func TestSendKeySendInFlight(t *testing.T) {
+	client := &mockClient{sendBlock: true}
+	c := newTestConnector(client)
+
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodSendTo,
+		c.cfg.Metrics)
+
+	pubKey := "02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5"
+	opts := &connectors.SendOptions{Timeout: 10 * time.Millisecond}
+
+	payment, err := c.sendKeySend(m, pubKey, 1000, decimal.New(1, -5), opts)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	if payment.Status != connectors.Pending {
+		t.Fatalf("wrong status of in flight payment: %v", payment.Status)
+	}
+
+	if payment.MediaID == "" {
+		t.Fatalf("payment hash isn't saved")
+	}
+
+	stored, err := c.cfg.PaymentStore.PaymentByID(payment.PaymentID)
+	if err != nil {
+		t.Fatalf("in flight payment isn't saved: %v", err)
+	}
+
+	if stored.Status != connectors.Pending {
+		t.Fatalf("wrong status of stored payment: %v", stored.Status)
+	}
+}
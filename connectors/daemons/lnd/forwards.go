@@ -0,0 +1,116 @@
+package lnd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/metrics"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	MethodSyncForwards = "SyncForwards"
+)
+
+// forwardsBatchSize is the maximum number of forwarding events which are
+// requested from lnd at once.
+const forwardsBatchSize = 1000
+
+// syncForwards fetches forwarding events which haven't been processed yet
+// and stores them as internal lightning payments. The index of the last
+// processed event is persisted, so that events aren't stored twice after
+// restart.
+func (c *Connector) syncForwards() error {
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodSyncForwards, c.cfg.Metrics)
+	defer m.Finish()
+
+	index, err := c.cfg.StateStorage.ForwardingIndex()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to get forwarding index: %v", err)
+	}
+
+	for {
+		// If start time is not specified lnd returns events only for the
+		// last day, and index offset is counted from the start time, for
+		// that reason we start from the very beginning to make index
+		// absolute.
+		req := &lnrpc.ForwardingHistoryRequest{
+			StartTime:    1,
+			EndTime:      uint64(time.Now().Unix()),
+			IndexOffset:  index,
+			NumMaxEvents: forwardsBatchSize,
+		}
+
+		resp, err := c.client.ForwardingHistory(context.Background(), req)
+		if err != nil {
+			m.AddError(metrics.MiddleSeverity)
+			return errors.Errorf("unable to get forwarding history: %v", err)
+		}
+
+		for i, event := range resp.ForwardingEvents {
+			eventIndex := index + uint32(i) + 1
+			payment := c.forwardToPayment(eventIndex, event)
+
+			if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+				m.AddError(metrics.HighSeverity)
+				return errors.Errorf("unable to save forward payment(%v): %v",
+					payment.PaymentID, err)
+			}
+
+			log.Infof("Forwarded payment, channels(%v -> %v), amount(%v), "+
+				"fee(%v)", event.ChanIdIn, event.ChanIdOut, payment.Amount,
+				payment.Detail.(*connectors.LightningForwardDetails).Fee)
+		}
+
+		if len(resp.ForwardingEvents) == 0 {
+			return nil
+		}
+
+		index = resp.LastOffsetIndex
+		if err := c.cfg.StateStorage.PutForwardingIndex(index); err != nil {
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to save forwarding index: %v", err)
+		}
+
+		if len(resp.ForwardingEvents) < forwardsBatchSize {
+			return nil
+		}
+	}
+}
+
+// forwardToPayment converts lnd forwarding event in the internal payment.
+func (c *Connector) forwardToPayment(index uint32,
+	event *lnrpc.ForwardingEvent) *connectors.Payment {
+	// Forwarding indexes of different lnd instances overlap, for that
+	// reason asset is included in the id.
+	id := fmt.Sprintf("%v_forward_%v", c.cfg.Asset, index)
+
+	return &connectors.Payment{
+		PaymentID: generatePaymentID(id, connectors.Internal),
+		UpdatedAt: int64(event.Timestamp) * 1000,
+		Status:    connectors.Completed,
+		Direction: connectors.Internal,
+		Asset:     c.cfg.Asset,
+		Media:     connectors.Lightning,
+		MediaID:   id,
+		Amount:    sat2DecAmount(btcutil.Amount(event.AmtOut)).Round(8),
+
+		// Fee hasn't been paid by us, but rather earned, for that reason
+		// it is kept in details, so that it wouldn't be counted as spent
+		// fee.
+		MediaFee: decimal.Zero,
+		Detail: &connectors.LightningForwardDetails{
+			ChanIDIn:  event.ChanIdIn,
+			ChanIDOut: event.ChanIdOut,
+			AmountIn:  sat2DecAmount(btcutil.Amount(event.AmtIn)).Round(8),
+			Fee:       sat2DecAmount(btcutil.Amount(event.Fee)).Round(8),
+		},
+	}
+}
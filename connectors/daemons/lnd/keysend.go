@@ -0,0 +1,175 @@
+package lnd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/metrics"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	MethodValidatePubKey = "ValidatePubKey"
+
+	// keySendType is the custom record type which is used by lnd to carry
+	// the payment preimage of the spontaneous payment.
+	keySendType uint64 = 5482373484
+
+	// preimageSize is the size of the payment preimage in bytes.
+	preimageSize = 32
+)
+
+// decodeNodePubKey decodes hex encoded compressed public key of the
+// lightning network node.
+func decodeNodePubKey(pubKey string) (*btcec.PublicKey, error) {
+	pubKeyBytes, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return nil, errors.Errorf("unable decode identity key from "+
+			"string: %v", err)
+	}
+
+	if len(pubKeyBytes) != btcec.PubKeyBytesLenCompressed {
+		return nil, errors.Errorf("wrong identity key length(%v)",
+			len(pubKeyBytes))
+	}
+
+	key, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, errors.Errorf("unable decode identity key: %v", err)
+	}
+
+	return key, nil
+}
+
+// isNodePubKey returns true if receipt is the public key of the lightning
+// network node rather than lightning network invoice.
+func isNodePubKey(receipt string) bool {
+	_, err := decodeNodePubKey(receipt)
+	return err == nil
+}
+
+// ValidatePubKey takes the hex encoded public key of lightning network node,
+// which is used as a receipt of the spontaneous payment, and ensures it is
+// valid.
+//
+// NOTE: Part of the connectors.LightningConnector interface.
+func (c *Connector) ValidatePubKey(pubKey, amountStr string) error {
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodValidatePubKey, c.cfg.Metrics)
+	defer m.Finish()
+
+	if _, err := btcToSatoshi(amountStr); err != nil {
+		m.AddError(metrics.LowSeverity)
+		return errors.Errorf("unable convert amount: %v", err)
+	}
+
+	if _, err := decodeNodePubKey(pubKey); err != nil {
+		m.AddError(metrics.LowSeverity)
+		return err
+	}
+
+	if pubKey == c.nodeAddr {
+		m.AddError(metrics.LowSeverity)
+		return errors.Errorf("unable send spontaneous payment to ourselves")
+	}
+
+	return nil
+}
+
+// sendKeySend is used to send spontaneous payment to the lightning network
+// node with the given public key. Preimage of the payment is generated
+// locally and delivered to the receiver in the custom record of the onion.
+func (c *Connector) sendKeySend(m crypto.Metric, pubKey string, amountSat int64,
+	paymentAmt decimal.Decimal, opts *connectors.SendOptions) (
+	*connectors.Payment, error) {
+
+	key, err := decodeNodePubKey(pubKey)
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	if pubKey == c.nodeAddr {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("unable send spontaneous payment to ourselves")
+	}
+
+	if amountSat <= 0 {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("unable send spontaneous payment " +
+			"with zero amount")
+	}
+
+	var preimage [preimageSize]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable generate preimage: %v", err)
+	}
+	hash := sha256.Sum256(preimage[:])
+
+	req := &lnrpc.SendRequest{
+		Dest:        key.SerializeCompressed(),
+		Amt:         amountSat,
+		PaymentHash: hash[:],
+		DestCustomRecords: map[uint64][]byte{
+			keySendType: preimage[:],
+		},
+	}
+
+	var mediaFee decimal.Decimal
+	status := connectors.Completed
+	paymentHash := hex.EncodeToString(hash[:])
+
+	resp, err := c.sendPayment(req, pubKey, amountSat, paymentHash, opts)
+	switch err.(type) {
+	case nil:
+		mediaFee = sat2DecAmount(btcutil.Amount(resp.PaymentRoute.TotalFees))
+		c.averageFee = c.averageFee.Add(mediaFee).Div(decimal.NewFromFloat(2.0))
+
+	case *connectors.ErrFeeLimitExceeded:
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+
+	default:
+		if err != errPaymentInFlight {
+			m.AddError(metrics.HighSeverity)
+			return nil, err
+		}
+
+		// Payment might be still delivered, so it is saved as pending
+		// with the payment hash, in order to be reconciled later.
+		m.AddError(metrics.MiddleSeverity)
+		status = connectors.Pending
+	}
+
+	// As far as the same node might receive a lot of spontaneous payments,
+	// payment hash is used to make the payment id unique.
+	payment := &connectors.Payment{
+		PaymentID: generatePaymentID(paymentHash, connectors.Outgoing),
+		UpdatedAt: connectors.NowInMilliSeconds(),
+		Status:    status,
+		Direction: connectors.Outgoing,
+		Receipt:   pubKey,
+		Asset:     c.cfg.Asset,
+		Media:     connectors.Lightning,
+		Amount:    paymentAmt.Round(8),
+		MediaFee:  mediaFee,
+		MediaID:   paymentHash,
+	}
+
+	if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable add payment in store: %v", err)
+	}
+
+	log.Infof("Send spontaneous payment %v", spew.Sdump(payment))
+
+	return payment, nil
+}
@@ -0,0 +1,167 @@
+package lnd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+func TestIsNodePubKey(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	pubKey := privKey.PubKey()
+
+	tests := []struct {
+		name    string
+		receipt string
+		valid   bool
+	}{
+		{
+			name:    "compressed public key",
+			receipt: hex.EncodeToString(pubKey.SerializeCompressed()),
+			valid:   true,
+		},
+		{
+			name:    "uncompressed public key",
+			receipt: hex.EncodeToString(pubKey.SerializeUncompressed()),
+			valid:   false,
+		},
+		{
+			name: "not on curve",
+			receipt: "02000000000000000000000000000000000000000000000000" +
+				"00000000000000",
+			valid: false,
+		},
+		{
+			name: "invoice",
+			receipt: "lnbc1pvjluezpp5qqqsyqcyq5rqwzqfqqqsyqcyq5rqwzqfqqq" +
+				"syqcyq5rqwzqfqypqdpl2pkx2ctnv5sxxmmwwd5kgetjypeh2ursd" +
+				"ae8g6twvus8g6rfwvs8qun0dfjkxaq",
+			valid: false,
+		},
+		{
+			name:    "empty",
+			receipt: "",
+			valid:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if isNodePubKey(test.receipt) != test.valid {
+				t.Fatalf("expected valid(%v)", test.valid)
+			}
+		})
+	}
+}
+
+func TestValidatePubKey(t *testing.T) {
+	c := newTestConnector(&mockClient{})
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	pubKey := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	if err := c.ValidatePubKey(pubKey, "0.001"); err != nil {
+		t.Fatalf("valid public key is rejected: %v", err)
+	}
+
+	if err := c.ValidatePubKey(c.nodeAddr, "0.001"); err == nil {
+		t.Fatalf("public key of our node isn't rejected")
+	}
+
+	if err := c.ValidatePubKey(pubKey, "kek"); err == nil {
+		t.Fatalf("invalid amount isn't rejected")
+	}
+}
+
+func TestSendKeySendToOurselves(t *testing.T) {
+	client := &mockClient{}
+	c := newTestConnector(client)
+
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodSendTo,
+		c.cfg.Metrics)
+
+	_, err := c.sendKeySend(m, c.nodeAddr, 1000, decimal.New(1, -5), nil)
+	if err == nil {
+		t.Fatalf("spontaneous payment to ourselves isn't rejected")
+	}
+
+	if len(client.sendRequests) != 0 {
+		t.Fatalf("spontaneous payment to ourselves is sent")
+	}
+}
+
+func TestSettledPaymentID(t *testing.T) {
+	c := newTestConnector(&mockClient{})
+
+	first, err := c.settledPayment(&lnrpc.Invoice{
+		RHash:      []byte{1},
+		AmtPaidSat: 1000,
+		Settled:    true,
+		IsKeysend:  true,
+	})
+	if err != nil {
+		t.Fatalf("unable to convert invoice: %v", err)
+	}
+
+	second, err := c.settledPayment(&lnrpc.Invoice{
+		RHash:      []byte{2},
+		AmtPaidSat: 1000,
+		Settled:    true,
+		IsKeysend:  true,
+	})
+	if err != nil {
+		t.Fatalf("unable to convert invoice: %v", err)
+	}
+
+	if first.Receipt != c.nodeAddr {
+		t.Fatalf("wrong receipt of spontaneous payment: %v", first.Receipt)
+	}
+
+	if first.PaymentID != generatePaymentID("01", connectors.Incoming) {
+		t.Fatalf("spontaneous payment id isn't generated from payment "+
+			"hash: %v", first.PaymentID)
+	}
+
+	if first.PaymentID == second.PaymentID {
+		t.Fatalf("spontaneous payments have the same id")
+	}
+
+	if first.Account != "" {
+		t.Fatalf("spontaneous payment has account: %v", first.Account)
+	}
+
+	err = c.cfg.StateStorage.PutInvoiceAccount("03", "account")
+	if err != nil {
+		t.Fatalf("unable to put account: %v", err)
+	}
+
+	invoice, err := c.settledPayment(&lnrpc.Invoice{
+		RHash:          []byte{3},
+		PaymentRequest: "lnsb1",
+		AmtPaidSat:     1000,
+		Settled:        true,
+	})
+	if err != nil {
+		t.Fatalf("unable to convert invoice: %v", err)
+	}
+
+	if invoice.Receipt != "lnsb1" ||
+		invoice.PaymentID != generatePaymentID("lnsb1", connectors.Incoming) {
+		t.Fatalf("wrong invoice payment: %v", invoice)
+	}
+
+	if invoice.Account != "account" {
+		t.Fatalf("wrong invoice account: %v", invoice.Account)
+	}
+}
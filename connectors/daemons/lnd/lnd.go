@@ -2,6 +2,7 @@ package lnd
 
 import (
 	"context"
+	"crypto/sha256"
 	"sync"
 
 	"time"
@@ -19,7 +20,6 @@ import (
 	"github.com/shopspring/decimal"
 	"google.golang.org/grpc"
 	"github.com/bitlum/connector/connectors"
-	"github.com/bitlum/connector/connectors/assets/bitcoin"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/davecgh/go-spew/spew"
 )
@@ -52,6 +52,10 @@ type Config struct {
 	// Net blockchain network this connector should operate with.
 	Net string
 
+	// Asset is the asset of the blockchain on top of which lightning
+	// network daemon is working.
+	Asset connectors.Asset
+
 	// Name of the daemon client.
 	Name string
 
@@ -77,6 +81,22 @@ type Config struct {
 	// PaymentStorage is an external storage for payments, it is used by
 	// connector to save payment as well as update its state.
 	PaymentStore connectors.PaymentsStore
+
+	// StateStorage is used to keep data which is needed for connector to
+	// properly synchronise with lightning network daemon.
+	StateStorage StateStorage
+
+	// MaxFee is the default absolute maximum fee which might be paid for
+	// routing the payment. If specified max fee percent is ignored.
+	MaxFee decimal.Decimal
+
+	// MaxFeePercent is the default maximum fee which might be paid for
+	// routing the payment, in percents of the payment amount.
+	MaxFeePercent int64
+
+	// PaymentTimeout is the default time after which we stop waiting for
+	// payment to be sent.
+	PaymentTimeout time.Duration
 }
 
 func (c *Config) validate() error {
@@ -92,6 +112,14 @@ func (c *Config) validate() error {
 		return errors.Errorf("net should be specified")
 	}
 
+	switch c.Asset {
+	case connectors.BTC, connectors.LTC:
+	case "":
+		return errors.Errorf("asset should be specified")
+	default:
+		return errors.Errorf("asset(%v) is not supported", c.Asset)
+	}
+
 	if c.Port == 0 {
 		return errors.Errorf("port should be specified")
 	}
@@ -112,6 +140,18 @@ func (c *Config) validate() error {
 		return errors.New("payment store should be specified")
 	}
 
+	if c.StateStorage == nil {
+		return errors.New("state storage should be specified")
+	}
+
+	if c.MaxFee.LessThanOrEqual(decimal.Zero) && c.MaxFeePercent <= 0 {
+		return errors.New("max fee or max fee percent should be specified")
+	}
+
+	if c.PaymentTimeout <= 0 {
+		return errors.New("payment timeout should be specified")
+	}
+
 	return nil
 }
 
@@ -165,7 +205,7 @@ func (c *Connector) Start() (err error) {
 		}
 	}()
 
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodStart, c.cfg.Metrics)
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodStart, c.cfg.Metrics)
 	defer m.Finish()
 
 	c.client, c.conn, err = c.getClient(c.cfg.MacaroonPath)
@@ -200,6 +240,12 @@ func (c *Connector) Start() (err error) {
 	}
 
 	c.nodeAddr = respInfo.IdentityPubkey
+
+	if err := c.migrateInvoiceAccounts(); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to migrate invoice accounts: %v", err)
+	}
+
 	var invoiceSubscription lnrpc.Lightning_SubscribeInvoicesClient
 
 	c.wg.Add(1)
@@ -207,17 +253,39 @@ func (c *Connector) Start() (err error) {
 		defer c.wg.Done()
 
 		for {
-			balance, err := c.ConfirmedBalance("")
+			balance, err := c.fetchBalance()
 			if err != nil {
 				m.AddError(metrics.MiddleSeverity)
 				log.Errorf("unable to get available funds: %v", err)
+			} else {
+				log.Infof("Asset(%v), media(lightning), channels funds(%v), "+
+					"wallet funds(%v), pending open(%v), pending close(%v)",
+					c.cfg.Asset, balance.ChannelsLocal, balance.WalletConfirmed,
+					balance.PendingOpen, balance.PendingClose)
+
+				// Funds of the on-chain wallet are also under control
+				// of lnd, and might be used for opening channels.
+				funds := balance.ChannelsLocal.Add(balance.WalletConfirmed)
+				f, _ := funds.Float64()
+				m.CurrentFunds(f)
+			}
+
+			select {
+			case <-time.After(time.Second * 10):
+			case <-c.quit:
+				return
 			}
+		}
+	}()
 
-			log.Infof("Asset(BTC), media(lightning), available funds(%v)",
-				balance.Round(8).String())
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
 
-			f, _ := balance.Float64()
-			m.CurrentFunds(f)
+		for {
+			if err := c.syncForwards(); err != nil {
+				log.Errorf("unable to sync forwarding events: %v", err)
+			}
 
 			select {
 			case <-time.After(time.Second * 10):
@@ -227,6 +295,23 @@ func (c *Connector) Start() (err error) {
 		}
 	}()
 
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			if err := c.reconcilePayments(); err != nil {
+				log.Errorf("unable to reconcile pending payments: %v", err)
+			}
+
+			select {
+			case <-time.After(time.Minute):
+			case <-c.quit:
+				return
+			}
+		}
+	}()
+
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
@@ -247,7 +332,7 @@ func (c *Connector) Start() (err error) {
 
 	c.wg.Add(1)
 	go func() {
-		m := crypto.NewMetric(c.cfg.Name, "BTC", MethodHandleInvoice, c.cfg.Metrics)
+		m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodHandleInvoice, c.cfg.Metrics)
 		defer m.Finish()
 		defer c.wg.Done()
 
@@ -297,29 +382,18 @@ func (c *Connector) Start() (err error) {
 
 			if !invoiceUpdate.Settled {
 				log.Infof("Received invoice creation notification, "+
-					"invoice(%v), amount(%v), receipt(%v), memo(%v)",
+					"invoice(%v), amount(%v), memo(%v)",
 					invoiceUpdate.PaymentRequest,
-					invoiceUpdate.Value, string(invoiceUpdate.Receipt),
-					invoiceUpdate.Memo)
+					invoiceUpdate.Value, invoiceUpdate.Memo)
 				continue
 			}
 
-			paymentHash := hex.EncodeToString(invoiceUpdate.RHash)
-			invoice := invoiceUpdate.PaymentRequest
-			amount := sat2DecAmount(btcutil.Amount(invoiceUpdate.AmtPaidSat))
-
-			payment := &connectors.Payment{
-				PaymentID: generatePaymentID(invoice, connectors.Incoming),
-				UpdatedAt: connectors.NowInMilliSeconds(),
-				Status:    connectors.Completed,
-				Direction: connectors.Incoming,
-				Account:   string(invoiceUpdate.Receipt),
-				Receipt:   invoice,
-				Asset:     connectors.BTC,
-				Media:     connectors.Lightning,
-				MediaID:   paymentHash,
-				Amount:    amount,
-				MediaFee:  decimal.Zero,
+			payment, err := c.settledPayment(invoiceUpdate)
+			if err != nil {
+				m.AddError(metrics.HighSeverity)
+				log.Errorf("unable to handle settled invoice(%x): %v",
+					invoiceUpdate.RHash, err)
+				continue
 			}
 
 			if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
@@ -335,6 +409,43 @@ func (c *Connector) Start() (err error) {
 	return err
 }
 
+// settledPayment converts settled invoice in the incoming payment.
+func (c *Connector) settledPayment(invoice *lnrpc.Invoice) (
+	*connectors.Payment, error) {
+
+	paymentHash := hex.EncodeToString(invoice.RHash)
+	amount := sat2DecAmount(btcutil.Amount(invoice.AmtPaidSat))
+
+	account, err := c.invoiceAccount(invoice)
+	if err != nil {
+		return nil, errors.Errorf("unable to get invoice account: %v", err)
+	}
+
+	// Spontaneous payments don't have payment request, for that reason our
+	// node public key is used as receipt, and payment hash is used to make
+	// payment id unique.
+	receipt := invoice.PaymentRequest
+	paymentID := generatePaymentID(invoice.PaymentRequest, connectors.Incoming)
+	if invoice.IsKeysend {
+		receipt = c.nodeAddr
+		paymentID = generatePaymentID(paymentHash, connectors.Incoming)
+	}
+
+	return &connectors.Payment{
+		PaymentID: paymentID,
+		UpdatedAt: connectors.NowInMilliSeconds(),
+		Status:    connectors.Completed,
+		Direction: connectors.Incoming,
+		Account:   account,
+		Receipt:   receipt,
+		Asset:     c.cfg.Asset,
+		Media:     connectors.Lightning,
+		MediaID:   paymentHash,
+		Amount:    amount,
+		MediaFee:  decimal.Zero,
+	}, nil
+}
+
 // Stop gracefully stops the connection with lnd daemon.
 func (c *Connector) Stop(reason string) error {
 	if !atomic.CompareAndSwapInt32(&c.shutdown, 0, 1) {
@@ -356,9 +467,9 @@ func (c *Connector) Stop(reason string) error {
 // CreateInvoice is used to create lightning network invoice.
 //
 // NOTE: Part of the connectors.LightningConnector interface.
-func (c *Connector) CreateInvoice(account, amount,
-description string) (string, *zpay32.Invoice, error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodCreateInvoice, c.cfg.Metrics)
+func (c *Connector) CreateInvoice(account, amount, description string,
+	opts *connectors.InvoiceOptions) (string, *zpay32.Invoice, error) {
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodCreateInvoice, c.cfg.Metrics)
 	defer m.Finish()
 
 	satoshis, err := btcToSatoshi(amount)
@@ -369,10 +480,22 @@ description string) (string, *zpay32.Invoice, error) {
 
 	expirationTime := time.Minute * 15
 	invoiceReq := &lnrpc.Invoice{
-		Receipt: []byte(account),
-		Value:   satoshis,
-		Memo:    description,
-		Expiry:  int64(expirationTime.Seconds()),
+		Value:  satoshis,
+		Memo:   description,
+		Expiry: int64(expirationTime.Seconds()),
+	}
+
+	if opts != nil {
+		// With private option lnd includes route hints of our private
+		// channels in the invoice.
+		invoiceReq.Private = opts.PrivateRouteHints
+		invoiceReq.FallbackAddr = opts.FallbackAddress
+
+		if opts.DescriptionHash {
+			hash := sha256.Sum256([]byte(description))
+			invoiceReq.DescriptionHash = hash[:]
+			invoiceReq.Memo = ""
+		}
 	}
 
 	invoiceResp, err := c.client.AddInvoice(context.Background(), invoiceReq)
@@ -381,9 +504,19 @@ description string) (string, *zpay32.Invoice, error) {
 		return "", nil, err
 	}
 
+	// Account is kept by us, so that it could be assigned to the payment
+	// when invoice is settled.
+	err = c.cfg.StateStorage.PutInvoiceAccount(
+		hex.EncodeToString(invoiceResp.RHash), account)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return "", nil, errors.Errorf("unable to save invoice account: %v",
+			err)
+	}
+
 	// Check that invoice is valid, and that amount which we are sending is
 	// corresponding to what we expect.
-	netParams, err := bitcoin.GetParams(c.cfg.Net)
+	netParams, err := c.getParams()
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		return "", nil, err
@@ -402,14 +535,14 @@ description string) (string, *zpay32.Invoice, error) {
 // payment system.
 //
 // NOTE: Part of the connectors.LightningConnector interface.
-func (c *Connector) SendTo(invoiceStr, amountStr string) (*connectors.Payment,
-	error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodSendTo, c.cfg.Metrics)
+func (c *Connector) SendTo(invoiceStr, amountStr string,
+	opts *connectors.SendOptions) (*connectors.Payment, error) {
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodSendTo, c.cfg.Metrics)
 	defer m.Finish()
 
 	// Check that invoice is valid, and that amount which we are sending is
 	// corresponding to what we expect.
-	netParams, err := bitcoin.GetParams(c.cfg.Net)
+	netParams, err := c.getParams()
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		return nil, err
@@ -421,6 +554,13 @@ func (c *Connector) SendTo(invoiceStr, amountStr string) (*connectors.Payment,
 		return nil, err
 	}
 
+	// If receipt is the public key of the lightning network node,
+	// than invoice is absent and spontaneous payment should be made.
+	if isNodePubKey(invoiceStr) {
+		return c.sendKeySend(m, invoiceStr, amountSat,
+			sat2DecAmount(btcutil.Amount(amountSat)), opts)
+	}
+
 	invoice, err := zpay32.Decode(invoiceStr, netParams)
 	if err != nil {
 		m.AddError(metrics.LowSeverity)
@@ -452,6 +592,7 @@ func (c *Connector) SendTo(invoiceStr, amountStr string) (*connectors.Payment,
 	}
 
 	var mediaFee decimal.Decimal
+	status := connectors.Completed
 	paymentHash := hex.EncodeToString(invoice.PaymentHash[:])
 	receiverNodeAddr := hex.EncodeToString(invoice.Destination.
 		SerializeCompressed())
@@ -466,7 +607,7 @@ func (c *Connector) SendTo(invoiceStr, amountStr string) (*connectors.Payment,
 			Status:    connectors.Completed,
 			Direction: connectors.Incoming,
 			Receipt:   invoiceStr,
-			Asset:     connectors.BTC,
+			Asset:     c.cfg.Asset,
 			Media:     connectors.Lightning,
 			Amount:    paymentAmt.Round(8),
 			MediaFee:  mediaFee,
@@ -490,37 +631,39 @@ func (c *Connector) SendTo(invoiceStr, amountStr string) (*connectors.Payment,
 		req := &lnrpc.SendRequest{
 			Amt:            paymentAmount,
 			PaymentRequest: invoiceStr,
-			FeeLimit: &lnrpc.FeeLimit{
-				Limit: &lnrpc.FeeLimit_Percent{
-					Percent: 3,
-				},
-			},
 		}
 
-		// TODO(andrew.shvv) Use async version and return waiting payment after
-		// 3-5 seconds.
-		resp, err := c.client.SendPaymentSync(context.Background(), req)
-		if err != nil {
-			m.AddError(metrics.HighSeverity)
-			return nil, errors.Errorf("unable to send payment: %v", err)
-		}
+		resp, err := c.sendPayment(req, receiverNodeAddr, amountSat,
+			paymentHash, opts)
+		switch err.(type) {
+		case nil:
+			mediaFee = sat2DecAmount(btcutil.Amount(resp.PaymentRoute.TotalFees))
+			c.averageFee = c.averageFee.Add(mediaFee).Div(decimal.NewFromFloat(2.0))
 
-		if resp.PaymentError != "" {
-			m.AddError(metrics.HighSeverity)
-			return nil, errors.Errorf("unable to send payment: %v", resp.PaymentError)
-		}
+		case *connectors.ErrFeeLimitExceeded:
+			m.AddError(metrics.LowSeverity)
+			return nil, err
+
+		default:
+			if err != errPaymentInFlight {
+				m.AddError(metrics.HighSeverity)
+				return nil, err
+			}
 
-		mediaFee = sat2DecAmount(btcutil.Amount(resp.PaymentRoute.TotalFees))
-		c.averageFee = c.averageFee.Add(mediaFee).Div(decimal.NewFromFloat(2.0))
+			// Payment might be still delivered, so it is saved as pending
+			// with the payment hash, in order to be reconciled later.
+			m.AddError(metrics.MiddleSeverity)
+			status = connectors.Pending
+		}
 	}
 
 	payment := &connectors.Payment{
 		PaymentID: generatePaymentID(invoiceStr, connectors.Outgoing),
 		UpdatedAt: connectors.NowInMilliSeconds(),
-		Status:    connectors.Completed,
+		Status:    status,
 		Direction: connectors.Outgoing,
 		Receipt:   invoiceStr,
-		Asset:     connectors.BTC,
+		Asset:     c.cfg.Asset,
 		Media:     connectors.Lightning,
 		Amount:    paymentAmt.Round(8),
 		MediaFee:  mediaFee,
@@ -549,7 +692,7 @@ func (c *Connector) ReceivedPayments() <-chan *connectors.Payment {
 //
 // NOTE: Part of the connectors.LightningConnector interface.
 func (c *Connector) Info() (*connectors.LightningInfo, error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodInfo, c.cfg.Metrics)
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodInfo, c.cfg.Metrics)
 	defer m.Finish()
 
 	req := &lnrpc.GetInfoRequest{}
@@ -569,11 +712,12 @@ func (c *Connector) Info() (*connectors.LightningInfo, error) {
 }
 
 // QueryRoutes returns list of routes from to the given lnd node,
-// and insures the the capacity of the channels is sufficient.
+// and insures the the capacity of the channels is sufficient. lnd returns
+// only the best route, that is why limit is ignored.
 //
 // NOTE: Part of the connectors.LightningConnector interface.
 func (c *Connector) QueryRoutes(pubKey, amount string, limit int32) ([]*lnrpc.Route, error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodQueryRoutes, c.cfg.Metrics)
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodQueryRoutes, c.cfg.Metrics)
 	defer m.Finish()
 
 	satoshis, err := btcToSatoshi(amount)
@@ -597,9 +741,8 @@ func (c *Connector) QueryRoutes(pubKey, amount string, limit int32) ([]*lnrpc.Ro
 	}
 
 	req := &lnrpc.QueryRoutesRequest{
-		PubKey:    pubKey,
-		Amt:       satoshis,
-		NumRoutes: limit,
+		PubKey: pubKey,
+		Amt:    satoshis,
 	}
 
 	info, err := c.client.QueryRoutes(context.Background(), req)
@@ -617,10 +760,10 @@ func (c *Connector) QueryRoutes(pubKey, amount string, limit int32) ([]*lnrpc.Ro
 // NOTE: Part of the connectors.Connector interface.
 func (c *Connector) ValidateInvoice(invoiceStr,
 amountStr string) (*zpay32.Invoice, error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodValidateInvoice, c.cfg.Metrics)
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodValidateInvoice, c.cfg.Metrics)
 	defer m.Finish()
 
-	netParams, err := bitcoin.GetParams(c.cfg.Net)
+	netParams, err := c.getParams()
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		return nil, errors.Errorf("unable load network params: %v", err)
@@ -656,12 +799,12 @@ amountStr string) (*zpay32.Invoice, error) {
 	return invoice, nil
 }
 
-// ConfirmedBalance return the amount of confirmed funds available for account.
-// TODO(andrew.shvv) Show funds locked in the channels
+// ConfirmedBalance return the amount of confirmed funds of lnd on-chain
+// wallet. Funds of the channels are returned by BalanceDetails.
 //
 // NOTE: Part of the connectors.Connector interface.
 func (c *Connector) ConfirmedBalance(account string) (decimal.Decimal, error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodConfirmedBalance, c.cfg.Metrics)
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodConfirmedBalance, c.cfg.Metrics)
 	defer m.Finish()
 
 	req := &lnrpc.WalletBalanceRequest{}
@@ -676,12 +819,13 @@ func (c *Connector) ConfirmedBalance(account string) (decimal.Decimal, error) {
 	return balanceBTC.Round(8), nil
 }
 
-// PendingBalance return the amount of funds waiting to be confirmed.
-// TODO(andrew.shvv) Show funds locked in the channels
+// PendingBalance return the amount of funds of lnd on-chain wallet waiting
+// to be confirmed. Funds of the pending channels are returned by
+// BalanceDetails.
 //
 // NOTE: Part of the connectors.Connector interface.
 func (c *Connector) PendingBalance(account string) (decimal.Decimal, error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodConfirmedBalance, c.cfg.Metrics)
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodPendingBalance, c.cfg.Metrics)
 	defer m.Finish()
 
 	req := &lnrpc.WalletBalanceRequest{}
@@ -699,14 +843,15 @@ func (c *Connector) PendingBalance(account string) (decimal.Decimal, error) {
 // reportMetrics is used to report necessary health metrics about internal
 // state of the connector.
 func (c *Connector) reportMetrics() error {
-	asset := connectors.BTC
-	m := crypto.NewMetric("lnd", string(asset),
+	asset := c.cfg.Asset
+	m := crypto.NewMetric(c.cfg.Name, string(asset),
 		"ReportMetrics", c.cfg.Metrics)
 	defer m.Finish()
 
 	var overallSent decimal.Decimal
 	var overallReceived decimal.Decimal
 	var overallFee decimal.Decimal
+	var routingIncome decimal.Decimal
 
 	payments, err := c.cfg.PaymentStore.ListPayments(asset,
 		connectors.Completed, "", connectors.Lightning)
@@ -715,6 +860,12 @@ func (c *Connector) reportMetrics() error {
 	}
 
 	for _, payment := range payments {
+		// Withdraw link refers to the payment which has been made with
+		// it, and shouldn't be counted twice.
+		if _, ok := payment.Detail.(*connectors.LnurlWithdrawDetails); ok {
+			continue
+		}
+
 		if payment.Direction == connectors.Incoming {
 			overallReceived = overallReceived.Add(payment.Amount)
 		}
@@ -727,6 +878,10 @@ func (c *Connector) reportMetrics() error {
 		if payment.Direction == connectors.Internal {
 			overallFee = overallFee.Add(payment.MediaFee)
 		}
+
+		if details, ok := payment.Detail.(*connectors.LightningForwardDetails); ok {
+			routingIncome = routingIncome.Add(details.Fee)
+		}
 	}
 
 	overallReceivedF, _ := overallReceived.Float64()
@@ -738,9 +893,13 @@ func (c *Connector) reportMetrics() error {
 	overallFeeF, _ := overallFee.Float64()
 	m.OverallFee(overallFeeF)
 
+	routingIncomeF, _ := routingIncome.Float64()
+	m.RoutingIncome(routingIncomeF)
+
 	log.Infof("Metrics reported, overall received(%v %v), "+
-		"overall sent(%v %v), overall fee(%v %v)", overallReceivedF,
-		asset, overallSentF, asset, overallFeeF, asset)
+		"overall sent(%v %v), overall fee(%v %v), routing income(%v %v)",
+		overallReceivedF, asset, overallSentF, asset, overallFeeF, asset,
+		routingIncomeF, asset)
 
 	return nil
 }
@@ -751,7 +910,7 @@ func (c *Connector) reportMetrics() error {
 // NOTE: Part of the connectors.Connector interface.
 func (c *Connector) EstimateFee(invoiceStr string) (decimal.Decimal,
 	error) {
-	m := crypto.NewMetric(c.cfg.Name, "BTC", MethodEstimateFee, c.cfg.Metrics)
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodEstimateFee, c.cfg.Metrics)
 	defer m.Finish()
 
 	if invoiceStr == "" {
@@ -761,7 +920,7 @@ func (c *Connector) EstimateFee(invoiceStr string) (decimal.Decimal,
 		return c.averageFee.Round(8), nil
 
 	} else {
-		netParams, err := bitcoin.GetParams(c.cfg.Net)
+		netParams, err := c.getParams()
 		if err != nil {
 			m.AddError(metrics.HighSeverity)
 			return decimal.Zero, err
@@ -785,14 +944,9 @@ func (c *Connector) EstimateFee(invoiceStr string) (decimal.Decimal,
 
 		pubKey := hex.EncodeToString(invoice.Destination.SerializeCompressed())
 		req := &lnrpc.QueryRoutesRequest{
-			PubKey: pubKey,
-			Amt:    amount,
-			FeeLimit: &lnrpc.FeeLimit{
-				Limit: &lnrpc.FeeLimit_Percent{
-					Percent: 3,
-				},
-			},
-			NumRoutes: 10,
+			PubKey:   pubKey,
+			Amt:      amount,
+			FeeLimit: c.feeLimit(nil),
 		}
 
 		resp, err := c.client.QueryRoutes(context.Background(), req)
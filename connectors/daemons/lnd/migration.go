@@ -0,0 +1,164 @@
+package lnd
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// legacyReceiptField is the number of the invoice receipt field, in which
+// the previously used lnd fork kept the account of the invoice. Upstream lnd
+// doesn't know this field, so it is only available as unrecognized field of
+// the invoice.
+const legacyReceiptField = 2
+
+// migrateBatchSize is the number of invoices requested from lnd at once
+// during the migration.
+const migrateBatchSize = 100
+
+// legacyInvoiceAccount returns the account which has been kept in the
+// receipt of the invoice created by the lnd fork. If invoice doesn't have
+// receipt empty account is returned.
+func legacyInvoiceAccount(invoice *lnrpc.Invoice) (string, error) {
+	data := invoice.XXX_unrecognized
+
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return "", errors.New("malformed field key")
+		}
+		data = data[n:]
+
+		field, wireType := key>>3, key&7
+
+		var size uint64
+		switch wireType {
+		// Varint
+		case 0:
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return "", errors.Errorf("malformed field(%v)", field)
+			}
+			data = data[n:]
+			continue
+
+		// Fixed 64-bit
+		case 1:
+			size = 8
+
+		// Length-delimited
+		case 2:
+			size, n = binary.Uvarint(data)
+			if n <= 0 {
+				return "", errors.Errorf("malformed field(%v) length",
+					field)
+			}
+			data = data[n:]
+
+		// Fixed 32-bit
+		case 5:
+			size = 4
+
+		default:
+			return "", errors.Errorf("unsupported wire type(%v) of "+
+				"field(%v)", wireType, field)
+		}
+
+		if uint64(len(data)) < size {
+			return "", errors.Errorf("field(%v) is truncated", field)
+		}
+
+		if field == legacyReceiptField && wireType == 2 {
+			return string(data[:size]), nil
+		}
+
+		data = data[size:]
+	}
+
+	return "", nil
+}
+
+// invoiceAccount returns the account for which invoice has been created.
+// Invoices which have been created before the account was kept in the state
+// storage fall back to the account kept in the invoice receipt.
+func (c *Connector) invoiceAccount(invoice *lnrpc.Invoice) (string, error) {
+	paymentHash := hex.EncodeToString(invoice.RHash)
+
+	account, err := c.cfg.StateStorage.InvoiceAccount(paymentHash)
+	if err != nil {
+		return "", err
+	}
+
+	if account != "" {
+		return account, nil
+	}
+
+	account, err = legacyInvoiceAccount(invoice)
+	if err != nil {
+		return "", errors.Errorf("unable to read invoice receipt: %v", err)
+	}
+
+	return account, nil
+}
+
+// migrateInvoiceAccounts copies the accounts of the pending invoices, which
+// have been created by the lnd fork, from the invoice receipt to the state
+// storage. It has to be done before lnd is upgraded, because upstream lnd
+// drops the receipt of the invoices.
+func (c *Connector) migrateInvoiceAccounts() error {
+	var offset uint64
+	for {
+		resp, err := c.client.ListInvoices(context.Background(),
+			&lnrpc.ListInvoiceRequest{
+				PendingOnly:    true,
+				IndexOffset:    offset,
+				NumMaxInvoices: migrateBatchSize,
+			})
+		if err != nil {
+			return errors.Errorf("unable to list invoices: %v", err)
+		}
+
+		for _, invoice := range resp.Invoices {
+			paymentHash := hex.EncodeToString(invoice.RHash)
+
+			account, err := c.cfg.StateStorage.InvoiceAccount(paymentHash)
+			if err != nil {
+				return errors.Errorf("unable to get invoice account: %v",
+					err)
+			}
+
+			if account != "" {
+				continue
+			}
+
+			account, err = legacyInvoiceAccount(invoice)
+			if err != nil {
+				log.Errorf("unable to read receipt of invoice(%v): %v",
+					paymentHash, err)
+				continue
+			}
+
+			if account == "" {
+				continue
+			}
+
+			err = c.cfg.StateStorage.PutInvoiceAccount(paymentHash, account)
+			if err != nil {
+				return errors.Errorf("unable to put invoice account: %v",
+					err)
+			}
+
+			log.Infof("Migrated account(%v) of invoice(%v)", account,
+				paymentHash)
+		}
+
+		if len(resp.Invoices) < migrateBatchSize ||
+			resp.LastIndexOffset <= offset {
+			return nil
+		}
+
+		offset = resp.LastIndexOffset
+	}
+}
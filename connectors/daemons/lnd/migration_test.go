@@ -0,0 +1,108 @@
+package lnd
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// legacyReceipt returns the unrecognized fields of the invoice created by
+// the lnd fork with the given account in the receipt.
+func legacyReceipt(account string) []byte {
+	// Unknown varint field goes before the receipt, to check that other
+	// fields are skipped.
+	data := []byte{0xf0, 0x01, 0x01}
+	data = append(data, 0x12, byte(len(account)))
+	return append(data, account...)
+}
+
+func TestSettledPaymentLegacyAccount(t *testing.T) {
+	c := newTestConnector(&mockClient{})
+
+	payment, err := c.settledPayment(&lnrpc.Invoice{
+		RHash:            []byte{1},
+		PaymentRequest:   "lnsb1",
+		AmtPaidSat:       1000,
+		Settled:          true,
+		XXX_unrecognized: legacyReceipt("account"),
+	})
+	if err != nil {
+		t.Fatalf("unable to convert invoice: %v", err)
+	}
+
+	if payment.Account != "account" {
+		t.Fatalf("wrong account of legacy invoice: %v", payment.Account)
+	}
+
+	payment, err = c.settledPayment(&lnrpc.Invoice{
+		RHash:          []byte{2},
+		PaymentRequest: "lnsb2",
+		AmtPaidSat:     1000,
+		Settled:        true,
+	})
+	if err != nil {
+		t.Fatalf("unable to convert invoice: %v", err)
+	}
+
+	if payment.Account != "" {
+		t.Fatalf("invoice without receipt has account: %v",
+			payment.Account)
+	}
+
+	_, err = c.settledPayment(&lnrpc.Invoice{
+		RHash:            []byte{3},
+		PaymentRequest:   "lnsb3",
+		AmtPaidSat:       1000,
+		Settled:          true,
+		XXX_unrecognized: []byte{0x12, 0x05, 'a'},
+	})
+	if err == nil {
+		t.Fatalf("invoice with truncated receipt isn't rejected")
+	}
+}
+
+func TestMigrateInvoiceAccounts(t *testing.T) {
+	client := &mockClient{}
+	for i := 1; i <= migrateBatchSize+1; i++ {
+		client.invoices = append(client.invoices, &lnrpc.Invoice{
+			RHash:    []byte{byte(i)},
+			AddIndex: uint64(i),
+		})
+	}
+
+	// Only invoices created by the lnd fork have receipt, and the last
+	// one is in the second batch.
+	client.invoices[0].XXX_unrecognized = legacyReceipt("first")
+	client.invoices[migrateBatchSize].XXX_unrecognized =
+		legacyReceipt("last")
+
+	c := newTestConnector(client)
+
+	// Accounts which are already in the storage shouldn't be overwritten.
+	if err := c.cfg.StateStorage.PutInvoiceAccount("02", "stored"); err != nil {
+		t.Fatalf("unable to put account: %v", err)
+	}
+	client.invoices[1].XXX_unrecognized = legacyReceipt("legacy")
+
+	if err := c.migrateInvoiceAccounts(); err != nil {
+		t.Fatalf("unable to migrate invoice accounts: %v", err)
+	}
+
+	accounts := c.cfg.StateStorage.(*mockStateStorage).accounts
+	expected := map[string]string{
+		"01": "first",
+		"02": "stored",
+		"65": "last",
+	}
+
+	if len(accounts) != len(expected) {
+		t.Fatalf("wrong number of accounts: %v", accounts)
+	}
+
+	for paymentHash, account := range expected {
+		if accounts[paymentHash] != account {
+			t.Fatalf("wrong account of invoice(%v): %v", paymentHash,
+				accounts[paymentHash])
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package lnd
+
+import (
+	"context"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+)
+
+// mockClient is the lnd client which returns predefined responses, methods
+// which aren't overridden panic if called.
+type mockClient struct {
+	lnrpc.LightningClient
+
+	sendRequests []*lnrpc.SendRequest
+	sendResp     *lnrpc.SendResponse
+	sendErr      error
+
+	// sendBlock makes payment to hang until the context is done.
+	sendBlock bool
+
+	routes    []*lnrpc.Route
+	routesErr error
+
+	payments []*lnrpc.Payment
+	invoices []*lnrpc.Invoice
+
+	walletBalance   *lnrpc.WalletBalanceResponse
+	channelBalance  *lnrpc.ChannelBalanceResponse
+	pendingChannels *lnrpc.PendingChannelsResponse
+}
+
+func (c *mockClient) WalletBalance(ctx context.Context,
+	req *lnrpc.WalletBalanceRequest, opts ...grpc.CallOption) (
+	*lnrpc.WalletBalanceResponse, error) {
+
+	return c.walletBalance, nil
+}
+
+func (c *mockClient) ChannelBalance(ctx context.Context,
+	req *lnrpc.ChannelBalanceRequest, opts ...grpc.CallOption) (
+	*lnrpc.ChannelBalanceResponse, error) {
+
+	return c.channelBalance, nil
+}
+
+func (c *mockClient) PendingChannels(ctx context.Context,
+	req *lnrpc.PendingChannelsRequest, opts ...grpc.CallOption) (
+	*lnrpc.PendingChannelsResponse, error) {
+
+	return c.pendingChannels, nil
+}
+
+func (c *mockClient) SendPaymentSync(ctx context.Context,
+	req *lnrpc.SendRequest, opts ...grpc.CallOption) (*lnrpc.SendResponse,
+	error) {
+
+	c.sendRequests = append(c.sendRequests, req)
+
+	if c.sendBlock {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return c.sendResp, c.sendErr
+}
+
+func (c *mockClient) ListPayments(ctx context.Context,
+	req *lnrpc.ListPaymentsRequest, opts ...grpc.CallOption) (
+	*lnrpc.ListPaymentsResponse, error) {
+
+	resp := &lnrpc.ListPaymentsResponse{}
+	for _, payment := range c.payments {
+		if req.IndexOffset != 0 && payment.PaymentIndex <= req.IndexOffset {
+			continue
+		}
+
+		if req.MaxPayments != 0 &&
+			uint64(len(resp.Payments)) == req.MaxPayments {
+			break
+		}
+
+		resp.Payments = append(resp.Payments, payment)
+		resp.LastIndexOffset = payment.PaymentIndex
+	}
+
+	return resp, nil
+}
+
+func (c *mockClient) ListInvoices(ctx context.Context,
+	req *lnrpc.ListInvoiceRequest, opts ...grpc.CallOption) (
+	*lnrpc.ListInvoiceResponse, error) {
+
+	resp := &lnrpc.ListInvoiceResponse{}
+	for _, invoice := range c.invoices {
+		if invoice.AddIndex <= req.IndexOffset {
+			continue
+		}
+
+		if uint64(len(resp.Invoices)) == req.NumMaxInvoices {
+			break
+		}
+
+		resp.Invoices = append(resp.Invoices, invoice)
+		resp.LastIndexOffset = invoice.AddIndex
+	}
+
+	return resp, nil
+}
+
+func (c *mockClient) QueryRoutes(ctx context.Context,
+	req *lnrpc.QueryRoutesRequest, opts ...grpc.CallOption) (
+	*lnrpc.QueryRoutesResponse, error) {
+
+	if c.routesErr != nil {
+		return nil, c.routesErr
+	}
+
+	return &lnrpc.QueryRoutesResponse{Routes: c.routes}, nil
+}
+
+type mockStateStorage struct {
+	StateStorage
+
+	accounts map[string]string
+}
+
+func (s *mockStateStorage) PutInvoiceAccount(paymentHash,
+	account string) error {
+	s.accounts[paymentHash] = account
+	return nil
+}
+
+func (s *mockStateStorage) InvoiceAccount(paymentHash string) (string,
+	error) {
+	return s.accounts[paymentHash], nil
+}
+
+// newTestConnector returns connector which works with mocked lnd client
+// and storages.
+func newTestConnector(client *mockClient) *Connector {
+	return &Connector{
+		cfg: &Config{
+			Name:         "lnd",
+			Net:          "simnet",
+			Asset:        connectors.BTC,
+			Metrics:      &crypto.EmptyBackend{},
+			PaymentStore: mock.NewPaymentsStore(),
+			StateStorage: &mockStateStorage{
+				accounts: make(map[string]string),
+			},
+			MaxFeePercent:  3,
+			PaymentTimeout: time.Minute,
+		},
+		client:   client,
+		nodeAddr: "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+	}
+}
@@ -0,0 +1,95 @@
+package lnd
+
+import (
+	"context"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/btcsuite/btcutil"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// reconcileBatchSize is the number of payments requested from lnd at once
+// during the reconciliation.
+const reconcileBatchSize = 100
+
+// reconcilePayments resolves outgoing payments which have been saved as
+// pending, because they haven't been finished in time. Status of such
+// payments is taken from lnd, payments which are still in flight are left
+// pending.
+func (c *Connector) reconcilePayments() error {
+	payments, err := c.cfg.PaymentStore.ListPayments(c.cfg.Asset,
+		connectors.Pending, connectors.Outgoing, connectors.Lightning)
+	if err != nil {
+		return errors.Errorf("unable to list pending payments: %v", err)
+	}
+
+	if len(payments) == 0 {
+		return nil
+	}
+
+	pending := make(map[string]*connectors.Payment, len(payments))
+	for _, payment := range payments {
+		pending[payment.MediaID] = payment
+	}
+
+	var offset uint64
+	for {
+		resp, err := c.client.ListPayments(context.Background(),
+			&lnrpc.ListPaymentsRequest{
+				IncludeIncomplete: true,
+				IndexOffset:       offset,
+				MaxPayments:       reconcileBatchSize,
+			})
+		if err != nil {
+			return errors.Errorf("unable to list payments: %v", err)
+		}
+
+		for _, lndPayment := range resp.Payments {
+			payment, ok := pending[lndPayment.PaymentHash]
+			if !ok {
+				continue
+			}
+
+			if err := c.resolvePayment(payment, lndPayment); err != nil {
+				return err
+			}
+		}
+
+		if len(resp.Payments) < reconcileBatchSize ||
+			resp.LastIndexOffset <= offset {
+			return nil
+		}
+
+		offset = resp.LastIndexOffset
+	}
+}
+
+// resolvePayment updates the pending payment in accordance with the status
+// of the payment in lnd.
+func (c *Connector) resolvePayment(payment *connectors.Payment,
+	lndPayment *lnrpc.Payment) error {
+
+	switch lndPayment.Status {
+	case lnrpc.Payment_SUCCEEDED:
+		payment.Status = connectors.Completed
+		payment.MediaFee = sat2DecAmount(btcutil.Amount(lndPayment.FeeSat))
+
+	case lnrpc.Payment_FAILED:
+		payment.Status = connectors.Failed
+
+	default:
+		return nil
+	}
+
+	payment.UpdatedAt = connectors.NowInMilliSeconds()
+	if err := c.cfg.PaymentStore.SavePayment(payment); err != nil {
+		return errors.Errorf("unable to save payment(%v): %v",
+			payment.PaymentID, err)
+	}
+
+	log.Infof("Resolve pending payment %v", spew.Sdump(payment))
+
+	return nil
+}
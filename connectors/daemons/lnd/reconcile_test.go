@@ -0,0 +1,90 @@
+package lnd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+func TestReconcilePayments(t *testing.T) {
+	// Pending payments are placed in the second batch of lnd payments,
+	// to check that all payments are looked through.
+	client := &mockClient{}
+	for i := 1; i <= reconcileBatchSize; i++ {
+		client.payments = append(client.payments, &lnrpc.Payment{
+			PaymentHash:  fmt.Sprintf("%x", i),
+			Status:       lnrpc.Payment_SUCCEEDED,
+			PaymentIndex: uint64(i),
+		})
+	}
+
+	client.payments = append(client.payments,
+		&lnrpc.Payment{
+			PaymentHash:  "succeeded",
+			Status:       lnrpc.Payment_SUCCEEDED,
+			FeeSat:       10,
+			PaymentIndex: reconcileBatchSize + 1,
+		},
+		&lnrpc.Payment{
+			PaymentHash:  "failed",
+			Status:       lnrpc.Payment_FAILED,
+			PaymentIndex: reconcileBatchSize + 2,
+		},
+		&lnrpc.Payment{
+			PaymentHash:  "inflight",
+			Status:       lnrpc.Payment_IN_FLIGHT,
+			PaymentIndex: reconcileBatchSize + 3,
+		},
+	)
+
+	c := newTestConnector(client)
+
+	for _, hash := range []string{"succeeded", "failed", "inflight"} {
+		err := c.cfg.PaymentStore.SavePayment(&connectors.Payment{
+			PaymentID: hash,
+			Status:    connectors.Pending,
+			Direction: connectors.Outgoing,
+			Asset:     connectors.BTC,
+			Media:     connectors.Lightning,
+			MediaID:   hash,
+			MediaFee:  decimal.Zero,
+		})
+		if err != nil {
+			t.Fatalf("unable to save payment: %v", err)
+		}
+	}
+
+	if err := c.reconcilePayments(); err != nil {
+		t.Fatalf("unable to reconcile payments: %v", err)
+	}
+
+	tests := []struct {
+		paymentID string
+		status    connectors.PaymentStatus
+		fee       decimal.Decimal
+	}{
+		{"succeeded", connectors.Completed, decimal.New(10, -8)},
+		{"failed", connectors.Failed, decimal.Zero},
+		{"inflight", connectors.Pending, decimal.Zero},
+	}
+
+	for _, test := range tests {
+		payment, err := c.cfg.PaymentStore.PaymentByID(test.paymentID)
+		if err != nil {
+			t.Fatalf("unable to get payment: %v", err)
+		}
+
+		if payment.Status != test.status {
+			t.Fatalf("wrong status of %v payment: %v", test.paymentID,
+				payment.Status)
+		}
+
+		if !payment.MediaFee.Equal(test.fee) {
+			t.Fatalf("wrong fee of %v payment: %v", test.paymentID,
+				payment.MediaFee)
+		}
+	}
+}
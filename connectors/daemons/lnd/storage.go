@@ -0,0 +1,25 @@
+package lnd
+
+// StateStorage is used to keep data which is needed for connector to
+// properly synchronise with lightning network daemon.
+//
+// NOTE: This storage has to be persistent.
+type StateStorage interface {
+	// ForwardingIndex returns the index of the last forwarding event which
+	// has been processed by connector. If no events have been processed yet
+	// zero index is returned.
+	ForwardingIndex() (uint32, error)
+
+	// PutForwardingIndex is used to save the index of the last processed
+	// forwarding event.
+	PutForwardingIndex(index uint32) error
+
+	// PutInvoiceAccount is used to save the account for which invoice
+	// with the given payment hash has been created.
+	PutInvoiceAccount(paymentHash, account string) error
+
+	// InvoiceAccount returns the account of the invoice with the given
+	// payment hash. If invoice hasn't been created by us, for example
+	// in case of spontaneous payment, empty account is returned.
+	InvoiceAccount(paymentHash string) (string, error)
+}
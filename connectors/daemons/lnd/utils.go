@@ -14,6 +14,9 @@ import (
 	"gopkg.in/macaroon.v2"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"net"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/bitlum/connector/connectors/assets/bitcoin"
+	"github.com/bitlum/connector/connectors/assets/litecoin"
 )
 
 var satoshiPerBitcoin = decimal.New(btcutil.SatoshiPerBitcoin, 0)
@@ -39,6 +42,42 @@ func sat2DecAmount(amount btcutil.Amount) decimal.Decimal {
 	return amt.Div(satoshiPerBitcoin)
 }
 
+// getParams returns the network parameters of the asset blockchain, which
+// are needed to properly decode lightning network invoices.
+func (c *Connector) getParams() (*chaincfg.Params, error) {
+	switch c.cfg.Asset {
+	case connectors.BTC:
+		// Simnet invoices of lnd are encoded with "sb" prefix, which is
+		// different from the regtest one used by our blockchain params.
+		if c.cfg.Net == "simnet" {
+			return &chaincfg.SimNetParams, nil
+		}
+
+		return bitcoin.GetParams(c.cfg.Net)
+	case connectors.LTC:
+		params, err := litecoin.GetParams(c.cfg.Net)
+		if err != nil {
+			return nil, err
+		}
+
+		// Litecoin simnet and regtest invoices of lnd are encoded with
+		// "sltc" and "rltc" prefixes, which are different from the one
+		// used by our blockchain params.
+		if params.Name == litecoin.RegressionNetParams.Name {
+			regtestParams := *params
+			regtestParams.Bech32HRPSegwit = "rltc"
+			if c.cfg.Net == "simnet" {
+				regtestParams.Bech32HRPSegwit = "sltc"
+			}
+			return &regtestParams, nil
+		}
+
+		return params, nil
+	}
+
+	return nil, errors.Errorf("asset(%v) is not supported", c.cfg.Asset)
+}
+
 func generatePaymentID(invoiceStr string,
 	direction connectors.PaymentDirection) string {
 	return connectors.GeneratePaymentID(invoiceStr, string(direction))
@@ -0,0 +1,127 @@
+package lnd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+)
+
+// Invoices of 10u with the same payment hash and description "test",
+// signed by the same node for every network.
+const (
+	testNodePubKey = "03e7156ae33b0a208d0744199163177e909e80176e55d97a2f2" +
+		"21ede0f934dd9ad"
+
+	btcMainnetInvoice = "lnbc10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpugpz" +
+		"ysnzs23v9ccrydpk8qarc0sdq8w3jhxaqegktju6v7hq7lm97w766w23zz263r7k" +
+		"v3ng5gnrne3dnv2mv89wzdt344nctcef0rcrdrxj2f3cfp0023xxequmqktcjzzz" +
+		"wafcvd6cpgsrwd3"
+	btcTestnetInvoice = "lntb10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpugpz" +
+		"ysnzs23v9ccrydpk8qarc0sdq8w3jhxaq244hxj0tsuexvudy4tvdrunm2huag2y" +
+		"3mhhrvdmaw5ua5wycze8pg8s8er2c4sv0m6ucpqsenu2e3j0jxjq2z3dcufks07r" +
+		"vd895fksqptdf2d"
+	btcSimnetInvoice = "lnsb10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpugpzy" +
+		"snzs23v9ccrydpk8qarc0sdq8w3jhxaqkgqwc759xxrcm056pfpnpzhrgkffkzw0" +
+		"xc9pae8hylpzr6jzm00js3n5jdlatrl98v5v5rxkyeuf5xycve74m6h2tjzc7z2j" +
+		"zc9r3dsq8fuled"
+	btcRegtestInvoice = "lnbcrt10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpug" +
+		"pzysnzs23v9ccrydpk8qarc0sdq8w3jhxaqz2s7s87lhcta6z7pwfdwdsdgx7fwd" +
+		"3qd0wzaem2fenuju0rxf74nm2qz5grj2myqfhmq5rpqf4ks6vrn9dpvfg6yya5xa" +
+		"cmqelev4vspyj9mzm"
+	ltcMainnetInvoice = "lnltc10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpugp" +
+		"zysnzs23v9ccrydpk8qarc0sdq8w3jhxaqer4564n3xlw6up36l49e39hmhyzpnd" +
+		"h4lqfwgygpp9555we9v8kh70f8whs4fzft3ct2w0x80emmw30q3dr4uhswy8ks6v" +
+		"l9u2nrh9cqtzpct2"
+	ltcTestnetInvoice = "lntltc10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpug" +
+		"pzysnzs23v9ccrydpk8qarc0sdq8w3jhxaqcnh6vqxx3td52tmnequpcu92lener" +
+		"wjgwxkwp7svt6kraglgctkq23uy8kxrrmxd6ldwec0z6xe9wv4cjy90q6gdz25fc" +
+		"r4nn70nv7gpca97ch"
+	ltcSimnetInvoice = "lnsltc10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpugp" +
+		"zysnzs23v9ccrydpk8qarc0sdq8w3jhxaq8cacrcd8sydqch7vhz296ergszkm7n" +
+		"clkr34c0harhe8csq2r858vk0uphnndpfyvzt8dnrrxrmdc8dk0a2dgxeezy6x0e" +
+		"ud4sdzdfcq5s5aky"
+	ltcRegtestInvoice = "lnrltc10u1pvjluezpp5qqqsyqcyq5rqwzqfpg9scrgwpug" +
+		"pzysnzs23v9ccrydpk8qarc0sdq8w3jhxaqw0uw2pkzdfmykjkrdhlnh78lsmyft" +
+		"nmllgk3vat40mdnsaayu4r87z2n47zdeacsen7dr40f4hgdvuvdumvdknvknmxax" +
+		"94lnd0lzqcpwjgct0"
+)
+
+func TestGetParams(t *testing.T) {
+	invoices := []string{
+		btcMainnetInvoice, btcTestnetInvoice, btcSimnetInvoice,
+		btcRegtestInvoice, ltcMainnetInvoice, ltcTestnetInvoice,
+		ltcSimnetInvoice, ltcRegtestInvoice,
+	}
+
+	tests := []struct {
+		asset   connectors.Asset
+		net     string
+		hrp     string
+		invoice string
+	}{
+		{connectors.BTC, "mainnet", "bc", btcMainnetInvoice},
+		{connectors.BTC, "testnet", "tb", btcTestnetInvoice},
+		{connectors.BTC, "simnet", "sb", btcSimnetInvoice},
+		{connectors.BTC, "regtest", "bcrt", btcRegtestInvoice},
+		{connectors.LTC, "mainnet", "ltc", ltcMainnetInvoice},
+		{connectors.LTC, "testnet", "tltc", ltcTestnetInvoice},
+		{connectors.LTC, "simnet", "sltc", ltcSimnetInvoice},
+		{connectors.LTC, "regtest", "rltc", ltcRegtestInvoice},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.asset)+"_"+test.net, func(t *testing.T) {
+			c := newTestConnector(&mockClient{})
+			c.cfg.Asset = test.asset
+			c.cfg.Net = test.net
+
+			params, err := c.getParams()
+			if err != nil {
+				t.Fatalf("unable to get params: %v", err)
+			}
+
+			if params.Bech32HRPSegwit != test.hrp {
+				t.Fatalf("wrong hrp: %v", params.Bech32HRPSegwit)
+			}
+
+			invoice, err := c.ValidateInvoice(test.invoice, "0.00001")
+			if err != nil {
+				t.Fatalf("unable to decode invoice: %v", err)
+			}
+
+			dest := hex.EncodeToString(invoice.Destination.SerializeCompressed())
+			if dest != testNodePubKey {
+				t.Fatalf("wrong destination: %v", dest)
+			}
+
+			// Invoices of the other networks shouldn't be accepted.
+			for _, other := range invoices {
+				if other == test.invoice {
+					continue
+				}
+
+				if _, err := c.ValidateInvoice(other, "0"); err == nil {
+					t.Fatalf("invoice of other network is accepted: "+
+						"%v", other)
+				}
+			}
+		})
+	}
+}
+
+func TestGetParamsUnsupported(t *testing.T) {
+	c := newTestConnector(&mockClient{})
+	c.cfg.Asset = connectors.ETH
+
+	if _, err := c.getParams(); err == nil {
+		t.Fatalf("params of unsupported asset are returned")
+	}
+
+	c.cfg.Asset = connectors.BTC
+	c.cfg.Net = "unknown"
+
+	if _, err := c.getParams(); err == nil {
+		t.Fatalf("params of unknown network are returned")
+	}
+}
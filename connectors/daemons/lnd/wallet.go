@@ -0,0 +1,107 @@
+package lnd
+
+import (
+	"context"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/metrics"
+	"github.com/bitlum/connector/metrics/crypto"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+const (
+	MethodBalanceDetails = "BalanceDetails"
+	MethodDepositAddress = "DepositAddress"
+)
+
+// fetchBalance requests on-chain wallet, channels and pending channels
+// balances from lnd and combines them together.
+func (c *Connector) fetchBalance() (*connectors.LightningBalance, error) {
+	walletResp, err := c.client.WalletBalance(context.Background(),
+		&lnrpc.WalletBalanceRequest{})
+	if err != nil {
+		return nil, errors.Errorf("unable to get wallet balance: %v", err)
+	}
+
+	channelResp, err := c.client.ChannelBalance(context.Background(),
+		&lnrpc.ChannelBalanceRequest{})
+	if err != nil {
+		return nil, errors.Errorf("unable to get channel balance: %v", err)
+	}
+
+	pendingResp, err := c.client.PendingChannels(context.Background(),
+		&lnrpc.PendingChannelsRequest{})
+	if err != nil {
+		return nil, errors.Errorf("unable to get pending channels: %v", err)
+	}
+
+	// Funds of the closing channels are returned in our on-chain wallet
+	// only after the closing transaction is confirmed, or after the time
+	// lock is expired in case of force close.
+	var pendingClose int64
+	for _, channel := range pendingResp.PendingClosingChannels {
+		pendingClose += channel.Channel.LocalBalance
+	}
+
+	for _, channel := range pendingResp.PendingForceClosingChannels {
+		pendingClose += channel.LimboBalance
+	}
+
+	for _, channel := range pendingResp.WaitingCloseChannels {
+		pendingClose += channel.LimboBalance
+	}
+
+	return &connectors.LightningBalance{
+		WalletConfirmed: sat2DecAmount(
+			btcutil.Amount(walletResp.ConfirmedBalance)).Round(8),
+		WalletUnconfirmed: sat2DecAmount(
+			btcutil.Amount(walletResp.UnconfirmedBalance)).Round(8),
+		ChannelsLocal: sat2DecAmount(
+			btcutil.Amount(channelResp.Balance)).Round(8),
+		PendingOpen: sat2DecAmount(
+			btcutil.Amount(channelResp.PendingOpenBalance)).Round(8),
+		PendingClose: sat2DecAmount(
+			btcutil.Amount(pendingClose)).Round(8),
+	}, nil
+}
+
+// BalanceDetails returns the detailed balance of lightning network daemon,
+// including its on-chain wallet, channels and pending channels.
+//
+// NOTE: Part of the connectors.LightningConnector interface.
+func (c *Connector) BalanceDetails() (*connectors.LightningBalance, error) {
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodBalanceDetails, c.cfg.Metrics)
+	defer m.Finish()
+
+	balance, err := c.fetchBalance()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// DepositAddress returns new address of the lnd on-chain wallet, which
+// might be used to deposit funds in lightning network daemon, for example
+// in order to open channels later.
+//
+// NOTE: Part of the connectors.LightningConnector interface.
+func (c *Connector) DepositAddress() (string, error) {
+	m := crypto.NewMetric(c.cfg.Name, string(c.cfg.Asset), MethodDepositAddress, c.cfg.Metrics)
+	defer m.Finish()
+
+	req := &lnrpc.NewAddressRequest{
+		Type: lnrpc.AddressType_WITNESS_PUBKEY_HASH,
+	}
+
+	resp, err := c.client.NewAddress(context.Background(), req)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return "", errors.Errorf("unable to generate new address: %v", err)
+	}
+
+	return resp.Address, nil
+}
@@ -0,0 +1,98 @@
+package lnd
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+func newBalanceClient() *mockClient {
+	return &mockClient{
+		walletBalance: &lnrpc.WalletBalanceResponse{
+			ConfirmedBalance:   100000,
+			UnconfirmedBalance: 20000,
+		},
+		channelBalance: &lnrpc.ChannelBalanceResponse{
+			Balance:            300000,
+			PendingOpenBalance: 40000,
+		},
+		pendingChannels: &lnrpc.PendingChannelsResponse{
+			PendingClosingChannels: []*lnrpc.PendingChannelsResponse_ClosedChannel{{
+				Channel: &lnrpc.PendingChannelsResponse_PendingChannel{
+					LocalBalance: 1000,
+				},
+			}},
+			PendingForceClosingChannels: []*lnrpc.PendingChannelsResponse_ForceClosedChannel{{
+				Channel: &lnrpc.PendingChannelsResponse_PendingChannel{
+					LocalBalance: 5000,
+				},
+				LimboBalance: 2000,
+			}},
+			WaitingCloseChannels: []*lnrpc.PendingChannelsResponse_WaitingCloseChannel{{
+				Channel: &lnrpc.PendingChannelsResponse_PendingChannel{
+					LocalBalance: 3000,
+				},
+				LimboBalance: 3000,
+			}},
+		},
+	}
+}
+
+func TestFetchBalance(t *testing.T) {
+	c := newTestConnector(newBalanceClient())
+
+	balance, err := c.fetchBalance()
+	if err != nil {
+		t.Fatalf("unable to fetch balance: %v", err)
+	}
+
+	expected := map[string][2]decimal.Decimal{
+		"wallet confirmed": {
+			balance.WalletConfirmed, decimal.New(100000, -8),
+		},
+		"wallet unconfirmed": {
+			balance.WalletUnconfirmed, decimal.New(20000, -8),
+		},
+		"channels local": {
+			balance.ChannelsLocal, decimal.New(300000, -8),
+		},
+		"pending open": {
+			balance.PendingOpen, decimal.New(40000, -8),
+		},
+		"pending close": {
+			balance.PendingClose, decimal.New(6000, -8),
+		},
+	}
+
+	for name, values := range expected {
+		if !values[0].Equal(values[1]) {
+			t.Fatalf("wrong %v balance, expected: %v, got: %v", name,
+				values[1], values[0])
+		}
+	}
+}
+
+func TestConfirmedBalance(t *testing.T) {
+	c := newTestConnector(newBalanceClient())
+
+	// Available and pending balances should be the ones of on-chain
+	// wallet, as they were before channels balances were reported.
+	confirmed, err := c.ConfirmedBalance("")
+	if err != nil {
+		t.Fatalf("unable to get confirmed balance: %v", err)
+	}
+
+	if !confirmed.Equal(decimal.New(100000, -8)) {
+		t.Fatalf("wrong confirmed balance: %v", confirmed)
+	}
+
+	pending, err := c.PendingBalance("")
+	if err != nil {
+		t.Fatalf("unable to get pending balance: %v", err)
+	}
+
+	if !pending.Equal(decimal.New(20000, -8)) {
+		t.Fatalf("wrong pending balance: %v", pending)
+	}
+}
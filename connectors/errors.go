@@ -0,0 +1,28 @@
+package connectors
+
+import (
+	"fmt"
+)
+
+// ErrFeeLimitExceeded is a type matching the error interface which is
+// returned when lightning network payment couldn't be sent because routing
+// fee of the payment exceeds the fee limit.
+type ErrFeeLimitExceeded struct {
+	Reason string
+}
+
+func (e *ErrFeeLimitExceeded) Error() string {
+	return fmt.Sprintf("fee limit exceeded: %v", e.Reason)
+}
+
+// ErrRoutingFailed is a type matching the error interface which is returned
+// when lightning network payment has definitely failed, for example because
+// route to the receiver couldn't be found. In contrast with other send
+// errors, it guarantees that funds haven't been sent.
+type ErrRoutingFailed struct {
+	Reason string
+}
+
+func (e *ErrRoutingFailed) Error() string {
+	return fmt.Sprintf("unable to route payment: %v", e.Reason)
+}
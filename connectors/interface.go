@@ -1,6 +1,8 @@
 package connectors
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/zpay32"
@@ -14,6 +16,27 @@ type LightningInfo struct {
 	*lnrpc.GetInfoResponse
 }
 
+// LightningBalance is the detailed balance of lightning network daemon.
+type LightningBalance struct {
+	// WalletConfirmed is the confirmed balance of lnd on-chain wallet.
+	WalletConfirmed decimal.Decimal
+
+	// WalletUnconfirmed is the unconfirmed balance of lnd on-chain wallet.
+	WalletUnconfirmed decimal.Decimal
+
+	// ChannelsLocal is the sum of our local balances in the opened
+	// channels, this funds could be used for sending payments.
+	ChannelsLocal decimal.Decimal
+
+	// PendingOpen is the sum of our local balances in the channels which
+	// are waiting for funding transaction to be confirmed.
+	PendingOpen decimal.Decimal
+
+	// PendingClose is the sum of our funds in the closing channels,
+	// which will be returned in on-chain wallet after channel is closed.
+	PendingClose decimal.Decimal
+}
+
 type AccountAlias string
 
 const (
@@ -31,6 +54,30 @@ const (
 	AllAccounts AccountAlias = "all_accounts"
 )
 
+// SendOptions is the optional parameters of the lightning network payment,
+// zero values mean that defaults of the connector are used.
+type SendOptions struct {
+	// MaxFee is the absolute maximum fee which might be paid for routing
+	// the payment. If specified max fee percent is ignored.
+	MaxFee decimal.Decimal
+
+	// MaxFeePercent is the maximum fee which might be paid for routing the
+	// payment, in percents of the payment amount.
+	MaxFeePercent int64
+
+	// Timeout is the time after which we stop waiting for payment to be
+	// sent.
+	Timeout time.Duration
+}
+
+// PaymentOptions are the options of the blockchain payment creation.
+type PaymentOptions struct {
+	// Internal denotes that funds stay under our control, for example if
+	// they are sent to our cold wallet, and payment is created with
+	// internal direction.
+	Internal bool
+}
+
 // BlockchainConnector is an interface which describes the blockchain service
 // which is able to connect to blockchain daemon of particular currency and
 // operate with transactions, addresses, and also  able to notify other
@@ -53,8 +100,10 @@ type BlockchainConnector interface {
 	PendingTransactions(accountAlias AccountAlias) ([]*Payment, error)
 
 	// CreatePayment generates the payment, but not sends it,
-	// instead returns the payment id and waits for it to be approved.
-	CreatePayment(address, amount string) (*Payment, error)
+	// instead returns the payment id and waits for it to be approved. If
+	// options are nil, than outgoing payment is created.
+	CreatePayment(address, amount string, opts *PaymentOptions) (*Payment,
+		error)
 
 	// SendPayment sends created previously payment to the
 	// blockchain network.
@@ -68,6 +117,38 @@ type BlockchainConnector interface {
 	EstimateFee(amount string) (decimal.Decimal, error)
 }
 
+// ColdSigningConnector is implemented by blockchain connectors which are
+// able to create unsigned payments, which are signed outside of the daemon.
+// Such payments are created with unsigned transaction details and stay
+// waiting until they are finalized.
+type ColdSigningConnector interface {
+	// FinalizePayment takes base64 encoded BIP174 transaction signed
+	// externally, ensures that it matches the created payment, and sends
+	// it to the blockchain network.
+	FinalizePayment(paymentID, psbt string) (*Payment, error)
+
+	// CancelPayment fails the payment, which is waiting to be signed
+	// externally, and releases the outputs reserved for it.
+	CancelPayment(paymentID string) (*Payment, error)
+}
+
+// InvoiceOptions are the optional parameters of the lightning network
+// invoice.
+type InvoiceOptions struct {
+	// PrivateRouteHints denotes that invoice should include route hints for
+	// our private channels, otherwise payer might be unable to find the
+	// route to us.
+	PrivateRouteHints bool
+
+	// FallbackAddress is an on-chain address which might be used by payer
+	// in case if lightning network payment couldn't be made.
+	FallbackAddress string
+
+	// DescriptionHash denotes that sha256 hash of the description should be
+	// placed in the invoice instead of the description itself.
+	DescriptionHash bool
+}
+
 // LightningConnector is an interface which describes the service
 // which is able to connect lightning network daemon of particular currency and
 // operate with transactions, addresses, and also  able to notify other
@@ -76,22 +157,34 @@ type LightningConnector interface {
 	// Info returns the information about our lnd node.
 	Info() (*LightningInfo, error)
 
-	// CreateInvoice is used to create lightning network invoice.
-	CreateInvoice(account, amount, description string) (string,
-		*zpay32.Invoice, error)
+	// CreateInvoice is used to create lightning network invoice. If options
+	// are nil, than plain invoice with description placed as memo is
+	// created.
+	CreateInvoice(account, amount, description string,
+		opts *InvoiceOptions) (string, *zpay32.Invoice, error)
 
 	// SendTo is used to send specific amount of money to address within this
-	// payment system.
-	SendTo(invoice, amount string) (*Payment, error)
+	// payment system. If receipt is the public key of lightning network
+	// node, than spontaneous (keysend) payment is made. If options are nil,
+	// than defaults of the connector are used.
+	SendTo(invoice, amount string, opts *SendOptions) (*Payment, error)
 
-	// ConfirmedBalance return the amount of confirmed funds available for account.
-	// TODO(andrew.shvv) Implement lightning wallet balance
+	// ConfirmedBalance return the amount of funds available for sending in
+	// lightning network.
 	ConfirmedBalance(account string) (decimal.Decimal, error)
 
-	// PendingBalance return the amount of funds waiting to be confirmed.
-	// TODO(andrew.shvv) Implement lightning wallet balance
+	// PendingBalance return the amount of funds locked in the pending
+	// channels.
 	PendingBalance(account string) (decimal.Decimal, error)
 
+	// BalanceDetails returns the detailed balance of lightning network
+	// daemon, including its on-chain wallet.
+	BalanceDetails() (*LightningBalance, error)
+
+	// DepositAddress returns new address of the lightning network daemon
+	// on-chain wallet.
+	DepositAddress() (string, error)
+
 	// QueryRoutes returns list of routes from to the given lnd node,
 	// and insures the the capacity of the channels is sufficient.
 	QueryRoutes(pubKey, amount string, limit int32) ([]*lnrpc.Route, error)
@@ -100,6 +193,10 @@ type LightningConnector interface {
 	// its valid.
 	ValidateInvoice(invoice, amount string) (*zpay32.Invoice, error)
 
+	// ValidatePubKey takes the public key of lightning network node, which
+	// is used as receipt of the spontaneous payment, and ensure its valid.
+	ValidatePubKey(pubKey, amount string) error
+
 	// EstimateFee estimate fee for the payment with the given sending
 	// amount, to the given node.
 	EstimateFee(invoice string) (decimal.Decimal, error)
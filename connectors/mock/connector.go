@@ -0,0 +1,155 @@
+package mock
+
+import (
+	"github.com/bitlum/connector/connectors"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"github.com/shopspring/decimal"
+)
+
+// BlockchainConnector creates and sends payments by saving them in the
+// store. Methods which aren't overridden panic if called.
+type BlockchainConnector struct {
+	connectors.BlockchainConnector
+
+	// Store is the payments store where created payments are saved.
+	Store *PaymentsStore
+
+	// Balance is the confirmed balance returned for any account.
+	Balance decimal.Decimal
+
+	// Sent is the list of ids of the sent payments.
+	Sent []string
+}
+
+// ValidateAddress takes the blockchain address and ensure its valid, any
+// address is valid.
+//
+// NOTE: Part of the connectors.BlockchainConnector interface.
+func (c *BlockchainConnector) ValidateAddress(address string) error {
+	return nil
+}
+
+// ConfirmedBalance returns the predefined balance.
+//
+// NOTE: Part of the connectors.BlockchainConnector interface.
+func (c *BlockchainConnector) ConfirmedBalance(
+	connectors.AccountAlias) (decimal.Decimal, error) {
+	return c.Balance, nil
+}
+
+// EstimateFee returns zero fee.
+//
+// NOTE: Part of the connectors.BlockchainConnector interface.
+func (c *BlockchainConnector) EstimateFee(string) (decimal.Decimal, error) {
+	return decimal.Zero, nil
+}
+
+// CreatePayment saves the waiting payment, payment id is equal to the
+// address.
+//
+// NOTE: Part of the connectors.BlockchainConnector interface.
+func (c *BlockchainConnector) CreatePayment(address, amount string,
+	opts *connectors.PaymentOptions) (*connectors.Payment, error) {
+
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	direction := connectors.Outgoing
+	if opts != nil && opts.Internal {
+		direction = connectors.Internal
+	}
+
+	payment := &connectors.Payment{
+		PaymentID: address,
+		UpdatedAt: connectors.NowInMilliSeconds(),
+		Status:    connectors.Waiting,
+		Direction: direction,
+		Receipt:   address,
+		Asset:     connectors.BTC,
+		Media:     connectors.Blockchain,
+		Amount:    value,
+		Detail:    &connectors.GeneratedTxDetails{},
+	}
+
+	return payment, c.Store.SavePayment(payment)
+}
+
+// SendPayment marks the payment as pending.
+//
+// NOTE: Part of the connectors.BlockchainConnector interface.
+func (c *BlockchainConnector) SendPayment(
+	paymentID string) (*connectors.Payment, error) {
+
+	payment, err := c.Store.PaymentByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Sent = append(c.Sent, paymentID)
+	payment.Status = connectors.Pending
+	return payment, c.Store.SavePayment(payment)
+}
+
+// LightningConnector returns predefined results of the lightning network
+// payments. Methods which aren't overridden panic if called.
+type LightningConnector struct {
+	connectors.LightningConnector
+
+	// Invoice is returned as the result of the invoice validation.
+	Invoice *zpay32.Invoice
+
+	// Address is returned as the deposit address.
+	Address string
+
+	// SendPayment and SendErr are returned as the result of the payment,
+	// if both are nil completed payment is returned.
+	SendPayment *connectors.Payment
+	SendErr     error
+
+	// Sent is the number of the payments attempts.
+	Sent int
+}
+
+// DepositAddress returns the predefined address.
+//
+// NOTE: Part of the connectors.LightningConnector interface.
+func (c *LightningConnector) DepositAddress() (string, error) {
+	return c.Address, nil
+}
+
+// ValidateInvoice returns the predefined invoice.
+//
+// NOTE: Part of the connectors.LightningConnector interface.
+func (c *LightningConnector) ValidateInvoice(invoice,
+	amount string) (*zpay32.Invoice, error) {
+
+	return c.Invoice, nil
+}
+
+// SendTo returns the predefined result of the payment.
+//
+// NOTE: Part of the connectors.LightningConnector interface.
+func (c *LightningConnector) SendTo(invoice, amount string,
+	opts *connectors.SendOptions) (*connectors.Payment, error) {
+
+	c.Sent++
+
+	if c.SendPayment != nil || c.SendErr != nil {
+		return c.SendPayment, c.SendErr
+	}
+
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &connectors.Payment{
+		PaymentID: invoice,
+		Status:    connectors.Completed,
+		Direction: connectors.Outgoing,
+		Amount:    value,
+		MediaID:   "hash",
+	}, nil
+}
@@ -0,0 +1,82 @@
+package mock
+
+import (
+	"github.com/bitlum/connector/connectors"
+)
+
+// PaymentsStore is the in-memory payments store used in tests. Methods
+// which aren't overridden panic if called.
+type PaymentsStore struct {
+	connectors.PaymentsStore
+
+	Payments map[string]*connectors.Payment
+}
+
+// NewPaymentsStore returns empty payments store.
+func NewPaymentsStore() *PaymentsStore {
+	return &PaymentsStore{Payments: make(map[string]*connectors.Payment)}
+}
+
+// PaymentByID returns payment by id.
+//
+// NOTE: Part of the connectors.PaymentsStore interface.
+func (s *PaymentsStore) PaymentByID(paymentID string) (*connectors.Payment,
+	error) {
+
+	payment, ok := s.Payments[paymentID]
+	if !ok {
+		return nil, connectors.PaymentNotFound
+	}
+
+	return payment, nil
+}
+
+// SavePayment adds or updates the payment.
+//
+// NOTE: Part of the connectors.PaymentsStore interface.
+func (s *PaymentsStore) SavePayment(payment *connectors.Payment) error {
+	s.Payments[payment.PaymentID] = payment
+	return nil
+}
+
+// ListPayments returns payments which match the given parameters, empty
+// parameter matches any value, the same way as in the real store.
+//
+// NOTE: Part of the connectors.PaymentsStore interface.
+func (s *PaymentsStore) ListPayments(asset connectors.Asset,
+	status connectors.PaymentStatus, direction connectors.PaymentDirection,
+	media connectors.PaymentMedia) ([]*connectors.Payment, error) {
+
+	var payments []*connectors.Payment
+	for _, payment := range s.Payments {
+		if (asset == "" || payment.Asset == asset) &&
+			(status == "" || payment.Status == status) &&
+			(direction == "" || payment.Direction == direction) &&
+			(media == "" || payment.Media == media) {
+			payments = append(payments, payment)
+		}
+	}
+
+	return payments, nil
+}
+
+// ReceiptsStore is the in-memory receipts store used in tests. Methods
+// which aren't overridden panic if called.
+type ReceiptsStore struct {
+	connectors.ReceiptsStore
+
+	Links map[string][]string
+}
+
+// NewReceiptsStore returns empty receipts store.
+func NewReceiptsStore() *ReceiptsStore {
+	return &ReceiptsStore{Links: make(map[string][]string)}
+}
+
+// LinkReceipt links the given receipt to the original one.
+//
+// NOTE: Part of the connectors.ReceiptsStore interface.
+func (s *ReceiptsStore) LinkReceipt(receipt, linkedReceipt string) error {
+	s.Links[receipt] = append(s.Links[receipt], linkedReceipt)
+	return nil
+}
@@ -17,6 +17,8 @@ var (
 	ETH  Asset = "ETH"
 	LTC  Asset = "LTC"
 	DASH Asset = "DASH"
+	DOGE Asset = "DOGE"
+	ZEC  Asset = "ZEC"
 )
 
 // Media is a list of possible media types. Media is a type of technology which
@@ -121,6 +123,11 @@ type Payment struct {
 	// Detail stores all additional information which is needed for this type
 	// and status of payment.
 	Detail Serializable
+
+	// External denotes that payment hasn't been initiated by the connector,
+	// for example withdrawal made with the daemon directly, or the one
+	// which has been lost from the storage.
+	External bool
 }
 
 // BlockchainPendingDetails is the information about pending blockchain
@@ -132,6 +139,105 @@ type BlockchainPendingDetails struct {
 	// ConfirmationsLeft is the number of confirmations left in order to
 	// interpret the transaction as confirmed.
 	ConfirmationsLeft int64
+
+	// BlockHash is the hash of the block which includes the transaction,
+	// empty if transaction is in the mempool.
+	BlockHash string
+
+	// RawTx byte representation of blockchain transaction. It is set only
+	// for payments sent by the connector, so that transaction could be
+	// broadcast again.
+	RawTx []byte
+}
+
+// BlockchainConfirmedDetails is the information about block in which
+// blockchain transaction has been confirmed. It is used to revert the
+// payment in case of chain re-organisation.
+type BlockchainConfirmedDetails struct {
+	// BlockHash is the hash of the block which includes the transaction.
+	BlockHash string
+
+	// BlockNumber is the number of the block which includes the
+	// transaction.
+	BlockNumber int64
+
+	// RawTx byte representation of blockchain transaction. It is set only
+	// for payments sent by the connector, so that transaction could be
+	// broadcast again if block is orphaned.
+	RawTx []byte
+}
+
+// FailureReasonDoubleSpent is the reason of the blockchain payment
+// failure, which denotes that transaction has been double-spent or
+// replaced, and will never be confirmed.
+const FailureReasonDoubleSpent = "double-spent"
+
+// FailureReasonExpired is the reason of the blockchain payment failure,
+// which denotes that transaction has been neither confirmed nor seen in
+// the mempool for too long.
+const FailureReasonExpired = "expired"
+
+// FailureReasonCancelled is the reason of the blockchain payment failure,
+// which denotes that payment has been cancelled before being sent, either on
+// request or by the connector because it hasn't been sent for too long.
+const FailureReasonCancelled = "cancelled"
+
+// BlockchainFailedDetails is the information about the reason of the
+// blockchain payment failure.
+type BlockchainFailedDetails struct {
+	// Reason is the reason of the payment failure.
+	Reason string
+
+	// ReplacementTxID is the id of the transaction which replaced or
+	// double-spent the transaction of the payment, if it is known.
+	ReplacementTxID string
+
+	// ReplacementPaymentIDs are the ids of the payments, which were made
+	// to us by the replacement transaction.
+	ReplacementPaymentIDs []string
+}
+
+// LightningForwardDetails is the information about payment which has been
+// forwarded through our lightning network node.
+type LightningForwardDetails struct {
+	// ChanIDIn is the id of the channel through which payment came to us.
+	ChanIDIn uint64
+
+	// ChanIDOut is the id of the channel through which payment was
+	// forwarded further.
+	ChanIDOut uint64
+
+	// AmountIn is the amount of the incoming htlc.
+	AmountIn decimal.Decimal
+
+	// Fee is the fee which we have earned for forwarding the payment.
+	Fee decimal.Decimal
+}
+
+// LnurlWithdrawDetails is the information about lnurl-withdraw link, which
+// is stored as outgoing lightning payment. Payment is waiting while link
+// might be used, pending while withdrawal is being made, and completed
+// after that.
+type LnurlWithdrawDetails struct {
+	// K1 is the secret of the link, which is used as its identificator.
+	K1 string
+
+	// MinAmount is the minimum amount which might be withdrawn.
+	MinAmount decimal.Decimal
+
+	// MaxAmount is the maximum amount which might be withdrawn.
+	MaxAmount decimal.Decimal
+
+	// Description is the default description of the withdrawal.
+	Description string
+
+	// Expiry is the time in milliseconds after which link couldn't be
+	// used anymore.
+	Expiry int64
+
+	// PaymentID is the id of the lightning payment, which has been made
+	// with this link.
+	PaymentID string
 }
 
 // GeneratePaymentID generates payment id based of the which is uniqie for
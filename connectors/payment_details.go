@@ -78,3 +78,144 @@ func (d *GeneratedTxDetails) Encode(w io.Writer, v uint32) error {
 	_, err = w.Write(data)
 	return err
 }
+
+// UnsignedTxDetails is the unsigned blockchain transaction, which should be
+// signed outside of the daemon before being sent.
+type UnsignedTxDetails struct {
+	// PSBT is the base64 encoded BIP174 partially signed transaction.
+	PSBT string
+
+	// TxID blockchain identification of transaction, it doesn't change
+	// after transaction is signed.
+	TxID string
+}
+
+// Runtime check to ensure that UnsignedTxDetails implements
+// Serializable interface.
+var _ Serializable = (*UnsignedTxDetails)(nil)
+
+// Decode reads the bytes stream and converts it to the object.
+func (d *UnsignedTxDetails) Decode(r io.Reader, v uint32) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, d)
+}
+
+// Encode converts object to the bytes stream and write it into the
+// writer.
+func (d *UnsignedTxDetails) Encode(w io.Writer, v uint32) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// Runtime check to ensure that BlockchainConfirmedDetails implements
+// Serializable interface.
+var _ Serializable = (*BlockchainConfirmedDetails)(nil)
+
+// Decode reads the bytes stream and converts it to the object.
+func (d *BlockchainConfirmedDetails) Decode(r io.Reader, v uint32) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, d)
+}
+
+// Encode converts object to the bytes stream and write it into the
+// writer.
+func (d *BlockchainConfirmedDetails) Encode(w io.Writer, v uint32) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// Runtime check to ensure that BlockchainFailedDetails implements
+// Serializable interface.
+var _ Serializable = (*BlockchainFailedDetails)(nil)
+
+// Decode reads the bytes stream and converts it to the object.
+func (d *BlockchainFailedDetails) Decode(r io.Reader, v uint32) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, d)
+}
+
+// Encode converts object to the bytes stream and write it into the
+// writer.
+func (d *BlockchainFailedDetails) Encode(w io.Writer, v uint32) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// Runtime check to ensure that LightningForwardDetails implements
+// Serializable interface.
+var _ Serializable = (*LightningForwardDetails)(nil)
+
+// Decode reads the bytes stream and converts it to the object.
+func (d *LightningForwardDetails) Decode(r io.Reader, v uint32) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, d)
+}
+
+// Encode converts object to the bytes stream and write it into the
+// writer.
+func (d *LightningForwardDetails) Encode(w io.Writer, v uint32) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// Runtime check to ensure that LnurlWithdrawDetails implements
+// Serializable interface.
+var _ Serializable = (*LnurlWithdrawDetails)(nil)
+
+// Decode reads the bytes stream and converts it to the object.
+func (d *LnurlWithdrawDetails) Decode(r io.Reader, v uint32) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, d)
+}
+
+// Encode converts object to the bytes stream and write it into the
+// writer.
+func (d *LnurlWithdrawDetails) Encode(w io.Writer, v uint32) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
@@ -4,12 +4,15 @@ import (
 	"testing"
 	"bytes"
 	"reflect"
+	"github.com/shopspring/decimal"
 )
 
 func TestBlockchainPendingDetailsEncodeDecode(t *testing.T) {
 	d := &BlockchainPendingDetails{
 		Confirmations:     1,
 		ConfirmationsLeft: 3,
+		BlockHash:         "hash",
+		RawTx:             []byte("rawtx"),
 	}
 
 	var b bytes.Buffer
@@ -47,3 +50,119 @@ func TestGeneratedTxDetailsEncodeDecode(t *testing.T) {
 		t.Fatal("objects are different")
 	}
 }
+
+func TestUnsignedTxDetailsEncodeDecode(t *testing.T) {
+	d := &UnsignedTxDetails{
+		PSBT: "cHNidP8BAAoCAAAAAAAAAAAAAA==",
+		TxID: "txid",
+	}
+
+	var b bytes.Buffer
+	if err := d.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode details: %v", err)
+	}
+
+	d1 := &UnsignedTxDetails{}
+	if err := d1.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode details: %v", err)
+	}
+
+	if *d1 != *d {
+		t.Fatal("objects are different")
+	}
+}
+
+func TestBlockchainConfirmedDetailsEncodeDecode(t *testing.T) {
+	d := &BlockchainConfirmedDetails{
+		BlockHash:   "000000000000000000055fa3a2ab6c6c5cb7a2a5f4c3a0c8b8e3c8e4d1b2a3f4",
+		BlockNumber: 540000,
+		RawTx:       []byte("rawtx"),
+	}
+
+	var b bytes.Buffer
+	if err := d.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode details: %v", err)
+	}
+
+	d1 := &BlockchainConfirmedDetails{}
+	if err := d1.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode details: %v", err)
+	}
+
+	if !reflect.DeepEqual(d1, d) {
+		t.Fatal("objects are different")
+	}
+}
+
+func TestBlockchainFailedDetailsEncodeDecode(t *testing.T) {
+	d := &BlockchainFailedDetails{
+		Reason:                FailureReasonDoubleSpent,
+		ReplacementTxID:       "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16",
+		ReplacementPaymentIDs: []string{"1", "2"},
+	}
+
+	var b bytes.Buffer
+	if err := d.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode details: %v", err)
+	}
+
+	d1 := &BlockchainFailedDetails{}
+	if err := d1.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode details: %v", err)
+	}
+
+	if !reflect.DeepEqual(d, d1) {
+		t.Fatal("objects are different")
+	}
+}
+
+func TestLightningForwardDetailsEncodeDecode(t *testing.T) {
+	d := &LightningForwardDetails{
+		ChanIDIn:  1,
+		ChanIDOut: 2,
+		AmountIn:  decimal.New(1001, -8),
+		Fee:       decimal.New(1, -8),
+	}
+
+	var b bytes.Buffer
+	if err := d.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode details: %v", err)
+	}
+
+	d1 := &LightningForwardDetails{}
+	if err := d1.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode details: %v", err)
+	}
+
+	if d1.ChanIDIn != d.ChanIDIn || d1.ChanIDOut != d.ChanIDOut ||
+		!d1.AmountIn.Equal(d.AmountIn) || !d1.Fee.Equal(d.Fee) {
+		t.Fatal("objects are different")
+	}
+}
+
+func TestLnurlWithdrawDetailsEncodeDecode(t *testing.T) {
+	d := &LnurlWithdrawDetails{
+		K1:          "e2af6254a8df433264fa23f67eb8188635d15ce883e8fc020989d5f82ae6f11e",
+		MinAmount:   decimal.New(1, -5),
+		MaxAmount:   decimal.New(1, -3),
+		Description: "withdrawal",
+		Expiry:      1546300800000,
+		PaymentID:   "1",
+	}
+
+	var b bytes.Buffer
+	if err := d.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode details: %v", err)
+	}
+
+	d1 := &LnurlWithdrawDetails{}
+	if err := d1.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode details: %v", err)
+	}
+
+	if d1.K1 != d.K1 || !d1.MinAmount.Equal(d.MinAmount) ||
+		!d1.MaxAmount.Equal(d.MaxAmount) || d1.Description != d.Description ||
+		d1.Expiry != d.Expiry || d1.PaymentID != d.PaymentID {
+		t.Fatal("objects are different")
+	}
+}
@@ -0,0 +1,84 @@
+package connectors
+
+import (
+	"time"
+)
+
+// PendingPayments returns blockchain payments of the given asset, which
+// have been received but not confirmed yet, i.e. pending payments with
+// blockchain pending details, which haven't been sent by the connector.
+func PendingPayments(store PaymentsStore, asset Asset) ([]*Payment, error) {
+	payments, err := store.ListPayments(asset, Pending, "", Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Payment
+	for _, payment := range payments {
+		details, ok := payment.Detail.(*BlockchainPendingDetails)
+		if ok && details.RawTx == nil {
+			pending = append(pending, payment)
+		}
+	}
+
+	return pending, nil
+}
+
+// SentPayments returns blockchain payments of the given asset, which have
+// been sent by the connector but not confirmed yet, i.e. pending payments
+// with blockchain pending details, which carry the raw transaction.
+func SentPayments(store PaymentsStore, asset Asset) ([]*Payment, error) {
+	payments, err := store.ListPayments(asset, Pending, "", Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	var sent []*Payment
+	for _, payment := range payments {
+		details, ok := payment.Detail.(*BlockchainPendingDetails)
+		if ok && details.RawTx != nil {
+			sent = append(sent, payment)
+		}
+	}
+
+	return sent, nil
+}
+
+// SentRawTx returns raw transaction of the payment sent by the connector.
+func SentRawTx(payment *Payment) []byte {
+	switch details := payment.Detail.(type) {
+	case *BlockchainPendingDetails:
+		return details.RawTx
+	case *BlockchainConfirmedDetails:
+		return details.RawTx
+	}
+
+	return nil
+}
+
+// ExpiredPayments returns pending payments of the given asset, which
+// haven't been updated for longer than expiry. Pending payments are updated
+// on every sync while transaction is either in the mempool or in the
+// blockchain, so such payments transactions have vanished. Both received and
+// sent payments are returned, the latter are expired if their transactions
+// couldn't be rebroadcasted.
+func ExpiredPayments(store PaymentsStore, asset Asset,
+	expiry time.Duration) ([]*Payment, error) {
+
+	payments, err := store.ListPayments(asset, Pending, "", Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := ConvertTimeToMilliSeconds(time.Now().Add(-expiry))
+
+	var expired []*Payment
+	for _, payment := range payments {
+		_, ok := payment.Detail.(*BlockchainPendingDetails)
+		if ok && payment.UpdatedAt < deadline {
+			expired = append(expired, payment)
+		}
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,130 @@
+package connectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiredPayments(t *testing.T) {
+	now := NowInMilliSeconds()
+	stale := ConvertTimeToMilliSeconds(time.Now().Add(-2 * time.Hour))
+
+	store := &listPaymentsStore{
+		payments: []*Payment{
+			{
+				PaymentID: "fresh",
+				UpdatedAt: now,
+				Status:    Pending,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &BlockchainPendingDetails{},
+			},
+			{
+				PaymentID: "stale",
+				UpdatedAt: stale,
+				Status:    Pending,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &BlockchainPendingDetails{},
+			},
+			{
+				PaymentID: "sent",
+				UpdatedAt: stale,
+				Status:    Pending,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail: &BlockchainPendingDetails{
+					RawTx: []byte("rawtx"),
+				},
+			},
+			{
+				PaymentID: "other asset",
+				UpdatedAt: stale,
+				Status:    Pending,
+				Asset:     LTC,
+				Media:     Blockchain,
+				Detail:    &BlockchainPendingDetails{},
+			},
+		},
+	}
+
+	pending, err := PendingPayments(store, BTC)
+	if err != nil {
+		t.Fatalf("unable to get pending payments: %v", err)
+	}
+
+	if len(pending) != 2 {
+		t.Fatalf("wrong number of pending payments: %v", len(pending))
+	}
+
+	expired, err := ExpiredPayments(store, BTC, time.Hour)
+	if err != nil {
+		t.Fatalf("unable to get expired payments: %v", err)
+	}
+
+	// Sent payments, which transactions couldn't be rebroadcasted, are
+	// expired as well.
+	if len(expired) != 2 || expired[0].PaymentID != "stale" ||
+		expired[1].PaymentID != "sent" {
+		t.Fatalf("wrong expired payments: %v", expired)
+	}
+}
+
+func TestSentPayments(t *testing.T) {
+	store := &listPaymentsStore{
+		payments: []*Payment{
+			{
+				PaymentID: "received",
+				Status:    Pending,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &BlockchainPendingDetails{},
+			},
+			{
+				PaymentID: "sent",
+				Status:    Pending,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail: &BlockchainPendingDetails{
+					RawTx: []byte("rawtx"),
+				},
+			},
+			{
+				PaymentID: "sent confirmed once",
+				Status:    Pending,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail: &BlockchainPendingDetails{
+					Confirmations: 1,
+					RawTx:         []byte("rawtx"),
+				},
+			},
+			{
+				PaymentID: "waiting",
+				Status:    Waiting,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &GeneratedTxDetails{RawTx: []byte("rawtx")},
+			},
+		},
+	}
+
+	sent, err := SentPayments(store, BTC)
+	if err != nil {
+		t.Fatalf("unable to get sent payments: %v", err)
+	}
+
+	if len(sent) != 2 || sent[0].PaymentID != "sent" ||
+		sent[1].PaymentID != "sent confirmed once" {
+		t.Fatalf("wrong sent payments: %v", sent)
+	}
+
+	pending, err := PendingPayments(store, BTC)
+	if err != nil {
+		t.Fatalf("unable to get pending payments: %v", err)
+	}
+
+	if len(pending) != 1 || pending[0].PaymentID != "received" {
+		t.Fatalf("wrong pending payments: %v", pending)
+	}
+}
@@ -0,0 +1,45 @@
+package connectors
+
+import (
+	"time"
+)
+
+// WaitingPolicy denotes how stale waiting payments, i.e. payments which
+// have been created but haven't been sent for too long, for example
+// because of the crash, are resolved.
+type WaitingPolicy string
+
+var (
+	// WaitingBroadcast means that stale waiting payment is sent.
+	WaitingBroadcast WaitingPolicy = "broadcast"
+
+	// WaitingCancel means that stale waiting payment is marked as failed,
+	// and inputs of its transaction are released.
+	WaitingCancel WaitingPolicy = "cancel"
+)
+
+// StaleWaitingPayments returns waiting blockchain payments of the given
+// asset, which haven't been updated for longer than timeout, and which
+// transaction has been generated by the connector. Payments waiting to be
+// signed externally are not returned, as far as they might be finalized
+// at any time.
+func StaleWaitingPayments(store PaymentsStore, asset Asset,
+	timeout time.Duration) ([]*Payment, error) {
+
+	payments, err := store.ListPayments(asset, Waiting, "", Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := ConvertTimeToMilliSeconds(time.Now().Add(-timeout))
+
+	var stale []*Payment
+	for _, payment := range payments {
+		_, ok := payment.Detail.(*GeneratedTxDetails)
+		if ok && payment.UpdatedAt < deadline {
+			stale = append(stale, payment)
+		}
+	}
+
+	return stale, nil
+}
@@ -0,0 +1,57 @@
+package connectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStaleWaitingPayments(t *testing.T) {
+	now := NowInMilliSeconds()
+	stale := ConvertTimeToMilliSeconds(time.Now().Add(-2 * time.Hour))
+
+	store := &listPaymentsStore{
+		payments: []*Payment{
+			{
+				PaymentID: "fresh",
+				UpdatedAt: now,
+				Status:    Waiting,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &GeneratedTxDetails{},
+			},
+			{
+				PaymentID: "stale",
+				UpdatedAt: stale,
+				Status:    Waiting,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &GeneratedTxDetails{},
+			},
+			{
+				PaymentID: "unsigned",
+				UpdatedAt: stale,
+				Status:    Waiting,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &UnsignedTxDetails{},
+			},
+			{
+				PaymentID: "sent",
+				UpdatedAt: stale,
+				Status:    Pending,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &GeneratedTxDetails{},
+			},
+		},
+	}
+
+	payments, err := StaleWaitingPayments(store, BTC, time.Hour)
+	if err != nil {
+		t.Fatalf("unable to get stale waiting payments: %v", err)
+	}
+
+	if len(payments) != 1 || payments[0].PaymentID != "stale" {
+		t.Fatalf("wrong stale waiting payments: %v", payments)
+	}
+}
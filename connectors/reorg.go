@@ -0,0 +1,55 @@
+package connectors
+
+// ReorgEvent is the information about chain re-organisation, which has been
+// handled by the blockchain connector.
+type ReorgEvent struct {
+	// Asset is the asset of the blockchain which has been re-organised.
+	Asset Asset
+
+	// ForkBlockHash is the hash of the last block which is common for the
+	// orphaned and the new chain.
+	ForkBlockHash string
+
+	// ForkBlockNumber is the number of the fork block.
+	ForkBlockNumber int64
+
+	// Depth is the number of orphaned blocks.
+	Depth int
+
+	// RevertedPayments are the ids of payments, which were confirmed in the
+	// orphaned blocks, and were reverted to pending.
+	RevertedPayments []string
+
+	// FailedPayments are the ids of payments, which were confirmed in the
+	// orphaned blocks, and which transactions have vanished.
+	FailedPayments []string
+}
+
+// ReorgHandler is used by blockchain connectors to notify about handled
+// chain re-organisations.
+type ReorgHandler func(event *ReorgEvent)
+
+// OrphanedPayments returns completed blockchain payments of the given
+// asset, which were confirmed in one of the orphaned blocks.
+func OrphanedPayments(store PaymentsStore, asset Asset,
+	orphanedBlocks map[string]struct{}) ([]*Payment, error) {
+
+	payments, err := store.ListPayments(asset, Completed, "", Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphaned []*Payment
+	for _, payment := range payments {
+		details, ok := payment.Detail.(*BlockchainConfirmedDetails)
+		if !ok {
+			continue
+		}
+
+		if _, ok := orphanedBlocks[details.BlockHash]; ok {
+			orphaned = append(orphaned, payment)
+		}
+	}
+
+	return orphaned, nil
+}
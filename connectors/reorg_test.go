@@ -0,0 +1,71 @@
+package connectors
+
+import (
+	"testing"
+)
+
+// listPaymentsStore is the payments store which returns the predefined
+// list of payments.
+type listPaymentsStore struct {
+	PaymentsStore
+	payments []*Payment
+}
+
+func (s *listPaymentsStore) ListPayments(asset Asset, status PaymentStatus,
+	direction PaymentDirection, media PaymentMedia) ([]*Payment, error) {
+
+	var payments []*Payment
+	for _, payment := range s.payments {
+		if payment.Asset == asset && payment.Status == status &&
+			payment.Media == media {
+			payments = append(payments, payment)
+		}
+	}
+
+	return payments, nil
+}
+
+func TestOrphanedPayments(t *testing.T) {
+	store := &listPaymentsStore{
+		payments: []*Payment{
+			{
+				PaymentID: "orphaned",
+				Status:    Completed,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &BlockchainConfirmedDetails{BlockHash: "a"},
+			},
+			{
+				PaymentID: "canonical",
+				Status:    Completed,
+				Asset:     BTC,
+				Media:     Blockchain,
+				Detail:    &BlockchainConfirmedDetails{BlockHash: "b"},
+			},
+			{
+				PaymentID: "without details",
+				Status:    Completed,
+				Asset:     BTC,
+				Media:     Blockchain,
+			},
+			{
+				PaymentID: "other asset",
+				Status:    Completed,
+				Asset:     LTC,
+				Media:     Blockchain,
+				Detail:    &BlockchainConfirmedDetails{BlockHash: "a"},
+			},
+		},
+	}
+
+	payments, err := OrphanedPayments(store, BTC, map[string]struct{}{
+		"a": {},
+	})
+	if err != nil {
+		t.Fatalf("unable to get orphaned payments: %v", err)
+	}
+
+	if len(payments) != 1 || payments[0].PaymentID != "orphaned" {
+		t.Fatalf("wrong orphaned payments: %v", payments)
+	}
+}
@@ -0,0 +1,25 @@
+package connectors
+
+// SendBlockchainPayment creates blockchain payment and sends it. Payment
+// which should be signed externally is returned without being sent, it is
+// sent after it has been finalized. If payment has been created but
+// couldn't be sent, it is returned along with the error.
+func SendBlockchainPayment(c BlockchainConnector, address, amount string,
+	opts *PaymentOptions) (*Payment, error) {
+
+	payment, err := c.CreatePayment(address, amount, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := payment.Detail.(*UnsignedTxDetails); ok {
+		return payment, nil
+	}
+
+	sent, err := c.SendPayment(payment.PaymentID)
+	if err != nil {
+		return payment, err
+	}
+
+	return sent, nil
+}
@@ -34,3 +34,15 @@ type StateStorage interface {
 	// LastSyncedHash is used to retrieve last synchronised block hash.
 	LastSyncedHash() ([]byte, error)
 }
+
+// ReceiptsStore is used to keep links between receipts, for example
+// between lightning network invoice and its on-chain fallback address,
+// so that payments received on the linked receipt could be found by the
+// original one.
+type ReceiptsStore interface {
+	// LinkReceipt links the given receipt to the original one.
+	LinkReceipt(receipt, linkedReceipt string) error
+
+	// LinkedReceipts returns receipts linked to the given one.
+	LinkedReceipts(receipt string) ([]string, error)
+}
@@ -11,6 +11,10 @@ const (
 
 	// ErrInternal...
 	ErrInternal
+
+	// ErrFeeLimitExceeded is returned if payment couldn't be sent because
+	// its routing fee exceeds the fee limit.
+	ErrFeeLimitExceeded
 )
 
 type Error struct {
@@ -53,3 +57,10 @@ func newErrInvalidArgument(argName string) Error {
 			argName),
 	}
 }
+
+func newErrFeeLimitExceeded(desc string) Error {
+	return Error{
+		code:   ErrFeeLimitExceeded,
+		errMsg: fmt.Sprintf("%v: %v", ErrFeeLimitExceeded, desc),
+	}
+}
@@ -14,6 +14,7 @@ It has these top-level messages:
 	CreateReceiptResponse
 	BalanceRequest
 	Balance
+	LightningBalance
 	ValidateReceiptResponse
 	Invoice
 	BalanceResponse
@@ -27,6 +28,17 @@ It has these top-level messages:
 	ListPaymentsRequest
 	ListPaymentsResponse
 	Payment
+	CreatePayLinkRequest
+	CreatePayLinkResponse
+	CreateWithdrawLinkRequest
+	CreateWithdrawLinkResponse
+	LightningDepositAddressRequest
+	LightningDepositAddressResponse
+	DepositLightningRequest
+	FinalizePaymentRequest
+	CancelPaymentRequest
+	SetSweepPausedRequest
+	PaymentAttempt
 */
 package crpc
 
@@ -70,6 +82,10 @@ const (
 	Asset_LTC Asset = 4
 	// Dash
 	Asset_DASH Asset = 5
+	// Dogecoin
+	Asset_DOGE Asset = 6
+	// Zcash
+	Asset_ZEC Asset = 7
 )
 
 var Asset_name = map[int32]string{
@@ -79,6 +95,8 @@ var Asset_name = map[int32]string{
 	3: "ETH",
 	4: "LTC",
 	5: "DASH",
+	6: "DOGE",
+	7: "ZEC",
 }
 var Asset_value = map[string]int32{
 	"ASSET_NONE": 0,
@@ -87,6 +105,8 @@ var Asset_value = map[string]int32{
 	"ETH":        3,
 	"LTC":        4,
 	"DASH":       5,
+	"DOGE":       6,
+	"ZEC":        7,
 }
 
 func (x Asset) String() string {
@@ -107,17 +127,25 @@ const (
 	// LIGHTNING means that second layer on top of the blockchain is used for
 	// making the payments.
 	Media_LIGHTNING Media = 2
+	//
+	// AUTO means that media is chosen depending on the receipt type. It is
+	// used only for sending the payments. If lightning network invoice
+	// contains on-chain fallback address, than lightning network is tried
+	// first and blockchain is used if payment couldn't be routed.
+	Media_AUTO Media = 3
 )
 
 var Media_name = map[int32]string{
 	0: "MEDIA_NONE",
 	1: "BLOCKCHAIN",
 	2: "LIGHTNING",
+	3: "AUTO",
 }
 var Media_value = map[string]int32{
 	"MEDIA_NONE": 0,
 	"BLOCKCHAIN": 1,
 	"LIGHTNING":  2,
+	"AUTO":       3,
 }
 
 func (x Media) String() string {
@@ -241,6 +269,23 @@ type CreateReceiptRequest struct {
 	// description will be placed in the invoice itself, which would allow user
 	// to see what he paid for later in the wallet.
 	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	//
+	// (optional) RouteHints works only for lightning invoices. If true than
+	// invoice includes route hints for private channels of our node,
+	// otherwise payer might be unable to find the route.
+	RouteHints bool `protobuf:"varint,5,opt,name=route_hints,json=routeHints" json:"route_hints,omitempty"`
+	//
+	// (optional) FallbackAddress works only for lightning invoices. If true
+	// than on-chain address of the same asset is generated and placed in
+	// the invoice, so that payer could use it if lightning network payment
+	// couldn't be made. Payments received on this address are returned
+	// along with payments of the invoice.
+	FallbackAddress bool `protobuf:"varint,6,opt,name=fallback_address,json=fallbackAddress" json:"fallback_address,omitempty"`
+	//
+	// (optional) DescriptionHash works only for lightning invoices. If true
+	// than sha256 hash of the description is placed in the invoice instead
+	// of the description itself.
+	DescriptionHash bool `protobuf:"varint,7,opt,name=description_hash,json=descriptionHash" json:"description_hash,omitempty"`
 }
 
 func (m *CreateReceiptRequest) Reset()                    { *m = CreateReceiptRequest{} }
@@ -276,9 +321,31 @@ func (m *CreateReceiptRequest) GetDescription() string {
 	return ""
 }
 
+func (m *CreateReceiptRequest) GetRouteHints() bool {
+	if m != nil {
+		return m.RouteHints
+	}
+	return false
+}
+
+func (m *CreateReceiptRequest) GetFallbackAddress() bool {
+	if m != nil {
+		return m.FallbackAddress
+	}
+	return false
+}
+
+func (m *CreateReceiptRequest) GetDescriptionHash() bool {
+	if m != nil {
+		return m.DescriptionHash
+	}
+	return false
+}
+
 type CreateReceiptResponse struct {
 	//
 	// When this invoice was created.
+	// NOTE: Only returns for lightning network media.
 	CreationDate int64 `protobuf:"varint,1,opt,name=creation_date,json=creationDate" json:"creation_date,omitempty"`
 	//
 	// Receipt represent either blockchains address or lightning network invoice,
@@ -286,7 +353,13 @@ type CreateReceiptResponse struct {
 	Receipt string `protobuf:"bytes,2,opt,name=receipt" json:"receipt,omitempty"`
 	//
 	// Invoice expiry time in seconds. Default is 3600 (1 hour).
+	// NOTE: Only returns for lightning network media.
 	Expiry int64 `protobuf:"varint,3,opt,name=expiry" json:"expiry,omitempty"`
+	//
+	// On-chain fallback address placed in the invoice.
+	// NOTE: Only returns for lightning network media, if fallback address
+	// was requested.
+	FallbackAddress string `protobuf:"bytes,4,opt,name=fallback_address,json=fallbackAddress" json:"fallback_address,omitempty"`
 }
 
 func (m *CreateReceiptResponse) Reset()                    { *m = CreateReceiptResponse{} }
@@ -315,6 +388,13 @@ func (m *CreateReceiptResponse) GetExpiry() int64 {
 	return 0
 }
 
+func (m *CreateReceiptResponse) GetFallbackAddress() string {
+	if m != nil {
+		return m.FallbackAddress
+	}
+	return ""
+}
+
 type BalanceRequest struct {
 	//
 	// Asset is an acronim of the crypto currency.
@@ -362,6 +442,10 @@ type Balance struct {
 	// Media is a type of technology which is used to transport value of
 	// underlying asset.
 	Media Media `protobuf:"varint,4,opt,name=media,enum=crpc.Media" json:"media,omitempty"`
+	//
+	// Lightning is the detailed balance of the lightning network daemon.
+	// NOTE: Only returns for lightning network media.
+	Lightning *LightningBalance `protobuf:"bytes,5,opt,name=lightning" json:"lightning,omitempty"`
 }
 
 func (m *Balance) Reset()                    { *m = Balance{} }
@@ -397,6 +481,74 @@ func (m *Balance) GetMedia() Media {
 	return Media_MEDIA_NONE
 }
 
+func (m *Balance) GetLightning() *LightningBalance {
+	if m != nil {
+		return m.Lightning
+	}
+	return nil
+}
+
+type LightningBalance struct {
+	//
+	// WalletConfirmed is the confirmed balance of the lightning network
+	// daemon on-chain wallet.
+	WalletConfirmed string `protobuf:"bytes,1,opt,name=wallet_confirmed,json=walletConfirmed" json:"wallet_confirmed,omitempty"`
+	//
+	// WalletUnconfirmed is the unconfirmed balance of the lightning network
+	// daemon on-chain wallet.
+	WalletUnconfirmed string `protobuf:"bytes,2,opt,name=wallet_unconfirmed,json=walletUnconfirmed" json:"wallet_unconfirmed,omitempty"`
+	//
+	// ChannelsLocal is the sum of our local balances in the opened channels.
+	ChannelsLocal string `protobuf:"bytes,3,opt,name=channels_local,json=channelsLocal" json:"channels_local,omitempty"`
+	//
+	// PendingOpen is the sum of our local balances in the channels which
+	// are waiting for funding transaction to be confirmed.
+	PendingOpen string `protobuf:"bytes,4,opt,name=pending_open,json=pendingOpen" json:"pending_open,omitempty"`
+	//
+	// PendingClose is the sum of our funds in the closing channels.
+	PendingClose string `protobuf:"bytes,5,opt,name=pending_close,json=pendingClose" json:"pending_close,omitempty"`
+}
+
+func (m *LightningBalance) Reset()                    { *m = LightningBalance{} }
+func (m *LightningBalance) String() string            { return proto.CompactTextString(m) }
+func (*LightningBalance) ProtoMessage()               {}
+func (*LightningBalance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *LightningBalance) GetWalletConfirmed() string {
+	if m != nil {
+		return m.WalletConfirmed
+	}
+	return ""
+}
+
+func (m *LightningBalance) GetWalletUnconfirmed() string {
+	if m != nil {
+		return m.WalletUnconfirmed
+	}
+	return ""
+}
+
+func (m *LightningBalance) GetChannelsLocal() string {
+	if m != nil {
+		return m.ChannelsLocal
+	}
+	return ""
+}
+
+func (m *LightningBalance) GetPendingOpen() string {
+	if m != nil {
+		return m.PendingOpen
+	}
+	return ""
+}
+
+func (m *LightningBalance) GetPendingClose() string {
+	if m != nil {
+		return m.PendingClose
+	}
+	return ""
+}
+
 type ValidateReceiptResponse struct {
 	// Types that are valid to be assigned to Data:
 	//	*ValidateReceiptResponse_Invoice
@@ -406,7 +558,7 @@ type ValidateReceiptResponse struct {
 func (m *ValidateReceiptResponse) Reset()                    { *m = ValidateReceiptResponse{} }
 func (m *ValidateReceiptResponse) String() string            { return proto.CompactTextString(m) }
 func (*ValidateReceiptResponse) ProtoMessage()               {}
-func (*ValidateReceiptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*ValidateReceiptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 type isValidateReceiptResponse_Data interface{ isValidateReceiptResponse_Data() }
 
@@ -512,7 +664,7 @@ type Invoice struct {
 func (m *Invoice) Reset()                    { *m = Invoice{} }
 func (m *Invoice) String() string            { return proto.CompactTextString(m) }
 func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *Invoice) GetMemo() string {
 	if m != nil {
@@ -563,7 +715,7 @@ type BalanceResponse struct {
 func (m *BalanceResponse) Reset()                    { *m = BalanceResponse{} }
 func (m *BalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*BalanceResponse) ProtoMessage()               {}
-func (*BalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*BalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *BalanceResponse) GetBalances() []*Balance {
 	if m != nil {
@@ -575,7 +727,8 @@ func (m *BalanceResponse) GetBalances() []*Balance {
 type ValidateReceiptRequest struct {
 	//
 	// Receipt is the blockchain address in case of blockchain media and
-	// lightning network invoice in case of lightning media.
+	// lightning network invoice or lightning network node public key in
+	// case of lightning media.
 	Receipt string `protobuf:"bytes,1,opt,name=receipt" json:"receipt,omitempty"`
 	//
 	// Asset is an acronim of the crypto currency.
@@ -593,7 +746,7 @@ type ValidateReceiptRequest struct {
 func (m *ValidateReceiptRequest) Reset()                    { *m = ValidateReceiptRequest{} }
 func (m *ValidateReceiptRequest) String() string            { return proto.CompactTextString(m) }
 func (*ValidateReceiptRequest) ProtoMessage()               {}
-func (*ValidateReceiptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*ValidateReceiptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *ValidateReceiptRequest) GetReceipt() string {
 	if m != nil {
@@ -645,7 +798,7 @@ type EstimateFeeRequest struct {
 func (m *EstimateFeeRequest) Reset()                    { *m = EstimateFeeRequest{} }
 func (m *EstimateFeeRequest) String() string            { return proto.CompactTextString(m) }
 func (*EstimateFeeRequest) ProtoMessage()               {}
-func (*EstimateFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*EstimateFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *EstimateFeeRequest) GetAsset() Asset {
 	if m != nil {
@@ -685,7 +838,7 @@ type EstimateFeeResponse struct {
 func (m *EstimateFeeResponse) Reset()                    { *m = EstimateFeeResponse{} }
 func (m *EstimateFeeResponse) String() string            { return proto.CompactTextString(m) }
 func (*EstimateFeeResponse) ProtoMessage()               {}
-func (*EstimateFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*EstimateFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *EstimateFeeResponse) GetMediaFee() string {
 	if m != nil {
@@ -708,13 +861,31 @@ type SendPaymentRequest struct {
 	//
 	// Receipt represent either blockchains address or lightning
 	// network invoice, which we should use determine payment receiver.
+	// In case of lightning media it might also be the public key of the
+	// lightning network node, in this case spontaneous (keysend) payment
+	// is made.
 	Receipt string `protobuf:"bytes,4,opt,name=receipt" json:"receipt,omitempty"`
+	//
+	// (optional) MaxFee is the absolute maximum fee which might be paid for
+	// routing the payment. Couldn't be used together with max fee percent.
+	// NOTE: Only used for lightning network media.
+	MaxFee string `protobuf:"bytes,5,opt,name=max_fee,json=maxFee" json:"max_fee,omitempty"`
+	//
+	// (optional) MaxFeePercent is the maximum fee which might be paid for
+	// routing the payment, in percents of the payment amount.
+	// NOTE: Only used for lightning network media.
+	MaxFeePercent int64 `protobuf:"varint,6,opt,name=max_fee_percent,json=maxFeePercent" json:"max_fee_percent,omitempty"`
+	//
+	// (optional) Timeout in seconds after which we stop waiting for payment
+	// to be sent.
+	// NOTE: Only used for lightning network media.
+	Timeout int64 `protobuf:"varint,7,opt,name=timeout" json:"timeout,omitempty"`
 }
 
 func (m *SendPaymentRequest) Reset()                    { *m = SendPaymentRequest{} }
 func (m *SendPaymentRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendPaymentRequest) ProtoMessage()               {}
-func (*SendPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*SendPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *SendPaymentRequest) GetAsset() Asset {
 	if m != nil {
@@ -744,6 +915,27 @@ func (m *SendPaymentRequest) GetReceipt() string {
 	return ""
 }
 
+func (m *SendPaymentRequest) GetMaxFee() string {
+	if m != nil {
+		return m.MaxFee
+	}
+	return ""
+}
+
+func (m *SendPaymentRequest) GetMaxFeePercent() int64 {
+	if m != nil {
+		return m.MaxFeePercent
+	}
+	return 0
+}
+
+func (m *SendPaymentRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 type PaymentByIDRequest struct {
 	//
 	// PaymentID is the payment id which was created by service itself,
@@ -754,7 +946,7 @@ type PaymentByIDRequest struct {
 func (m *PaymentByIDRequest) Reset()                    { *m = PaymentByIDRequest{} }
 func (m *PaymentByIDRequest) String() string            { return proto.CompactTextString(m) }
 func (*PaymentByIDRequest) ProtoMessage()               {}
-func (*PaymentByIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*PaymentByIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *PaymentByIDRequest) GetPaymentId() string {
 	if m != nil {
@@ -773,7 +965,7 @@ type PaymentsByReceiptRequest struct {
 func (m *PaymentsByReceiptRequest) Reset()                    { *m = PaymentsByReceiptRequest{} }
 func (m *PaymentsByReceiptRequest) String() string            { return proto.CompactTextString(m) }
 func (*PaymentsByReceiptRequest) ProtoMessage()               {}
-func (*PaymentsByReceiptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*PaymentsByReceiptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *PaymentsByReceiptRequest) GetReceipt() string {
 	if m != nil {
@@ -789,7 +981,7 @@ type PaymentsByReceiptResponse struct {
 func (m *PaymentsByReceiptResponse) Reset()                    { *m = PaymentsByReceiptResponse{} }
 func (m *PaymentsByReceiptResponse) String() string            { return proto.CompactTextString(m) }
 func (*PaymentsByReceiptResponse) ProtoMessage()               {}
-func (*PaymentsByReceiptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*PaymentsByReceiptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *PaymentsByReceiptResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -817,7 +1009,7 @@ type ListPaymentsRequest struct {
 func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
 func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *ListPaymentsRequest) GetStatus() PaymentStatus {
 	if m != nil {
@@ -854,7 +1046,7 @@ type ListPaymentsResponse struct {
 func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
 func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -901,12 +1093,49 @@ type Payment struct {
 	// MediaFee is the fee which is taken by the blockchain or lightning
 	// network in order to propagate the payment.
 	MediaFee string `protobuf:"bytes,10,opt,name=media_fee,json=mediaFee" json:"media_fee,omitempty"`
+	//
+	// Attempts is the list of attempts made to send the payment, in the
+	// order they were made.
+	// NOTE: Only returns for payments sent with AUTO media.
+	Attempts []*PaymentAttempt `protobuf:"bytes,11,rep,name=attempts" json:"attempts,omitempty"`
+	//
+	// FailureReason is the reason why payment has failed, e.g.
+	// "double-spent" in case of the incoming blockchain payment which
+	// transaction has been replaced.
+	FailureReason string `protobuf:"bytes,12,opt,name=failure_reason,json=failureReason" json:"failure_reason,omitempty"`
+	//
+	// ReplacementPaymentIds are the ids of the payments made by the
+	// transaction which has replaced the transaction of the failed payment.
+	ReplacementPaymentIds []string `protobuf:"bytes,13,rep,name=replacement_payment_ids,json=replacementPaymentIds" json:"replacement_payment_ids,omitempty"`
+	//
+	// Psbt is the base64 encoded unsigned BIP174 transaction of the
+	// blockchain payment, which should be signed externally and finalized
+	// with FinalizePayment.
+	// NOTE: Only returns for waiting payments of the cold signing wallets.
+	Psbt string `protobuf:"bytes,14,opt,name=psbt" json:"psbt,omitempty"`
+	//
+	// External denotes that payment hasn't been initiated by the connector,
+	// for example withdrawal made with the daemon directly, or the one
+	// which has been lost from the storage.
+	External bool `protobuf:"varint,15,opt,name=external" json:"external,omitempty"`
+	//
+	// Confirmations is the number of confirmations of the pending
+	// blockchain payment transaction.
+	Confirmations int64 `protobuf:"varint,16,opt,name=confirmations" json:"confirmations,omitempty"`
+	//
+	// ConfirmationsLeft is the number of confirmations left before pending
+	// blockchain payment is considered to be confirmed.
+	ConfirmationsLeft int64 `protobuf:"varint,17,opt,name=confirmations_left,json=confirmationsLeft" json:"confirmations_left,omitempty"`
+	//
+	// BlockHash is the hash of the block which includes transaction of the
+	// blockchain payment, empty if transaction is not in the block yet.
+	BlockHash string `protobuf:"bytes,18,opt,name=block_hash,json=blockHash" json:"block_hash,omitempty"`
 }
 
 func (m *Payment) Reset()                    { *m = Payment{} }
 func (m *Payment) String() string            { return proto.CompactTextString(m) }
 func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *Payment) GetPaymentId() string {
 	if m != nil {
@@ -978,6 +1207,361 @@ func (m *Payment) GetMediaFee() string {
 	return ""
 }
 
+func (m *Payment) GetAttempts() []*PaymentAttempt {
+	if m != nil {
+		return m.Attempts
+	}
+	return nil
+}
+
+func (m *Payment) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
+	}
+	return ""
+}
+
+func (m *Payment) GetReplacementPaymentIds() []string {
+	if m != nil {
+		return m.ReplacementPaymentIds
+	}
+	return nil
+}
+
+func (m *Payment) GetPsbt() string {
+	if m != nil {
+		return m.Psbt
+	}
+	return ""
+}
+
+func (m *Payment) GetExternal() bool {
+	if m != nil {
+		return m.External
+	}
+	return false
+}
+
+func (m *Payment) GetConfirmations() int64 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+func (m *Payment) GetConfirmationsLeft() int64 {
+	if m != nil {
+		return m.ConfirmationsLeft
+	}
+	return 0
+}
+
+func (m *Payment) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+type CreatePayLinkRequest struct {
+	//
+	// Asset is an acronim of the crypto currency.
+	Asset Asset `protobuf:"varint,1,opt,name=asset,enum=crpc.Asset" json:"asset,omitempty"`
+	//
+	// (optional) Account is the account for which invoices will be created,
+	// if not specified default account is used.
+	Account string `protobuf:"bytes,2,opt,name=account" json:"account,omitempty"`
+}
+
+func (m *CreatePayLinkRequest) Reset()                    { *m = CreatePayLinkRequest{} }
+func (m *CreatePayLinkRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreatePayLinkRequest) ProtoMessage()               {}
+func (*CreatePayLinkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *CreatePayLinkRequest) GetAsset() Asset {
+	if m != nil {
+		return m.Asset
+	}
+	return Asset_ASSET_NONE
+}
+
+func (m *CreatePayLinkRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+type CreatePayLinkResponse struct {
+	//
+	// Lnurl is the bech32 encoded lnurl-pay link.
+	Lnurl string `protobuf:"bytes,1,opt,name=lnurl" json:"lnurl,omitempty"`
+}
+
+func (m *CreatePayLinkResponse) Reset()                    { *m = CreatePayLinkResponse{} }
+func (m *CreatePayLinkResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreatePayLinkResponse) ProtoMessage()               {}
+func (*CreatePayLinkResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *CreatePayLinkResponse) GetLnurl() string {
+	if m != nil {
+		return m.Lnurl
+	}
+	return ""
+}
+
+type CreateWithdrawLinkRequest struct {
+	//
+	// Asset is an acronim of the crypto currency.
+	Asset Asset `protobuf:"varint,1,opt,name=asset,enum=crpc.Asset" json:"asset,omitempty"`
+	//
+	// (optional) MinAmount is the minimum amount which could be withdrawn.
+	MinAmount string `protobuf:"bytes,2,opt,name=min_amount,json=minAmount" json:"min_amount,omitempty"`
+	//
+	// MaxAmount is the maximum amount which could be withdrawn.
+	MaxAmount string `protobuf:"bytes,3,opt,name=max_amount,json=maxAmount" json:"max_amount,omitempty"`
+	//
+	// (optional) Description is the default description which wallet will
+	// put in the invoice.
+	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *CreateWithdrawLinkRequest) Reset()                    { *m = CreateWithdrawLinkRequest{} }
+func (m *CreateWithdrawLinkRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateWithdrawLinkRequest) ProtoMessage()               {}
+func (*CreateWithdrawLinkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *CreateWithdrawLinkRequest) GetAsset() Asset {
+	if m != nil {
+		return m.Asset
+	}
+	return Asset_ASSET_NONE
+}
+
+func (m *CreateWithdrawLinkRequest) GetMinAmount() string {
+	if m != nil {
+		return m.MinAmount
+	}
+	return ""
+}
+
+func (m *CreateWithdrawLinkRequest) GetMaxAmount() string {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return ""
+}
+
+func (m *CreateWithdrawLinkRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type CreateWithdrawLinkResponse struct {
+	//
+	// Lnurl is the bech32 encoded lnurl-withdraw link.
+	Lnurl string `protobuf:"bytes,1,opt,name=lnurl" json:"lnurl,omitempty"`
+	//
+	// Expiry is the unix time in milliseconds after which link couldn't be
+	// used anymore.
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry" json:"expiry,omitempty"`
+}
+
+func (m *CreateWithdrawLinkResponse) Reset()                    { *m = CreateWithdrawLinkResponse{} }
+func (m *CreateWithdrawLinkResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateWithdrawLinkResponse) ProtoMessage()               {}
+func (*CreateWithdrawLinkResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *CreateWithdrawLinkResponse) GetLnurl() string {
+	if m != nil {
+		return m.Lnurl
+	}
+	return ""
+}
+
+func (m *CreateWithdrawLinkResponse) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+type LightningDepositAddressRequest struct {
+	//
+	// Asset is an acronim of the crypto currency.
+	Asset Asset `protobuf:"varint,1,opt,name=asset,enum=crpc.Asset" json:"asset,omitempty"`
+}
+
+func (m *LightningDepositAddressRequest) Reset()         { *m = LightningDepositAddressRequest{} }
+func (m *LightningDepositAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*LightningDepositAddressRequest) ProtoMessage()    {}
+func (*LightningDepositAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{24}
+}
+
+func (m *LightningDepositAddressRequest) GetAsset() Asset {
+	if m != nil {
+		return m.Asset
+	}
+	return Asset_ASSET_NONE
+}
+
+type LightningDepositAddressResponse struct {
+	//
+	// Address is the blockchain address of lightning network daemon
+	// on-chain wallet.
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+}
+
+func (m *LightningDepositAddressResponse) Reset()         { *m = LightningDepositAddressResponse{} }
+func (m *LightningDepositAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*LightningDepositAddressResponse) ProtoMessage()    {}
+func (*LightningDepositAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{25}
+}
+
+func (m *LightningDepositAddressResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type DepositLightningRequest struct {
+	//
+	// Asset is an acronim of the crypto currency.
+	Asset Asset `protobuf:"varint,1,opt,name=asset,enum=crpc.Asset" json:"asset,omitempty"`
+	//
+	// Amount is number of money which should be moved in lightning network
+	// daemon on-chain wallet.
+	Amount string `protobuf:"bytes,2,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *DepositLightningRequest) Reset()                    { *m = DepositLightningRequest{} }
+func (m *DepositLightningRequest) String() string            { return proto.CompactTextString(m) }
+func (*DepositLightningRequest) ProtoMessage()               {}
+func (*DepositLightningRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *DepositLightningRequest) GetAsset() Asset {
+	if m != nil {
+		return m.Asset
+	}
+	return Asset_ASSET_NONE
+}
+
+func (m *DepositLightningRequest) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+type FinalizePaymentRequest struct {
+	//
+	// PaymentID is the id of the payment which is waiting to be signed.
+	PaymentId string `protobuf:"bytes,1,opt,name=payment_id,json=paymentId" json:"payment_id,omitempty"`
+	//
+	// Psbt is the base64 encoded BIP174 transaction signed by the external
+	// signer.
+	Psbt string `protobuf:"bytes,2,opt,name=psbt" json:"psbt,omitempty"`
+}
+
+func (m *FinalizePaymentRequest) Reset()                    { *m = FinalizePaymentRequest{} }
+func (m *FinalizePaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*FinalizePaymentRequest) ProtoMessage()               {}
+func (*FinalizePaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *FinalizePaymentRequest) GetPaymentId() string {
+	if m != nil {
+		return m.PaymentId
+	}
+	return ""
+}
+
+func (m *FinalizePaymentRequest) GetPsbt() string {
+	if m != nil {
+		return m.Psbt
+	}
+	return ""
+}
+
+type CancelPaymentRequest struct {
+	//
+	// PaymentID is the id of the payment which is waiting to be signed.
+	PaymentId string `protobuf:"bytes,1,opt,name=payment_id,json=paymentId" json:"payment_id,omitempty"`
+}
+
+func (m *CancelPaymentRequest) Reset()                    { *m = CancelPaymentRequest{} }
+func (m *CancelPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*CancelPaymentRequest) ProtoMessage()               {}
+func (*CancelPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *CancelPaymentRequest) GetPaymentId() string {
+	if m != nil {
+		return m.PaymentId
+	}
+	return ""
+}
+
+type SetSweepPausedRequest struct {
+	//
+	// Asset is an acronim of the crypto currency.
+	Asset Asset `protobuf:"varint,1,opt,name=asset,enum=crpc.Asset" json:"asset,omitempty"`
+	//
+	// Paused denotes whether sweeping should be paused or resumed.
+	Paused bool `protobuf:"varint,2,opt,name=paused" json:"paused,omitempty"`
+}
+
+func (m *SetSweepPausedRequest) Reset()                    { *m = SetSweepPausedRequest{} }
+func (m *SetSweepPausedRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetSweepPausedRequest) ProtoMessage()               {}
+func (*SetSweepPausedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *SetSweepPausedRequest) GetAsset() Asset {
+	if m != nil {
+		return m.Asset
+	}
+	return Asset_ASSET_NONE
+}
+
+func (m *SetSweepPausedRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+type PaymentAttempt struct {
+	//
+	// Media which was used to send the payment.
+	Media Media `protobuf:"varint,1,opt,name=media,enum=crpc.Media" json:"media,omitempty"`
+	//
+	// Error is the reason of the attempt failure, empty if attempt
+	// succeeded.
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *PaymentAttempt) Reset()                    { *m = PaymentAttempt{} }
+func (m *PaymentAttempt) String() string            { return proto.CompactTextString(m) }
+func (*PaymentAttempt) ProtoMessage()               {}
+func (*PaymentAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *PaymentAttempt) GetMedia() Media {
+	if m != nil {
+		return m.Media
+	}
+	return Media_MEDIA_NONE
+}
+
+func (m *PaymentAttempt) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*EmptyRequest)(nil), "crpc.EmptyRequest")
 	proto.RegisterType((*EmptyResponse)(nil), "crpc.EmptyResponse")
@@ -985,6 +1569,7 @@ func init() {
 	proto.RegisterType((*CreateReceiptResponse)(nil), "crpc.CreateReceiptResponse")
 	proto.RegisterType((*BalanceRequest)(nil), "crpc.BalanceRequest")
 	proto.RegisterType((*Balance)(nil), "crpc.Balance")
+	proto.RegisterType((*LightningBalance)(nil), "crpc.LightningBalance")
 	proto.RegisterType((*ValidateReceiptResponse)(nil), "crpc.ValidateReceiptResponse")
 	proto.RegisterType((*Invoice)(nil), "crpc.Invoice")
 	proto.RegisterType((*BalanceResponse)(nil), "crpc.BalanceResponse")
@@ -998,6 +1583,17 @@ func init() {
 	proto.RegisterType((*ListPaymentsRequest)(nil), "crpc.ListPaymentsRequest")
 	proto.RegisterType((*ListPaymentsResponse)(nil), "crpc.ListPaymentsResponse")
 	proto.RegisterType((*Payment)(nil), "crpc.Payment")
+	proto.RegisterType((*CreatePayLinkRequest)(nil), "crpc.CreatePayLinkRequest")
+	proto.RegisterType((*CreatePayLinkResponse)(nil), "crpc.CreatePayLinkResponse")
+	proto.RegisterType((*CreateWithdrawLinkRequest)(nil), "crpc.CreateWithdrawLinkRequest")
+	proto.RegisterType((*CreateWithdrawLinkResponse)(nil), "crpc.CreateWithdrawLinkResponse")
+	proto.RegisterType((*LightningDepositAddressRequest)(nil), "crpc.LightningDepositAddressRequest")
+	proto.RegisterType((*LightningDepositAddressResponse)(nil), "crpc.LightningDepositAddressResponse")
+	proto.RegisterType((*DepositLightningRequest)(nil), "crpc.DepositLightningRequest")
+	proto.RegisterType((*FinalizePaymentRequest)(nil), "crpc.FinalizePaymentRequest")
+	proto.RegisterType((*CancelPaymentRequest)(nil), "crpc.CancelPaymentRequest")
+	proto.RegisterType((*SetSweepPausedRequest)(nil), "crpc.SetSweepPausedRequest")
+	proto.RegisterType((*PaymentAttempt)(nil), "crpc.PaymentAttempt")
 	proto.RegisterEnum("crpc.Asset", Asset_name, Asset_value)
 	proto.RegisterEnum("crpc.Media", Media_name, Media_value)
 	proto.RegisterEnum("crpc.PaymentStatus", PaymentStatus_name, PaymentStatus_value)
@@ -1047,6 +1643,37 @@ type PayServerClient interface {
 	// ListPayments returnes list of payment which were registered by the
 	// system.
 	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	//
+	// CreatePayLink returns lnurl-pay link for the account, which might be
+	// used by lightning network wallets to request invoices.
+	CreatePayLink(ctx context.Context, in *CreatePayLinkRequest, opts ...grpc.CallOption) (*CreatePayLinkResponse, error)
+	//
+	// CreateWithdrawLink creates one time lnurl-withdraw link, which might be
+	// used by lightning network wallets to withdraw funds within the given
+	// limits.
+	CreateWithdrawLink(ctx context.Context, in *CreateWithdrawLinkRequest, opts ...grpc.CallOption) (*CreateWithdrawLinkResponse, error)
+	//
+	// LightningDepositAddress returns new address of the lightning network
+	// daemon on-chain wallet, which might be used to deposit funds in it.
+	LightningDepositAddress(ctx context.Context, in *LightningDepositAddressRequest, opts ...grpc.CallOption) (*LightningDepositAddressResponse, error)
+	//
+	// DepositLightning moves funds from the blockchain hot wallet in the
+	// lightning network daemon on-chain wallet. Payment is registered as
+	// internal.
+	DepositLightning(ctx context.Context, in *DepositLightningRequest, opts ...grpc.CallOption) (*Payment, error)
+	//
+	// FinalizePayment takes externally signed BIP174 transaction of the
+	// payment, which is waiting to be signed, ensures that it matches the
+	// payment, and sends it in the blockchain network.
+	FinalizePayment(ctx context.Context, in *FinalizePaymentRequest, opts ...grpc.CallOption) (*Payment, error)
+	//
+	// CancelPayment fails the payment, which is waiting to be signed, and
+	// releases the outputs reserved for it.
+	CancelPayment(ctx context.Context, in *CancelPaymentRequest, opts ...grpc.CallOption) (*Payment, error)
+	//
+	// SetSweepPaused pauses or resumes automatic sweeping of the hot wallet
+	// funds in the cold wallet.
+	SetSweepPaused(ctx context.Context, in *SetSweepPausedRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 }
 
 type payServerClient struct {
@@ -1129,6 +1756,69 @@ func (c *payServerClient) ListPayments(ctx context.Context, in *ListPaymentsRequ
 	return out, nil
 }
 
+func (c *payServerClient) CreatePayLink(ctx context.Context, in *CreatePayLinkRequest, opts ...grpc.CallOption) (*CreatePayLinkResponse, error) {
+	out := new(CreatePayLinkResponse)
+	err := grpc.Invoke(ctx, "/crpc.PayServer/CreatePayLink", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *payServerClient) CreateWithdrawLink(ctx context.Context, in *CreateWithdrawLinkRequest, opts ...grpc.CallOption) (*CreateWithdrawLinkResponse, error) {
+	out := new(CreateWithdrawLinkResponse)
+	err := grpc.Invoke(ctx, "/crpc.PayServer/CreateWithdrawLink", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *payServerClient) LightningDepositAddress(ctx context.Context, in *LightningDepositAddressRequest, opts ...grpc.CallOption) (*LightningDepositAddressResponse, error) {
+	out := new(LightningDepositAddressResponse)
+	err := grpc.Invoke(ctx, "/crpc.PayServer/LightningDepositAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *payServerClient) DepositLightning(ctx context.Context, in *DepositLightningRequest, opts ...grpc.CallOption) (*Payment, error) {
+	out := new(Payment)
+	err := grpc.Invoke(ctx, "/crpc.PayServer/DepositLightning", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *payServerClient) FinalizePayment(ctx context.Context, in *FinalizePaymentRequest, opts ...grpc.CallOption) (*Payment, error) {
+	out := new(Payment)
+	err := grpc.Invoke(ctx, "/crpc.PayServer/FinalizePayment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *payServerClient) CancelPayment(ctx context.Context, in *CancelPaymentRequest, opts ...grpc.CallOption) (*Payment, error) {
+	out := new(Payment)
+	err := grpc.Invoke(ctx, "/crpc.PayServer/CancelPayment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *payServerClient) SetSweepPaused(ctx context.Context, in *SetSweepPausedRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := grpc.Invoke(ctx, "/crpc.PayServer/SetSweepPaused", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for PayServer service
 
 type PayServerServer interface {
@@ -1164,6 +1854,37 @@ type PayServerServer interface {
 	// ListPayments returnes list of payment which were registered by the
 	// system.
 	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	//
+	// CreatePayLink returns lnurl-pay link for the account, which might be
+	// used by lightning network wallets to request invoices.
+	CreatePayLink(context.Context, *CreatePayLinkRequest) (*CreatePayLinkResponse, error)
+	//
+	// CreateWithdrawLink creates one time lnurl-withdraw link, which might be
+	// used by lightning network wallets to withdraw funds within the given
+	// limits.
+	CreateWithdrawLink(context.Context, *CreateWithdrawLinkRequest) (*CreateWithdrawLinkResponse, error)
+	//
+	// LightningDepositAddress returns new address of the lightning network
+	// daemon on-chain wallet, which might be used to deposit funds in it.
+	LightningDepositAddress(context.Context, *LightningDepositAddressRequest) (*LightningDepositAddressResponse, error)
+	//
+	// DepositLightning moves funds from the blockchain hot wallet in the
+	// lightning network daemon on-chain wallet. Payment is registered as
+	// internal.
+	DepositLightning(context.Context, *DepositLightningRequest) (*Payment, error)
+	//
+	// FinalizePayment takes externally signed BIP174 transaction of the
+	// payment, which is waiting to be signed, ensures that it matches the
+	// payment, and sends it in the blockchain network.
+	FinalizePayment(context.Context, *FinalizePaymentRequest) (*Payment, error)
+	//
+	// CancelPayment fails the payment, which is waiting to be signed, and
+	// releases the outputs reserved for it.
+	CancelPayment(context.Context, *CancelPaymentRequest) (*Payment, error)
+	//
+	// SetSweepPaused pauses or resumes automatic sweeping of the hot wallet
+	// funds in the cold wallet.
+	SetSweepPaused(context.Context, *SetSweepPausedRequest) (*EmptyResponse, error)
 }
 
 func RegisterPayServerServer(s *grpc.Server, srv PayServerServer) {
@@ -1314,6 +2035,132 @@ func _PayServer_ListPayments_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PayServer_CreatePayLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePayLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PayServerServer).CreatePayLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crpc.PayServer/CreatePayLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PayServerServer).CreatePayLink(ctx, req.(*CreatePayLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PayServer_CreateWithdrawLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWithdrawLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PayServerServer).CreateWithdrawLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crpc.PayServer/CreateWithdrawLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PayServerServer).CreateWithdrawLink(ctx, req.(*CreateWithdrawLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PayServer_LightningDepositAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LightningDepositAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PayServerServer).LightningDepositAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crpc.PayServer/LightningDepositAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PayServerServer).LightningDepositAddress(ctx, req.(*LightningDepositAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PayServer_DepositLightning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositLightningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PayServerServer).DepositLightning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crpc.PayServer/DepositLightning",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PayServerServer).DepositLightning(ctx, req.(*DepositLightningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PayServer_FinalizePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizePaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PayServerServer).FinalizePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crpc.PayServer/FinalizePayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PayServerServer).FinalizePayment(ctx, req.(*FinalizePaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PayServer_CancelPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PayServerServer).CancelPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crpc.PayServer/CancelPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PayServerServer).CancelPayment(ctx, req.(*CancelPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PayServer_SetSweepPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSweepPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PayServerServer).SetSweepPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crpc.PayServer/SetSweepPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PayServerServer).SetSweepPaused(ctx, req.(*SetSweepPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PayServer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "crpc.PayServer",
 	HandlerType: (*PayServerServer)(nil),
@@ -1350,6 +2197,34 @@ var _PayServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListPayments",
 			Handler:    _PayServer_ListPayments_Handler,
 		},
+		{
+			MethodName: "CreatePayLink",
+			Handler:    _PayServer_CreatePayLink_Handler,
+		},
+		{
+			MethodName: "CreateWithdrawLink",
+			Handler:    _PayServer_CreateWithdrawLink_Handler,
+		},
+		{
+			MethodName: "LightningDepositAddress",
+			Handler:    _PayServer_LightningDepositAddress_Handler,
+		},
+		{
+			MethodName: "DepositLightning",
+			Handler:    _PayServer_DepositLightning_Handler,
+		},
+		{
+			MethodName: "FinalizePayment",
+			Handler:    _PayServer_FinalizePayment_Handler,
+		},
+		{
+			MethodName: "CancelPayment",
+			Handler:    _PayServer_CancelPayment_Handler,
+		},
+		{
+			MethodName: "SetSweepPaused",
+			Handler:    _PayServer_SetSweepPaused_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
@@ -1358,68 +2233,114 @@ var _PayServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1007 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xcb, 0x6e, 0xe3, 0x54,
-	0x18, 0x1e, 0xd7, 0xce, 0xc5, 0x7f, 0x2e, 0x35, 0x67, 0x3a, 0xc5, 0xcd, 0xcc, 0x40, 0x31, 0x9b,
-	0xa1, 0x48, 0x5d, 0x74, 0x46, 0xb3, 0x62, 0xe3, 0x24, 0x6e, 0x63, 0x91, 0x3a, 0x91, 0xe3, 0x0e,
-	0xcb, 0xe8, 0xd4, 0x3e, 0x83, 0x2c, 0x12, 0xc7, 0xd8, 0x4e, 0x45, 0x9e, 0x80, 0x0d, 0x0b, 0x56,
-	0x3c, 0x06, 0x5b, 0x76, 0x3c, 0x08, 0x4f, 0x83, 0x7c, 0x2e, 0x89, 0x9d, 0xa4, 0x6a, 0x2b, 0x8d,
-	0x60, 0xe7, 0xf3, 0x5f, 0x3e, 0x7f, 0xff, 0xf5, 0x1c, 0x50, 0x93, 0xd8, 0x3f, 0x8f, 0x93, 0x45,
-	0xb6, 0x40, 0x8a, 0x9f, 0xc4, 0xbe, 0xd1, 0x86, 0xa6, 0x35, 0x8f, 0xb3, 0x95, 0x4b, 0x7e, 0x5e,
-	0x92, 0x34, 0x33, 0x0e, 0xa1, 0xc5, 0xcf, 0x69, 0xbc, 0x88, 0x52, 0x62, 0xfc, 0x21, 0xc1, 0x51,
-	0x2f, 0x21, 0x38, 0x23, 0x2e, 0xf1, 0x49, 0x18, 0x67, 0xdc, 0x12, 0x7d, 0x05, 0x15, 0x9c, 0xa6,
-	0x24, 0xd3, 0xa5, 0x53, 0xe9, 0x4d, 0xfb, 0xa2, 0x71, 0x9e, 0xe3, 0x9d, 0x9b, 0xb9, 0xc8, 0x65,
-	0x9a, 0xdc, 0x64, 0x4e, 0x82, 0x10, 0xeb, 0x07, 0x45, 0x93, 0xeb, 0x5c, 0xe4, 0x32, 0x0d, 0x3a,
-	0x86, 0x2a, 0x9e, 0x2f, 0x96, 0x51, 0xa6, 0xcb, 0xa7, 0xd2, 0x1b, 0xd5, 0xe5, 0x27, 0x74, 0x0a,
-	0x8d, 0x80, 0xa4, 0x7e, 0x12, 0xc6, 0x59, 0xb8, 0x88, 0x74, 0x85, 0x2a, 0x8b, 0x22, 0x23, 0x82,
-	0x17, 0x5b, 0xbc, 0x18, 0x63, 0xf4, 0x35, 0xb4, 0xfc, 0x5c, 0x11, 0x2e, 0xa2, 0x69, 0x80, 0x33,
-	0x42, 0x09, 0xca, 0x6e, 0x53, 0x08, 0xfb, 0x38, 0x23, 0x48, 0x87, 0x5a, 0xc2, 0xfc, 0x28, 0x39,
-	0xd5, 0x15, 0xc7, 0x9c, 0x11, 0xf9, 0x25, 0x0e, 0x93, 0x15, 0x65, 0x24, 0xbb, 0xfc, 0x64, 0x7c,
-	0x80, 0x76, 0x17, 0xcf, 0x70, 0xe4, 0x93, 0x4f, 0x9a, 0x01, 0xe3, 0x57, 0x09, 0x6a, 0x1c, 0x18,
-	0xbd, 0x02, 0x15, 0xdf, 0xe1, 0x70, 0x86, 0x6f, 0x67, 0x8c, 0xb6, 0xea, 0x6e, 0x04, 0x39, 0xe7,
-	0x98, 0x44, 0x41, 0x18, 0xfd, 0x28, 0x38, 0xf3, 0xe3, 0x86, 0x89, 0xfc, 0x30, 0x13, 0xe5, 0x5e,
-	0x26, 0x43, 0xf8, 0xfc, 0x03, 0x9e, 0x85, 0xc1, 0x9e, 0x9c, 0x7e, 0x03, 0xb5, 0x30, 0xba, 0x5b,
-	0x84, 0x3e, 0xa3, 0xd5, 0xb8, 0x68, 0x31, 0x7f, 0x9b, 0x09, 0x07, 0xcf, 0x5c, 0xa1, 0xef, 0x56,
-	0x41, 0x09, 0x70, 0x86, 0x8d, 0xbf, 0x24, 0xa8, 0x71, 0x35, 0x42, 0xa0, 0xcc, 0xc9, 0x7c, 0xc1,
-	0x43, 0xa2, 0xdf, 0xe8, 0x08, 0x2a, 0x77, 0x78, 0xb6, 0x24, 0x3c, 0x16, 0x76, 0xd8, 0x2d, 0x9e,
-	0xbc, 0xa7, 0x78, 0x9b, 0x12, 0x29, 0xc5, 0x12, 0xe5, 0xce, 0x1f, 0xf1, 0x6c, 0x76, 0x8b, 0xfd,
-	0x9f, 0xa6, 0x38, 0x08, 0x12, 0xbd, 0x42, 0xa1, 0x9b, 0x42, 0x68, 0x06, 0x41, 0xc2, 0x3b, 0x2b,
-	0x0b, 0x23, 0x8a, 0xa7, 0x57, 0xd7, 0x9d, 0x25, 0x44, 0xc6, 0x77, 0x70, 0xb8, 0xae, 0xf4, 0x3a,
-	0xfe, 0xfa, 0x2d, 0x13, 0xa5, 0xba, 0x74, 0x2a, 0x6f, 0x12, 0x20, 0x0c, 0xd7, 0x6a, 0xe3, 0x77,
-	0x09, 0x8e, 0x77, 0xd2, 0xc8, 0x1a, 0xa6, 0xd0, 0x74, 0x52, 0xb9, 0xe9, 0xd6, 0x05, 0x3c, 0x78,
-	0xb8, 0x80, 0xf2, 0x23, 0x86, 0x49, 0x29, 0x0e, 0x93, 0xf1, 0x9b, 0x04, 0xc8, 0x4a, 0xb3, 0x70,
-	0x8e, 0x33, 0x72, 0x49, 0xc8, 0x7f, 0x33, 0xc1, 0x85, 0x60, 0x95, 0x52, 0xb0, 0xc6, 0x05, 0x3c,
-	0x2f, 0xb1, 0xe1, 0x39, 0x7e, 0x09, 0x2a, 0x45, 0x9c, 0x7e, 0x24, 0xa2, 0xf9, 0xeb, 0x54, 0x70,
-	0x49, 0x08, 0x0d, 0x61, 0x42, 0xa2, 0x60, 0x8c, 0x57, 0x73, 0x12, 0x65, 0xff, 0x77, 0x08, 0x6f,
-	0x01, 0x71, 0x26, 0xdd, 0x95, 0xdd, 0x17, 0x6c, 0x5e, 0x03, 0xc4, 0x4c, 0x3a, 0x0d, 0x03, 0x31,
-	0xbf, 0x5c, 0x62, 0x07, 0xc6, 0x3b, 0xd0, 0xb9, 0x53, 0xda, 0x5d, 0x3d, 0xb6, 0x35, 0x8c, 0x4b,
-	0x38, 0xd9, 0xe3, 0xb5, 0xe9, 0x4b, 0x8e, 0xbf, 0xd5, 0x97, 0x22, 0x4f, 0x6b, 0xb5, 0xf1, 0xb7,
-	0x04, 0xcf, 0x87, 0x61, 0x9a, 0x09, 0x30, 0xf1, 0xe7, 0x6f, 0xa1, 0x9a, 0x66, 0x38, 0x5b, 0xa6,
-	0x3c, 0x87, 0xcf, 0x4b, 0x00, 0x13, 0xaa, 0x72, 0xb9, 0x09, 0x7a, 0x07, 0x6a, 0x10, 0x26, 0xc4,
-	0xa7, 0xa3, 0xc3, 0x12, 0x7a, 0x5c, 0xb2, 0xef, 0x0b, 0xad, 0xbb, 0x31, 0xfc, 0x44, 0xeb, 0xc9,
-	0x84, 0xa3, 0x32, 0xff, 0xa7, 0xe7, 0xe0, 0x9f, 0x03, 0xa8, 0x71, 0xe9, 0x03, 0xc5, 0xca, 0xd5,
-	0xcb, 0x38, 0x9f, 0xe1, 0x60, 0x8a, 0xd9, 0x58, 0xca, 0xae, 0xca, 0x25, 0x66, 0x31, 0x6b, 0xf2,
-	0x13, 0xb3, 0xa6, 0x3c, 0x39, 0x6b, 0x95, 0x7b, 0xb3, 0x56, 0xe8, 0x9a, 0x6a, 0x79, 0xa1, 0x9c,
-	0x00, 0x9b, 0x9d, 0x3c, 0xb6, 0x1a, 0x53, 0xd1, 0xb3, 0x1d, 0x6c, 0x52, 0x5d, 0x7f, 0xc4, 0x40,
-	0xa8, 0xa5, 0x81, 0x28, 0x8d, 0x28, 0x94, 0x47, 0xf4, 0xcc, 0x82, 0x0a, 0x25, 0x87, 0xda, 0x00,
-	0xe6, 0x64, 0x62, 0x79, 0x53, 0x67, 0xe4, 0x58, 0xda, 0x33, 0x54, 0x03, 0xb9, 0xeb, 0xf5, 0x34,
-	0x89, 0x7e, 0xf4, 0x06, 0xda, 0x41, 0xfe, 0x61, 0x79, 0x03, 0x4d, 0xce, 0x3f, 0x86, 0x5e, 0x4f,
-	0x53, 0x50, 0x1d, 0x94, 0xbe, 0x39, 0x19, 0x68, 0x95, 0xb3, 0xf7, 0x50, 0xa1, 0x5c, 0x72, 0x98,
-	0x6b, 0xab, 0x6f, 0x9b, 0x02, 0xa6, 0x0d, 0xd0, 0x1d, 0x8e, 0x7a, 0xdf, 0xf7, 0x06, 0xa6, 0xed,
-	0x68, 0x12, 0x6a, 0x81, 0x3a, 0xb4, 0xaf, 0x06, 0x9e, 0x63, 0x3b, 0x57, 0xda, 0xc1, 0xd9, 0x0d,
-	0xb4, 0x4a, 0xd9, 0x47, 0x87, 0xd0, 0x98, 0x78, 0xa6, 0x77, 0x33, 0x11, 0x00, 0x0d, 0xa8, 0xfd,
-	0x60, 0xda, 0x5e, 0x6e, 0x2e, 0xe5, 0x87, 0xb1, 0xe5, 0xf4, 0xa9, 0x6f, 0x0e, 0xd5, 0x1b, 0x5d,
-	0x8f, 0x87, 0x96, 0x67, 0xf5, 0x35, 0x19, 0x01, 0x54, 0x2f, 0x4d, 0x7b, 0x68, 0xf5, 0x35, 0xe5,
-	0x6c, 0x0c, 0xda, 0x76, 0x91, 0x10, 0x82, 0x76, 0xdf, 0x76, 0xad, 0x9e, 0x67, 0x8f, 0x1c, 0x01,
-	0xde, 0x84, 0xba, 0xed, 0xf4, 0x46, 0xd7, 0x0c, 0xbd, 0x09, 0xf5, 0xd1, 0x8d, 0x77, 0x35, 0x62,
-	0xf0, 0x54, 0xe7, 0x59, 0xae, 0x63, 0x0e, 0x35, 0xf9, 0xe2, 0x4f, 0x05, 0xd4, 0x31, 0x5e, 0x4d,
-	0x48, 0x72, 0x47, 0x12, 0x34, 0x80, 0x56, 0xe9, 0x19, 0x83, 0x3a, 0xac, 0x1e, 0xfb, 0xde, 0x5c,
-	0x9d, 0x97, 0x7b, 0x75, 0x7c, 0x0e, 0x1c, 0x38, 0xdc, 0xba, 0x77, 0xd0, 0x2b, 0x66, 0xbf, 0xff,
-	0x3a, 0xea, 0xbc, 0xbe, 0x47, 0xcb, 0xf1, 0xde, 0x6f, 0xde, 0x25, 0x47, 0xe5, 0xcb, 0x8e, 0xfb,
-	0xbf, 0xd8, 0x92, 0x72, 0xbf, 0x2e, 0x34, 0x0a, 0xeb, 0x1d, 0xe9, 0xcc, 0x6a, 0xf7, 0xfe, 0xe9,
-	0x9c, 0xec, 0xd1, 0xac, 0xff, 0xdd, 0x28, 0x6c, 0x7b, 0x81, 0xb1, 0x7b, 0x01, 0x74, 0xca, 0xa3,
-	0x9e, 0xfb, 0x15, 0xf6, 0xb2, 0xf0, 0xdb, 0x5d, 0xd5, 0xdb, 0x7e, 0x1e, 0x7c, 0xb6, 0xb3, 0x64,
-	0xd1, 0x17, 0x25, 0x9b, 0x9d, 0x9d, 0xdd, 0xf9, 0xf2, 0x5e, 0x3d, 0x8f, 0xc2, 0x82, 0x66, 0x71,
-	0x63, 0x21, 0x1e, 0xf0, 0x9e, 0x2d, 0xdc, 0xe9, 0xec, 0x53, 0x31, 0x98, 0xdb, 0x2a, 0x7d, 0xb0,
-	0xbf, 0xfd, 0x37, 0x00, 0x00, 0xff, 0xff, 0xeb, 0x5c, 0x5a, 0xf4, 0xbd, 0x0b, 0x00, 0x00,
+	// 1741 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xdb, 0x6e, 0xe3, 0xc6,
+	0x19, 0x0e, 0x4d, 0x9d, 0xf8, 0xcb, 0x92, 0xb8, 0xb3, 0x3e, 0xd0, 0xda, 0x6c, 0xd6, 0x61, 0x92,
+	0x62, 0xb3, 0x45, 0x16, 0x85, 0x93, 0xe6, 0xa2, 0x2d, 0x8a, 0xc8, 0x92, 0xbc, 0x52, 0xa3, 0x95,
+	0x04, 0x4a, 0xce, 0x16, 0xbd, 0x11, 0xc6, 0xd4, 0x38, 0x26, 0x96, 0x22, 0x59, 0x72, 0xb4, 0x6b,
+	0xf7, 0x19, 0x5a, 0xb4, 0xb7, 0xbd, 0xe9, 0x6b, 0xf4, 0xa2, 0x68, 0x5f, 0xa4, 0x0f, 0xd0, 0xd7,
+	0x28, 0xe6, 0x24, 0x91, 0x3a, 0xd4, 0x16, 0x10, 0x34, 0x77, 0x9c, 0xef, 0x3f, 0x70, 0xfe, 0xf3,
+	0x4f, 0x82, 0x11, 0x47, 0xee, 0xcb, 0x28, 0x0e, 0x69, 0x88, 0x72, 0x6e, 0x1c, 0xb9, 0x76, 0x15,
+	0xf6, 0xdb, 0xb3, 0x88, 0xde, 0x39, 0xe4, 0xf7, 0x73, 0x92, 0x50, 0xbb, 0x06, 0x15, 0x79, 0x4e,
+	0xa2, 0x30, 0x48, 0x88, 0xfd, 0xa7, 0x3d, 0x38, 0x68, 0xc6, 0x04, 0x53, 0xe2, 0x10, 0x97, 0x78,
+	0x11, 0x95, 0x9c, 0xe8, 0x63, 0xc8, 0xe3, 0x24, 0x21, 0xd4, 0xd2, 0x4e, 0xb5, 0xe7, 0xd5, 0xb3,
+	0xf2, 0x4b, 0xa6, 0xef, 0x65, 0x83, 0x41, 0x8e, 0xa0, 0x30, 0x96, 0x19, 0x99, 0x7a, 0xd8, 0xda,
+	0x4b, 0xb3, 0xbc, 0x66, 0x90, 0x23, 0x28, 0xe8, 0x08, 0x0a, 0x78, 0x16, 0xce, 0x03, 0x6a, 0xe9,
+	0xa7, 0xda, 0x73, 0xc3, 0x91, 0x27, 0x74, 0x0a, 0xe5, 0x29, 0x49, 0xdc, 0xd8, 0x8b, 0xa8, 0x17,
+	0x06, 0x56, 0x8e, 0x13, 0xd3, 0x10, 0x7a, 0x06, 0xe5, 0x38, 0x9c, 0x53, 0x32, 0xb9, 0xf1, 0x02,
+	0x9a, 0x58, 0xf9, 0x53, 0xed, 0x79, 0xc9, 0x01, 0x0e, 0x75, 0x18, 0x82, 0x3e, 0x07, 0xf3, 0x1a,
+	0xfb, 0xfe, 0x15, 0x76, 0xdf, 0x4e, 0xf0, 0x74, 0x1a, 0x93, 0x24, 0xb1, 0x0a, 0x9c, 0xab, 0xa6,
+	0xf0, 0x86, 0x80, 0x19, 0x6b, 0x4a, 0xf5, 0xe4, 0x06, 0x27, 0x37, 0x56, 0x51, 0xb0, 0xa6, 0xf0,
+	0x0e, 0x4e, 0x6e, 0xec, 0xbf, 0x6a, 0x70, 0xb8, 0xe2, 0x0f, 0xe1, 0x29, 0xf4, 0x09, 0x54, 0x5c,
+	0x46, 0x60, 0x1a, 0xa6, 0x98, 0x12, 0xee, 0x18, 0xdd, 0xd9, 0x57, 0x60, 0x0b, 0x53, 0x82, 0x2c,
+	0x28, 0xc6, 0x42, 0x8e, 0x3b, 0xc5, 0x70, 0xd4, 0x91, 0x79, 0x82, 0xdc, 0x46, 0x5e, 0x7c, 0xc7,
+	0x3d, 0xa1, 0x3b, 0xf2, 0xb4, 0xd1, 0x0c, 0xe1, 0x8e, 0x55, 0x33, 0xec, 0xef, 0xa0, 0x7a, 0x8e,
+	0x7d, 0x1c, 0xb8, 0xe4, 0x07, 0x0d, 0x92, 0xfd, 0x4f, 0x0d, 0x8a, 0x52, 0x31, 0xfa, 0x10, 0x0c,
+	0xfc, 0x0e, 0x7b, 0x3e, 0xbe, 0xf2, 0x85, 0x85, 0x86, 0xb3, 0x04, 0x98, 0x79, 0x11, 0x09, 0xa6,
+	0x5e, 0xf0, 0xbd, 0x32, 0x4f, 0x1e, 0x97, 0x37, 0xd1, 0xef, 0xbf, 0x49, 0x6e, 0x6b, 0xba, 0x7c,
+	0x05, 0x86, 0xef, 0x7d, 0x7f, 0x43, 0x03, 0xf6, 0x06, 0x16, 0xf2, 0xf2, 0xd9, 0x91, 0x60, 0xeb,
+	0x29, 0x58, 0x79, 0x60, 0xc9, 0x68, 0xff, 0x5b, 0x03, 0x73, 0x95, 0xce, 0xfc, 0xfa, 0x1e, 0xfb,
+	0x3e, 0xa1, 0x13, 0x37, 0x0c, 0xae, 0xbd, 0x78, 0x46, 0xa6, 0xd2, 0x9e, 0x9a, 0xc0, 0x9b, 0x0a,
+	0x46, 0x5f, 0x00, 0x92, 0xac, 0xf3, 0x60, 0xc9, 0x2c, 0x0c, 0x7c, 0x24, 0x28, 0x97, 0x4b, 0x02,
+	0xfa, 0x0c, 0xaa, 0xee, 0x0d, 0x0e, 0x02, 0xe2, 0x27, 0x13, 0x3f, 0x74, 0xb1, 0x2f, 0x73, 0xbb,
+	0xa2, 0xd0, 0x1e, 0x03, 0xd1, 0xc7, 0xb0, 0x2f, 0x9d, 0x33, 0x09, 0x23, 0xb2, 0xc8, 0x71, 0x89,
+	0x0d, 0x22, 0x12, 0xb0, 0x94, 0x52, 0x2c, 0xae, 0x1f, 0x26, 0x84, 0x9b, 0x6c, 0x38, 0x4a, 0xae,
+	0xc9, 0x30, 0xbb, 0x07, 0xc7, 0xdf, 0x61, 0xdf, 0x9b, 0x6e, 0x48, 0xc9, 0xcf, 0xa1, 0xe8, 0x05,
+	0xef, 0x42, 0xcf, 0x15, 0xa1, 0x2a, 0x9f, 0x55, 0x84, 0xb3, 0xba, 0x02, 0xec, 0x7c, 0xe0, 0x28,
+	0xfa, 0x79, 0x01, 0x72, 0x53, 0x4c, 0xb1, 0xfd, 0x77, 0x0d, 0x8a, 0x92, 0x8c, 0x10, 0xe4, 0x66,
+	0x64, 0x16, 0x4a, 0xb7, 0xf0, 0x67, 0x74, 0x00, 0xf9, 0x77, 0xd8, 0x9f, 0x13, 0x69, 0xbe, 0x38,
+	0xac, 0xe7, 0xbe, 0xbe, 0x21, 0xf7, 0x97, 0x19, 0x9e, 0xcb, 0x64, 0xf8, 0x27, 0x50, 0xc9, 0x64,
+	0xb8, 0xb2, 0x32, 0x9d, 0xde, 0xb2, 0x21, 0x50, 0x2f, 0xe0, 0xfa, 0xac, 0xc2, 0xa2, 0x21, 0x28,
+	0xc8, 0xfe, 0x15, 0xd4, 0x16, 0xd9, 0xbf, 0xb0, 0xbf, 0x74, 0x25, 0xa0, 0xc4, 0xd2, 0x4e, 0xf5,
+	0xa5, 0x03, 0x14, 0xe3, 0x82, 0x6c, 0xff, 0x45, 0x83, 0xa3, 0x35, 0x37, 0x8a, 0x22, 0x4a, 0xd5,
+	0xac, 0x96, 0xad, 0xd9, 0x45, 0x52, 0xef, 0xdd, 0x9f, 0xd4, 0xfa, 0x03, 0x7a, 0x60, 0x2e, 0xdd,
+	0x03, 0xed, 0x3f, 0x6a, 0x80, 0xda, 0x09, 0xf5, 0x66, 0x98, 0x92, 0x0b, 0x42, 0xfe, 0x3f, 0x8d,
+	0x37, 0x65, 0x6c, 0x2e, 0x63, 0xac, 0x7d, 0x06, 0x8f, 0x33, 0xb7, 0x91, 0x3e, 0x7e, 0x02, 0x06,
+	0xd7, 0x38, 0xb9, 0x26, 0xaa, 0x21, 0x94, 0x38, 0x70, 0x41, 0x88, 0xfd, 0x1f, 0x0d, 0xd0, 0x88,
+	0x04, 0xd3, 0x21, 0xbe, 0x9b, 0x91, 0x80, 0xfe, 0xc8, 0x26, 0xa0, 0x63, 0x28, 0xce, 0xf0, 0x2d,
+	0xbf, 0xa9, 0xc8, 0xb1, 0xc2, 0x0c, 0xdf, 0x5e, 0x10, 0x82, 0x7e, 0x02, 0x35, 0x49, 0x98, 0x44,
+	0x24, 0x76, 0x49, 0x40, 0x79, 0x86, 0xe9, 0x4e, 0x45, 0x30, 0x0c, 0x05, 0xc8, 0x54, 0x53, 0x6f,
+	0x46, 0xc2, 0x39, 0xe5, 0xf3, 0x41, 0x77, 0xd4, 0xd1, 0xfe, 0x12, 0x90, 0x34, 0xf2, 0xfc, 0xae,
+	0xdb, 0x52, 0x86, 0x3e, 0x05, 0x88, 0x04, 0x3a, 0xf1, 0x54, 0x7b, 0x31, 0x24, 0xd2, 0x9d, 0xda,
+	0x5f, 0x81, 0x25, 0x85, 0x92, 0xf3, 0xbb, 0x87, 0x66, 0x9d, 0x7d, 0x01, 0x27, 0x1b, 0xa4, 0x96,
+	0x29, 0x2f, 0xf5, 0xaf, 0xa4, 0xbc, 0x0a, 0xc1, 0x82, 0x6c, 0xff, 0x4b, 0x83, 0xc7, 0x3d, 0x2f,
+	0xa1, 0x4a, 0x99, 0x7a, 0xf3, 0x4f, 0xa1, 0x90, 0x50, 0x4c, 0xe7, 0x89, 0x0c, 0xcf, 0xe3, 0x8c,
+	0x82, 0x11, 0x27, 0x39, 0x92, 0x85, 0x75, 0xe4, 0xa9, 0x17, 0x13, 0x97, 0x57, 0xa5, 0x88, 0xd5,
+	0x51, 0x86, 0xbf, 0xa5, 0xa8, 0xce, 0x92, 0xf1, 0x87, 0x99, 0x06, 0x76, 0x03, 0x0e, 0xb2, 0xf7,
+	0xdf, 0xdd, 0x07, 0x7f, 0xce, 0x43, 0x51, 0xa2, 0xf7, 0x04, 0x8b, 0x91, 0xe7, 0x11, 0x6b, 0x0f,
+	0xd3, 0x09, 0x16, 0x15, 0xaf, 0x3b, 0x86, 0x44, 0x1a, 0x69, 0xaf, 0xe9, 0x3b, 0x7a, 0x2d, 0xb7,
+	0xb3, 0xd7, 0xf2, 0x5b, 0xbd, 0x96, 0xca, 0x9a, 0x42, 0x36, 0xf7, 0x4f, 0x40, 0x94, 0x25, 0xb3,
+	0xad, 0x28, 0x48, 0xfc, 0xdc, 0x9d, 0x2e, 0x5d, 0x5d, 0x7a, 0x40, 0xad, 0x19, 0x99, 0x5a, 0xcb,
+	0x54, 0x3f, 0x64, 0xab, 0x1f, 0xfd, 0x0c, 0x4a, 0x98, 0x52, 0x32, 0x8b, 0x68, 0x62, 0x95, 0x79,
+	0x1c, 0x0e, 0x32, 0x46, 0x36, 0x04, 0xd1, 0x59, 0x70, 0xb1, 0xd1, 0x79, 0x8d, 0x3d, 0x7f, 0x1e,
+	0x93, 0x49, 0x4c, 0x70, 0x12, 0x06, 0xd6, 0xbe, 0x18, 0x9d, 0x12, 0x75, 0x38, 0x88, 0xbe, 0x86,
+	0xe3, 0x98, 0x44, 0x3e, 0x76, 0x09, 0x8f, 0xd6, 0x32, 0x6a, 0x89, 0x55, 0x39, 0xd5, 0x9f, 0x1b,
+	0xce, 0x61, 0x8a, 0x3c, 0x54, 0x11, 0x4c, 0xd8, 0x40, 0x8b, 0x92, 0x2b, 0x6a, 0x55, 0xc5, 0x40,
+	0x63, 0xcf, 0xa8, 0x0e, 0x25, 0x72, 0x4b, 0x49, 0x1c, 0x60, 0xdf, 0xaa, 0xf1, 0x9d, 0x6f, 0x71,
+	0x46, 0x9f, 0x42, 0x45, 0x8e, 0x75, 0x3e, 0x62, 0x12, 0xcb, 0x14, 0x4d, 0x21, 0x03, 0xb2, 0xf5,
+	0x20, 0x03, 0x4c, 0x7c, 0x72, 0x4d, 0xad, 0x47, 0x9c, 0xf5, 0x51, 0x86, 0xd2, 0x23, 0xd7, 0x3c,
+	0xcd, 0xae, 0xfc, 0xd0, 0x7d, 0x2b, 0xd6, 0x4c, 0x24, 0xd2, 0x8c, 0x23, 0x7c, 0xc1, 0x1c, 0xa9,
+	0x7d, 0x7b, 0x88, 0xef, 0x7a, 0x5e, 0xf0, 0x76, 0x87, 0x9e, 0x69, 0x41, 0x11, 0xbb, 0x2e, 0x8f,
+	0x92, 0xdc, 0xbe, 0xe4, 0xd1, 0xfe, 0x02, 0x0e, 0x57, 0x94, 0xca, 0x52, 0x39, 0x80, 0xbc, 0x1f,
+	0xcc, 0x63, 0x5f, 0xa6, 0xbb, 0x38, 0xd8, 0x7f, 0xd3, 0xe0, 0x44, 0xf0, 0xbf, 0xf1, 0xe8, 0xcd,
+	0x34, 0xc6, 0xef, 0x77, 0xbc, 0xc9, 0x53, 0x80, 0x99, 0x17, 0x4c, 0xf0, 0x2c, 0x75, 0x19, 0x63,
+	0xe6, 0x05, 0x0d, 0x0e, 0x70, 0x32, 0xbe, 0x9d, 0x64, 0xba, 0xb7, 0x31, 0xc3, 0xb7, 0x8d, 0x07,
+	0x2e, 0xff, 0xf6, 0x6f, 0xa0, 0xbe, 0xe9, 0x7e, 0xff, 0xcb, 0xa8, 0xd4, 0xfa, 0xb1, 0x97, 0x5e,
+	0x3f, 0xec, 0x26, 0x7c, 0xb4, 0x58, 0x0e, 0x5b, 0x24, 0x0a, 0x13, 0x8f, 0xca, 0x85, 0xfa, 0xe1,
+	0x06, 0xdb, 0xbf, 0x84, 0x67, 0x5b, 0x95, 0xc8, 0x5b, 0xb1, 0xe8, 0x08, 0x48, 0x35, 0x74, 0x79,
+	0xb4, 0xc7, 0x70, 0x2c, 0x65, 0x16, 0x3a, 0x76, 0xf0, 0xf5, 0xb2, 0x34, 0xf7, 0x32, 0xeb, 0xc3,
+	0xb7, 0x70, 0x74, 0xe1, 0x05, 0xd8, 0xf7, 0xfe, 0x40, 0x56, 0xc6, 0xef, 0x3d, 0x8d, 0x4e, 0x55,
+	0xc9, 0xde, 0xb2, 0x4a, 0xec, 0x9f, 0xc3, 0x41, 0x13, 0x07, 0x2e, 0xf1, 0x77, 0x52, 0x65, 0x3b,
+	0x70, 0x38, 0x22, 0x74, 0xf4, 0x9e, 0x90, 0x68, 0x88, 0xe7, 0x09, 0x99, 0xee, 0x66, 0x57, 0xc4,
+	0x65, 0xf8, 0x45, 0x4a, 0x8e, 0x3c, 0xd9, 0x5d, 0xa8, 0x66, 0xfb, 0xc7, 0xb2, 0x7f, 0x69, 0x5b,
+	0xfb, 0xd7, 0x01, 0xe4, 0x49, 0x1c, 0x87, 0xb1, 0x5a, 0x5b, 0xf9, 0xe1, 0xc5, 0x6f, 0x21, 0xcf,
+	0x5f, 0x89, 0xaa, 0x00, 0x8d, 0xd1, 0xa8, 0x3d, 0x9e, 0xf4, 0x07, 0xfd, 0xb6, 0xf9, 0x01, 0x2a,
+	0x82, 0x7e, 0x3e, 0x6e, 0x9a, 0x1a, 0x7f, 0x68, 0x76, 0xcc, 0x3d, 0xf6, 0xd0, 0x1e, 0x77, 0x4c,
+	0x9d, 0x3d, 0xf4, 0xc6, 0x4d, 0x33, 0x87, 0x4a, 0x90, 0x6b, 0x35, 0x46, 0x1d, 0x33, 0xcf, 0x9f,
+	0x06, 0xaf, 0xda, 0x66, 0x81, 0x11, 0x7f, 0xd7, 0x6e, 0x9a, 0xc5, 0x17, 0xdf, 0x40, 0x9e, 0xbf,
+	0x9f, 0x69, 0x7e, 0xdd, 0x6e, 0x75, 0x1b, 0x4a, 0x73, 0x15, 0xe0, 0xbc, 0x37, 0x68, 0x7e, 0xdb,
+	0xec, 0x34, 0xba, 0x7d, 0x53, 0x43, 0x15, 0x30, 0x7a, 0xdd, 0x57, 0x9d, 0x71, 0xbf, 0xdb, 0x7f,
+	0x65, 0xee, 0x31, 0x55, 0x8d, 0xcb, 0xf1, 0xc0, 0xd4, 0x5f, 0x5c, 0x42, 0x25, 0x33, 0x3b, 0x50,
+	0x0d, 0xca, 0xa3, 0x71, 0x63, 0x7c, 0x39, 0x52, 0xaa, 0xca, 0x50, 0x7c, 0xd3, 0xe8, 0x8e, 0x99,
+	0xa0, 0xc6, 0x0e, 0xc3, 0x76, 0xbf, 0x25, 0xb4, 0x54, 0xc0, 0x68, 0x0e, 0x5e, 0x0f, 0x7b, 0xed,
+	0x71, 0xbb, 0x65, 0xea, 0x08, 0xa0, 0x70, 0xd1, 0xe8, 0xf6, 0xda, 0x2d, 0x33, 0xf7, 0x62, 0x08,
+	0xe6, 0xea, 0x88, 0x41, 0x08, 0xaa, 0xad, 0xae, 0xd3, 0x6e, 0x8e, 0xbb, 0x83, 0xbe, 0x52, 0xbe,
+	0x0f, 0xa5, 0x6e, 0xbf, 0x39, 0x78, 0x2d, 0xb4, 0xef, 0x43, 0x69, 0x70, 0x39, 0x7e, 0x35, 0x10,
+	0xea, 0x39, 0x6d, 0xdc, 0x76, 0xfa, 0x8d, 0x9e, 0xa9, 0x9f, 0xfd, 0xa3, 0x04, 0xc6, 0x10, 0xdf,
+	0x8d, 0x48, 0xfc, 0x8e, 0xc4, 0xa8, 0x03, 0x95, 0xcc, 0xe7, 0x31, 0xaa, 0x8b, 0x68, 0x6c, 0xfa,
+	0x87, 0x50, 0x7f, 0xb2, 0x91, 0x26, 0xeb, 0xa5, 0x0f, 0xb5, 0x95, 0x85, 0x1c, 0x7d, 0x28, 0xf8,
+	0x37, 0xef, 0xe9, 0xf5, 0xa7, 0x5b, 0xa8, 0x52, 0xdf, 0xd7, 0xcb, 0x8f, 0xd8, 0x83, 0xec, 0x57,
+	0x80, 0x94, 0x3f, 0x5c, 0x41, 0xa5, 0xdc, 0x39, 0x94, 0x53, 0x7b, 0x2f, 0xb2, 0x04, 0xd7, 0xfa,
+	0x62, 0x5e, 0x3f, 0xd9, 0x40, 0x59, 0xbc, 0xbb, 0x9c, 0x5a, 0x83, 0x95, 0x8e, 0xf5, 0xcd, 0xb8,
+	0x9e, 0x5d, 0x54, 0x98, 0x5c, 0x6a, 0xab, 0x54, 0x72, 0xeb, 0x8b, 0xe6, 0xaa, 0xdc, 0x18, 0x1e,
+	0xad, 0xad, 0x88, 0xe8, 0xa3, 0x0c, 0xcf, 0xda, 0xc6, 0x59, 0x7f, 0xb6, 0x95, 0x2e, 0xad, 0x68,
+	0xc3, 0x7e, 0x7a, 0xdf, 0x42, 0x27, 0xea, 0xd3, 0x7b, 0x6d, 0x87, 0xac, 0xd7, 0x37, 0x91, 0xa4,
+	0x9a, 0x45, 0x8a, 0xc8, 0x61, 0x94, 0x4d, 0x91, 0xec, 0xd8, 0xab, 0x3f, 0xd9, 0x48, 0x93, 0x9a,
+	0xde, 0x00, 0x5a, 0x1f, 0x03, 0xe8, 0x59, 0x5a, 0x64, 0xc3, 0x00, 0xab, 0x9f, 0x6e, 0x67, 0x90,
+	0x8a, 0xaf, 0xe1, 0x78, 0x4b, 0x3b, 0x47, 0x9f, 0xae, 0xfc, 0x6f, 0xd8, 0x38, 0x32, 0xea, 0x9f,
+	0xdd, 0xc3, 0x25, 0xdf, 0xf3, 0x0d, 0x98, 0xab, 0x9d, 0x1f, 0xc9, 0x34, 0xde, 0x32, 0x11, 0x56,
+	0x23, 0xfd, 0x6b, 0xa8, 0xad, 0x74, 0x79, 0x55, 0x25, 0x9b, 0x9b, 0xff, 0xaa, 0xfc, 0x2f, 0xa0,
+	0x92, 0x69, 0xec, 0x8b, 0x60, 0x6c, 0xe8, 0xf6, 0xab, 0xb2, 0xe7, 0x50, 0xcd, 0x76, 0x77, 0xf4,
+	0x44, 0x25, 0xf6, 0x86, 0x9e, 0x5f, 0x97, 0x1b, 0x71, 0xe6, 0xff, 0xe2, 0x55, 0x81, 0xff, 0x8d,
+	0xfc, 0xf2, 0xbf, 0x03, 0x00, 0xa4, 0xf5, 0x65, 0x22, 0x9a, 0x14, 0x00, 0x00,
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/bitlum/connector/metrics"
 	"encoding/hex"
 	"github.com/shopspring/decimal"
+	"github.com/bitlum/connector/lnurl"
+	"github.com/bitlum/connector/sweeper"
 )
 
 // defaultAccount default account which will be used for all request until
@@ -15,14 +17,21 @@ import (
 var defaultAccount = "zigzag"
 
 const (
-	CreateReceiptReq     = "CreateReceipt"
-	ValidateReceiptReq   = "ValidateReceipt"
-	BalanceReq           = "Balance"
-	EstimateFeeReq       = "EstimateFee"
-	SendPaymentReq       = "SendPayment"
-	PaymentByIDReq       = "PaymentByID"
-	PaymentsByReceiptReq = "PaymentsByReceipt"
-	ListPaymentsReq      = "ListPayments"
+	CreateReceiptReq           = "CreateReceipt"
+	ValidateReceiptReq         = "ValidateReceipt"
+	BalanceReq                 = "Balance"
+	EstimateFeeReq             = "EstimateFee"
+	SendPaymentReq             = "SendPayment"
+	PaymentByIDReq             = "PaymentByID"
+	PaymentsByReceiptReq       = "PaymentsByReceipt"
+	ListPaymentsReq            = "ListPayments"
+	CreatePayLinkReq           = "CreatePayLink"
+	CreateWithdrawLinkReq      = "CreateWithdrawLink"
+	LightningDepositAddressReq = "LightningDepositAddress"
+	DepositLightningReq        = "DepositLightning"
+	FinalizePaymentReq         = "FinalizePayment"
+	CancelPaymentReq           = "CancelPayment"
+	SetSweepPausedReq          = "SetSweepPaused"
 )
 
 // Server is the gRPC server which implements PayServer interface.
@@ -31,7 +40,15 @@ type Server struct {
 	blockchainConnectors map[connectors.Asset]connectors.BlockchainConnector
 	lightningConnectors  map[connectors.Asset]connectors.LightningConnector
 	paymentsStore        connectors.PaymentsStore
+	receiptsStore        connectors.ReceiptsStore
 	metrics              rpc.MetricsBackend
+
+	// lnurlServer is used to create lnurl links, nil if lnurl is disabled.
+	lnurlServer *lnurl.Server
+
+	// sweepers are used to sweep hot wallet funds in the cold wallet, only
+	// assets with configured sweeping are present.
+	sweepers map[connectors.Asset]*sweeper.Sweeper
 }
 
 // A compile time check to ensure that Server fully implements the
@@ -43,13 +60,19 @@ func NewRPCServer(net string,
 	blockchainConnectors map[connectors.Asset]connectors.BlockchainConnector,
 	lightningConnectors map[connectors.Asset]connectors.LightningConnector,
 	paymentsStore connectors.PaymentsStore,
-	metrics rpc.MetricsBackend) (*Server, error) {
+	receiptsStore connectors.ReceiptsStore,
+	metrics rpc.MetricsBackend,
+	lnurlServer *lnurl.Server,
+	sweepers map[connectors.Asset]*sweeper.Sweeper) (*Server, error) {
 	return &Server{
 		blockchainConnectors: blockchainConnectors,
 		lightningConnectors:  lightningConnectors,
 		paymentsStore:        paymentsStore,
+		receiptsStore:        receiptsStore,
 		metrics:              metrics,
 		net:                  net,
+		lnurlServer:          lnurlServer,
+		sweepers:             sweepers,
 	}, nil
 }
 
@@ -102,8 +125,34 @@ func (s *Server) CreateReceipt(ctx context.Context,
 			req.Amount = "0"
 		}
 
+		opts := &connectors.InvoiceOptions{
+			PrivateRouteHints: req.RouteHints,
+			DescriptionHash:   req.DescriptionHash,
+		}
+
+		if req.FallbackAddress {
+			bc, ok := s.blockchainConnectors[connectors.Asset(req.Asset.String())]
+			if !ok {
+				err := newErrAssetNotSupported(req.Asset.String(),
+					Media_BLOCKCHAIN.String())
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(CreateReceiptReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+
+			address, err := bc.CreateAddress(connectors.AccountAlias(defaultAccount))
+			if err != nil {
+				err := newErrInternal(err.Error())
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(CreateReceiptReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+
+			opts.FallbackAddress = address
+		}
+
 		paymentRequest, invoice, err := c.CreateInvoice(defaultAccount,
-			req.Amount, req.Description)
+			req.Amount, req.Description, opts)
 		if err != nil {
 			err := newErrInternal(err.Error())
 			log.Errorf("command(%v), error: %v", getFunctionName(), err)
@@ -111,10 +160,24 @@ func (s *Server) CreateReceipt(ctx context.Context,
 			return nil, err
 		}
 
+		// Link fallback address to the invoice, so that on-chain payment
+		// could be found by the invoice.
+		if opts.FallbackAddress != "" {
+			err := s.receiptsStore.LinkReceipt(paymentRequest,
+				opts.FallbackAddress)
+			if err != nil {
+				err := newErrInternal(err.Error())
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(CreateReceiptReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+		}
+
 		resp = &CreateReceiptResponse{
-			CreationDate: connectors.ConvertTimeToMilliSeconds(invoice.Timestamp),
-			Expiry:       connectors.ConvertDurationToMilliSeconds(invoice.Expiry()),
-			Receipt:      paymentRequest,
+			CreationDate:    connectors.ConvertTimeToMilliSeconds(invoice.Timestamp),
+			Expiry:          connectors.ConvertDurationToMilliSeconds(invoice.Expiry()),
+			Receipt:         paymentRequest,
+			FallbackAddress: opts.FallbackAddress,
 		}
 
 	default:
@@ -167,6 +230,74 @@ func (s *Server) ValidateReceipt(ctx context.Context,
 			req.Amount = "0"
 		}
 
+		// Lnurl-pay receipt is validated by requesting pay parameters from
+		// the service, invoice is not requested to not create it without
+		// the actual payment.
+		if lnurl.IsLNURL(req.Receipt) {
+			params, err := lnurl.FetchPayParams(req.Receipt)
+			if err != nil {
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(ValidateReceiptReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+
+			amount, err := decimal.NewFromString(req.Amount)
+			if err != nil {
+				err := newErrInvalidArgument("amount")
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(ValidateReceiptReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+
+			if !amount.Equal(decimal.Zero) {
+				amountMsat := lnurl.BtcToMsat(amount)
+				if amountMsat < params.MinSendable ||
+					amountMsat > params.MaxSendable {
+					err := errors.Errorf("amount(%v) is out of the "+
+						"allowed range [%v, %v]", amount.Round(8),
+						lnurl.MsatToBtc(params.MinSendable).Round(8),
+						lnurl.MsatToBtc(params.MaxSendable).Round(8))
+					log.Errorf("command(%v), error: %v", getFunctionName(), err)
+					s.metrics.AddError(ValidateReceiptReq, string(metrics.LowSeverity))
+					return nil, err
+				}
+			}
+
+			data = &ValidateReceiptResponse_Invoice{
+				Invoice: &Invoice{
+					Memo:  params.Description(),
+					Value: amount.Round(8).String(),
+				},
+			}
+			break
+		}
+
+		// Spontaneous (keysend) payments are made directly to the
+		// lightning network node, and receipt is its public key.
+		if isNodePubKey(req.Receipt) {
+			if err := c.ValidatePubKey(req.Receipt, req.Amount); err != nil {
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(ValidateReceiptReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+
+			amount, err := decimal.NewFromString(req.Amount)
+			if err != nil {
+				err := newErrInvalidArgument("amount")
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(ValidateReceiptReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+
+			data = &ValidateReceiptResponse_Invoice{
+				Invoice: &Invoice{
+					Value:       amount.Round(8).String(),
+					Destination: req.Receipt,
+				},
+			}
+			break
+		}
+
 		invoice, err := c.ValidateInvoice(req.Receipt, req.Amount)
 		if err != nil {
 			log.Errorf("command(%v), error: %v", getFunctionName(), err)
@@ -309,11 +440,6 @@ func (s *Server) Balance(ctx context.Context, req *BalanceRequest,
 		}
 
 		for asset, c := range cntrs {
-			// TODO(andrew.shvv) Show channels balance, problems:
-			// * if we don't have distinction between off-chain and on-chain
-			// balance it will be unclear for end user how use this balance,
-			// otherwise we would ned to have two different rpc methods for that.
-
 			available, err := c.ConfirmedBalance(defaultAccount)
 			if err != nil {
 				err := newErrInternal(err.Error())
@@ -338,11 +464,30 @@ func (s *Server) Balance(ctx context.Context, req *BalanceRequest,
 				return nil, err
 			}
 
+			// Available and pending balances are the ones of lnd on-chain
+			// wallet, funds of the channels are shown separately,
+			// because only they could be used for sending payments in
+			// lightning network.
+			details, err := c.BalanceDetails()
+			if err != nil {
+				err := newErrInternal(err.Error())
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(BalanceReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+
 			resp.Balances = append(resp.Balances, &Balance{
 				Media:     Media_LIGHTNING,
 				Asset:     protoAsset,
 				Available: available.String(),
 				Pending:   pending.String(),
+				Lightning: &LightningBalance{
+					WalletConfirmed:   details.WalletConfirmed.String(),
+					WalletUnconfirmed: details.WalletUnconfirmed.String(),
+					ChannelsLocal:     details.ChannelsLocal.String(),
+					PendingOpen:       details.PendingOpen.String(),
+					PendingClose:      details.PendingClose.String(),
+				},
 			})
 		}
 	}
@@ -428,9 +573,10 @@ func (s *Server) SendPayment(ctx context.Context,
 	log.Tracef("command(%v), request(%v)", getFunctionName(), convertProtoMessage(req))
 
 	var (
-		resp    *Payment
-		payment *connectors.Payment
-		err     error
+		resp     *Payment
+		payment  *connectors.Payment
+		attempts []*PaymentAttempt
+		err      error
 	)
 
 	switch req.Media {
@@ -449,7 +595,8 @@ func (s *Server) SendPayment(ctx context.Context,
 			req.Amount = "0"
 		}
 
-		payment, err = c.CreatePayment(req.Receipt, req.Amount)
+		payment, err = connectors.SendBlockchainPayment(c, req.Receipt,
+			req.Amount, nil)
 		if err != nil {
 			err := newErrInternal(err.Error())
 			log.Errorf("command(%v), error: %v", getFunctionName(), err)
@@ -457,30 +604,59 @@ func (s *Server) SendPayment(ctx context.Context,
 			return nil, err
 		}
 
-		payment, err = c.SendPayment(payment.PaymentID)
+	case Media_LIGHTNING:
+		c, ok := s.lightningConnectors[connectors.Asset(req.Asset.String())]
+		if !ok {
+			err := newErrAssetNotSupported(req.Asset.String(), req.Media.String())
+			log.Errorf("command(%v), error: %v", getFunctionName(), err)
+			s.metrics.AddError(SendPaymentReq, string(metrics.LowSeverity))
+			return nil, err
+		}
+
+		if req.Amount == "" {
+			req.Amount = "0"
+		}
+
+		opts, err := parseSendOptions(req)
 		if err != nil {
-			err := newErrInternal(err.Error())
 			log.Errorf("command(%v), error: %v", getFunctionName(), err)
 			s.metrics.AddError(SendPaymentReq, string(metrics.LowSeverity))
 			return nil, err
 		}
 
-	case Media_LIGHTNING:
-		c, ok := s.lightningConnectors[connectors.Asset(req.Asset.String())]
-		if !ok {
-			err := newErrAssetNotSupported(req.Asset.String(), req.Media.String())
+		// In case of lnurl-pay receipt invoice is requested from the
+		// service, and the payment is made with it.
+		receipt := req.Receipt
+		if lnurl.IsLNURL(receipt) {
+			receipt, err = fetchLNURLInvoice(c, req.Receipt, req.Amount)
+			if err != nil {
+				err := newErrInternal(err.Error())
+				log.Errorf("command(%v), error: %v", getFunctionName(), err)
+				s.metrics.AddError(SendPaymentReq, string(metrics.LowSeverity))
+				return nil, err
+			}
+		}
+
+		payment, err = c.SendTo(receipt, req.Amount, opts)
+		if _, ok := err.(*connectors.ErrFeeLimitExceeded); ok {
+			err := newErrFeeLimitExceeded(err.Error())
+			log.Errorf("command(%v), error: %v", getFunctionName(), err)
+			s.metrics.AddError(SendPaymentReq, string(metrics.LowSeverity))
+			return nil, err
+		} else if err != nil {
+			err := newErrInternal(err.Error())
 			log.Errorf("command(%v), error: %v", getFunctionName(), err)
 			s.metrics.AddError(SendPaymentReq, string(metrics.LowSeverity))
 			return nil, err
 		}
 
+	case Media_AUTO:
 		if req.Amount == "" {
 			req.Amount = "0"
 		}
 
-		payment, err = c.SendTo(req.Receipt, req.Amount)
+		payment, attempts, err = s.sendAutoPayment(req)
 		if err != nil {
-			err := newErrInternal(err.Error())
 			log.Errorf("command(%v), error: %v", getFunctionName(), err)
 			s.metrics.AddError(SendPaymentReq, string(metrics.LowSeverity))
 			return nil, err
@@ -501,12 +677,134 @@ func (s *Server) SendPayment(ctx context.Context,
 		return nil, err
 	}
 
+	resp.Attempts = attempts
+
 	log.Tracef("command(%v), response(%v)", getFunctionName(),
 		convertProtoMessage(resp))
 
 	return resp, nil
 }
 
+// sendAutoPayment sends the payment choosing the media depending on the
+// receipt type. Lightning network invoice with on-chain fallback address is
+// paid on-chain only if lightning network payment has definitely failed.
+// Attempts made to send the payment are returned along with the payment.
+func (s *Server) sendAutoPayment(req *SendPaymentRequest) (*connectors.Payment,
+	[]*PaymentAttempt, error) {
+
+	asset := connectors.Asset(req.Asset.String())
+	lc, lightningOk := s.lightningConnectors[asset]
+	bc, blockchainOk := s.blockchainConnectors[asset]
+
+	isLightning := lnurl.IsLNURL(req.Receipt) || isNodePubKey(req.Receipt) ||
+		isLightningInvoice(req.Receipt)
+
+	if !isLightning {
+		if !blockchainOk {
+			return nil, nil, newErrAssetNotSupported(req.Asset.String(),
+				Media_BLOCKCHAIN.String())
+		}
+
+		if err := bc.ValidateAddress(req.Receipt); err != nil {
+			return nil, nil, newErrInvalidArgument("receipt")
+		}
+
+		payment, err := connectors.SendBlockchainPayment(bc,
+			req.Receipt, req.Amount, nil)
+		if err != nil {
+			return nil, nil, newErrInternal(err.Error())
+		}
+
+		attempts := []*PaymentAttempt{{Media: Media_BLOCKCHAIN}}
+		return payment, attempts, nil
+	}
+
+	if !lightningOk {
+		return nil, nil, newErrAssetNotSupported(req.Asset.String(),
+			Media_LIGHTNING.String())
+	}
+
+	opts, err := parseSendOptions(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt := req.Receipt
+	if lnurl.IsLNURL(receipt) {
+		receipt, err = fetchLNURLInvoice(lc, req.Receipt, req.Amount)
+		if err != nil {
+			return nil, nil, newErrInternal(err.Error())
+		}
+	}
+
+	// Fallback address could be only in the invoice, for that reason
+	// decode it beforehand, so that we would know whether payment might be
+	// sent on-chain if lightning network payment fails.
+	var fallbackAddress, fallbackAmount string
+	if !isNodePubKey(receipt) {
+		invoice, err := lc.ValidateInvoice(receipt, req.Amount)
+		if err != nil {
+			return nil, nil, newErrInvalidArgument("receipt")
+		}
+
+		if invoice.FallbackAddr != nil {
+			fallbackAddress = invoice.FallbackAddr.EncodeAddress()
+
+			fallbackAmount = req.Amount
+			if invoice.MilliSat != nil {
+				fallbackAmount = lnurl.MsatToBtc(int64(*invoice.MilliSat)).String()
+			}
+		}
+	}
+
+	payment, err := lc.SendTo(receipt, req.Amount, opts)
+	if err == nil {
+		attempts := []*PaymentAttempt{{Media: Media_LIGHTNING}}
+		return payment, attempts, nil
+	}
+
+	attempts := []*PaymentAttempt{{
+		Media: Media_LIGHTNING,
+		Error: err.Error(),
+	}}
+
+	// Only if payment has definitely failed we could send it on-chain,
+	// otherwise receiver might be paid twice.
+	var definitelyFailed bool
+	switch err.(type) {
+	case *connectors.ErrFeeLimitExceeded, *connectors.ErrRoutingFailed:
+		definitelyFailed = true
+	}
+
+	if !definitelyFailed || fallbackAddress == "" || !blockchainOk {
+		if _, ok := err.(*connectors.ErrFeeLimitExceeded); ok {
+			return nil, nil, newErrFeeLimitExceeded(err.Error())
+		}
+
+		return nil, nil, newErrInternal(err.Error())
+	}
+
+	log.Infof("Unable to send lightning payment(%v): %v, sending it to "+
+		"fallback address(%v)", receipt, err, fallbackAddress)
+
+	payment, err = connectors.SendBlockchainPayment(bc, fallbackAddress,
+		fallbackAmount, nil)
+	if err != nil {
+		return nil, nil, newErrInternal(err.Error())
+	}
+
+	attempts = append(attempts, &PaymentAttempt{Media: Media_BLOCKCHAIN})
+
+	// Link fallback address to the invoice, so that on-chain payment
+	// could be found by the invoice.
+	if err := s.receiptsStore.LinkReceipt(receipt, fallbackAddress); err != nil {
+		log.Errorf("unable to link fallback address(%v) to the invoice(%v): "+
+			"%v", fallbackAddress, receipt, err)
+	}
+
+	return payment, attempts, nil
+}
+
 //
 // PaymentByID is used to fetch the information about payment, by the
 // given system payment id.
@@ -552,6 +850,28 @@ func (s *Server) PaymentsByReceipt(ctx context.Context,
 		return nil, err
 	}
 
+	// Payments might be received on the receipts linked to the requested
+	// one, for example on the fallback address of lightning invoice.
+	linkedReceipts, err := s.receiptsStore.LinkedReceipts(req.Receipt)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(PaymentsByReceiptReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	for _, receipt := range linkedReceipts {
+		linkedPayments, err := s.paymentsStore.PaymentByReceipt(receipt)
+		if err != nil {
+			err := newErrInternal(err.Error())
+			log.Errorf("command(%v), error: %v", getFunctionName(), err)
+			s.metrics.AddError(PaymentsByReceiptReq, string(metrics.LowSeverity))
+			return nil, err
+		}
+
+		payments = append(payments, linkedPayments...)
+	}
+
 	var protoPayments []*Payment
 	for _, payment := range payments {
 		protoPayment, err := convertPaymentToProto(payment)
@@ -661,3 +981,349 @@ func (s *Server) ListPayments(ctx context.Context,
 
 	return resp, nil
 }
+
+//
+// CreatePayLink returns lnurl-pay link for the account, which might be
+// used by lightning network wallets to request invoices.
+func (s *Server) CreatePayLink(ctx context.Context,
+	req *CreatePayLinkRequest) (*CreatePayLinkResponse, error) {
+	log.Tracef("command(%v), request(%v)", getFunctionName(),
+		convertProtoMessage(req))
+
+	if s.lnurlServer == nil {
+		err := errors.Errorf("lnurl is disabled")
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CreatePayLinkReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	account := req.Account
+	if account == "" {
+		account = defaultAccount
+	}
+
+	link, err := s.lnurlServer.PayLink(connectors.Asset(req.Asset.String()),
+		account)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CreatePayLinkReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	resp := &CreatePayLinkResponse{
+		Lnurl: link,
+	}
+
+	log.Tracef("command(%v), response(%v)", getFunctionName(),
+		convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+//
+// CreateWithdrawLink creates one time lnurl-withdraw link, which might be
+// used by lightning network wallets to withdraw funds within the given
+// limits.
+func (s *Server) CreateWithdrawLink(ctx context.Context,
+	req *CreateWithdrawLinkRequest) (*CreateWithdrawLinkResponse, error) {
+	log.Tracef("command(%v), request(%v)", getFunctionName(),
+		convertProtoMessage(req))
+
+	if s.lnurlServer == nil {
+		err := errors.Errorf("lnurl is disabled")
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CreateWithdrawLinkReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	if req.MinAmount == "" {
+		req.MinAmount = "0"
+	}
+
+	minAmount, err := decimal.NewFromString(req.MinAmount)
+	if err != nil {
+		err := newErrInvalidArgument("min_amount")
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CreateWithdrawLinkReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	maxAmount, err := decimal.NewFromString(req.MaxAmount)
+	if err != nil {
+		err := newErrInvalidArgument("max_amount")
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CreateWithdrawLinkReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	link, expiry, err := s.lnurlServer.CreateWithdrawLink(
+		connectors.Asset(req.Asset.String()), minAmount, maxAmount,
+		req.Description)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CreateWithdrawLinkReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	resp := &CreateWithdrawLinkResponse{
+		Lnurl:  link,
+		Expiry: connectors.ConvertTimeToMilliSeconds(expiry),
+	}
+
+	log.Tracef("command(%v), response(%v)", getFunctionName(),
+		convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+//
+// LightningDepositAddress returns new address of the lightning network
+// daemon on-chain wallet, which might be used to deposit funds in it.
+func (s *Server) LightningDepositAddress(ctx context.Context,
+	req *LightningDepositAddressRequest) (*LightningDepositAddressResponse,
+	error) {
+	log.Tracef("command(%v), request(%v)", getFunctionName(),
+		convertProtoMessage(req))
+
+	c, ok := s.lightningConnectors[connectors.Asset(req.Asset.String())]
+	if !ok {
+		err := newErrAssetNotSupported(req.Asset.String(),
+			Media_LIGHTNING.String())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(LightningDepositAddressReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	address, err := c.DepositAddress()
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(LightningDepositAddressReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	resp := &LightningDepositAddressResponse{
+		Address: address,
+	}
+
+	log.Tracef("command(%v), response(%v)", getFunctionName(),
+		convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+//
+// DepositLightning moves funds from the blockchain hot wallet in the
+// lightning network daemon on-chain wallet. Payment is registered as
+// internal.
+func (s *Server) DepositLightning(ctx context.Context,
+	req *DepositLightningRequest) (*Payment, error) {
+	log.Tracef("command(%v), request(%v)", getFunctionName(),
+		convertProtoMessage(req))
+
+	bc, ok := s.blockchainConnectors[connectors.Asset(req.Asset.String())]
+	if !ok {
+		err := newErrAssetNotSupported(req.Asset.String(),
+			Media_BLOCKCHAIN.String())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(DepositLightningReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	lc, ok := s.lightningConnectors[connectors.Asset(req.Asset.String())]
+	if !ok {
+		err := newErrAssetNotSupported(req.Asset.String(),
+			Media_LIGHTNING.String())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(DepositLightningReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+		err := newErrInvalidArgument("amount")
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(DepositLightningReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	address, err := lc.DepositAddress()
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(DepositLightningReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	// Funds stay under our control, that is why payment is created as
+	// internal.
+	payment, err := connectors.SendBlockchainPayment(bc, address, req.Amount,
+		&connectors.PaymentOptions{Internal: true})
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(DepositLightningReq, string(metrics.HighSeverity))
+		return nil, err
+	}
+
+	resp, err := convertPaymentToProto(payment)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(DepositLightningReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	log.Tracef("command(%v), response(%v)", getFunctionName(),
+		convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+//
+// FinalizePayment takes externally signed BIP174 transaction of the
+// payment, which is waiting to be signed, ensures that it matches the
+// payment, and sends it in the blockchain network.
+func (s *Server) FinalizePayment(ctx context.Context,
+	req *FinalizePaymentRequest) (*Payment, error) {
+	log.Tracef("command(%v), request(%v)", getFunctionName(),
+		convertProtoMessage(req))
+
+	if req.Psbt == "" {
+		err := newErrInvalidArgument("psbt")
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(FinalizePaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	payment, err := s.paymentsStore.PaymentByID(req.PaymentId)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(FinalizePaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	bc, ok := s.blockchainConnectors[payment.Asset]
+	if !ok {
+		err := newErrAssetNotSupported(string(payment.Asset),
+			Media_BLOCKCHAIN.String())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(FinalizePaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	cc, ok := bc.(connectors.ColdSigningConnector)
+	if !ok {
+		err := newErrAssetNotSupported(string(payment.Asset),
+			Media_BLOCKCHAIN.String())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(FinalizePaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	payment, err = cc.FinalizePayment(req.PaymentId, req.Psbt)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(FinalizePaymentReq, string(metrics.HighSeverity))
+		return nil, err
+	}
+
+	resp, err := convertPaymentToProto(payment)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(FinalizePaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	log.Tracef("command(%v), response(%v)", getFunctionName(),
+		convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+//
+// CancelPayment fails the payment, which is waiting to be signed, and
+// releases the outputs reserved for it.
+func (s *Server) CancelPayment(ctx context.Context,
+	req *CancelPaymentRequest) (*Payment, error) {
+	log.Tracef("command(%v), request(%v)", getFunctionName(),
+		convertProtoMessage(req))
+
+	payment, err := s.paymentsStore.PaymentByID(req.PaymentId)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CancelPaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	bc, ok := s.blockchainConnectors[payment.Asset]
+	if !ok {
+		err := newErrAssetNotSupported(string(payment.Asset),
+			Media_BLOCKCHAIN.String())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CancelPaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	cc, ok := bc.(connectors.ColdSigningConnector)
+	if !ok {
+		err := newErrAssetNotSupported(string(payment.Asset),
+			Media_BLOCKCHAIN.String())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CancelPaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	payment, err = cc.CancelPayment(req.PaymentId)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CancelPaymentReq, string(metrics.HighSeverity))
+		return nil, err
+	}
+
+	resp, err := convertPaymentToProto(payment)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(CancelPaymentReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	log.Tracef("command(%v), response(%v)", getFunctionName(),
+		convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+//
+// SetSweepPaused pauses or resumes automatic sweeping of the hot wallet
+// funds in the cold wallet.
+func (s *Server) SetSweepPaused(ctx context.Context,
+	req *SetSweepPausedRequest) (*EmptyResponse, error) {
+	log.Tracef("command(%v), request(%v)", getFunctionName(),
+		convertProtoMessage(req))
+
+	sw, ok := s.sweepers[connectors.Asset(req.Asset.String())]
+	if !ok {
+		err := newErrInvalidArgument("asset")
+		log.Errorf("command(%v), error: %v", getFunctionName(), err)
+		s.metrics.AddError(SetSweepPausedReq, string(metrics.LowSeverity))
+		return nil, err
+	}
+
+	sw.SetPaused(req.Paused)
+
+	resp := &EmptyResponse{}
+
+	log.Tracef("command(%v), response(%v)", getFunctionName(),
+		convertProtoMessage(resp))
+
+	return resp, nil
+}
@@ -0,0 +1,190 @@
+package crpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/bitlum/connector/metrics/rpc"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+func newTestServer(bc *mock.BlockchainConnector,
+	lc *mock.LightningConnector) *Server {
+
+	return &Server{
+		net: "simnet",
+		blockchainConnectors: map[connectors.Asset]connectors.BlockchainConnector{
+			connectors.BTC: bc,
+		},
+		lightningConnectors: map[connectors.Asset]connectors.LightningConnector{
+			connectors.BTC: lc,
+		},
+		paymentsStore: bc.Store,
+		receiptsStore: mock.NewReceiptsStore(),
+		metrics:       &rpc.EmptyBackend{},
+	}
+}
+
+func TestDepositLightning(t *testing.T) {
+	store := mock.NewPaymentsStore()
+	bc := &mock.BlockchainConnector{Store: store}
+	lc := &mock.LightningConnector{Address: "lnd-address"}
+	s := newTestServer(bc, lc)
+
+	resp, err := s.DepositLightning(context.Background(),
+		&DepositLightningRequest{
+			Asset:  Asset_BTC,
+			Amount: "0.1",
+		})
+	if err != nil {
+		t.Fatalf("unable to deposit lightning: %v", err)
+	}
+
+	if resp.Receipt != "lnd-address" || resp.Direction != PaymentDirection_INTERNAL {
+		t.Fatalf("wrong deposit payment: %v", resp)
+	}
+
+	payment, err := store.PaymentByID(resp.PaymentId)
+	if err != nil {
+		t.Fatalf("deposit payment isn't saved: %v", err)
+	}
+
+	if payment.Direction != connectors.Internal ||
+		payment.Status != connectors.Pending || len(bc.Sent) != 1 {
+		t.Fatalf("deposit payment isn't sent as internal one")
+	}
+
+	if _, err := s.DepositLightning(context.Background(),
+		&DepositLightningRequest{
+			Asset:  Asset_BTC,
+			Amount: "0",
+		}); err == nil {
+		t.Fatalf("deposit with zero amount isn't rejected")
+	}
+}
+
+func TestSendAutoPayment(t *testing.T) {
+	fallbackAddr, err := btcutil.NewAddressPubKeyHash(make([]byte, 20),
+		&chaincfg.RegressionNetParams)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	const invoice = "lnsb10u1fallback"
+
+	tests := []struct {
+		name        string
+		fallback    btcutil.Address
+		sendPayment *connectors.Payment
+		sendErr     error
+		media       connectors.PaymentMedia
+		status      connectors.PaymentStatus
+		failed      bool
+	}{
+		{
+			name:     "routing failed",
+			fallback: fallbackAddr,
+			sendErr:  &connectors.ErrRoutingFailed{Reason: "no_route"},
+			media:    connectors.Blockchain,
+			status:   connectors.Pending,
+		},
+		{
+			name:     "fee limit exceeded",
+			fallback: fallbackAddr,
+			sendErr:  &connectors.ErrFeeLimitExceeded{Reason: "fee"},
+			media:    connectors.Blockchain,
+			status:   connectors.Pending,
+		},
+		{
+			name:    "routing failed without fallback",
+			sendErr: &connectors.ErrRoutingFailed{Reason: "no_route"},
+			failed:  true,
+		},
+		{
+			name:     "payment might be sent",
+			fallback: fallbackAddr,
+			sendErr:  errors.New("invoice is already paid"),
+			failed:   true,
+		},
+		{
+			name:     "payment in flight",
+			fallback: fallbackAddr,
+			sendPayment: &connectors.Payment{
+				PaymentID: "lightning",
+				Status:    connectors.Pending,
+				Media:     connectors.Lightning,
+			},
+			media:  connectors.Lightning,
+			status: connectors.Pending,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bc := &mock.BlockchainConnector{Store: mock.NewPaymentsStore()}
+			lc := &mock.LightningConnector{
+				Invoice: &zpay32.Invoice{
+					FallbackAddr: test.fallback,
+				},
+				SendPayment: test.sendPayment,
+				SendErr:     test.sendErr,
+			}
+			s := newTestServer(bc, lc)
+
+			payment, attempts, err := s.sendAutoPayment(&SendPaymentRequest{
+				Asset:   Asset_BTC,
+				Media:   Media_AUTO,
+				Amount:  "0.00001",
+				Receipt: invoice,
+			})
+
+			if lc.Sent != 1 {
+				t.Fatalf("lightning payment isn't sent")
+			}
+
+			if test.failed {
+				if err == nil {
+					t.Fatalf("payment error isn't returned")
+				}
+
+				if len(bc.Sent) != 0 {
+					t.Fatalf("payment is sent on-chain")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unable to send payment: %v", err)
+			}
+
+			if payment.Media != test.media || payment.Status != test.status {
+				t.Fatalf("wrong payment: %v", payment)
+			}
+
+			if test.media == connectors.Lightning {
+				if len(bc.Sent) != 0 || len(attempts) != 1 {
+					t.Fatalf("payment is sent on-chain")
+				}
+				return
+			}
+
+			if len(bc.Sent) != 1 || len(attempts) != 2 {
+				t.Fatalf("payment isn't sent on-chain")
+			}
+
+			if payment.Receipt != fallbackAddr.EncodeAddress() {
+				t.Fatalf("payment isn't sent to the fallback address")
+			}
+
+			links := s.receiptsStore.(*mock.ReceiptsStore).Links[invoice]
+			if len(links) != 1 || links[0] != fallbackAddr.EncodeAddress() {
+				t.Fatalf("fallback address isn't linked to the invoice")
+			}
+		})
+	}
+}
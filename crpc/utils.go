@@ -10,6 +10,11 @@ import (
 	"github.com/shopspring/decimal"
 	"math/big"
 	"github.com/btcsuite/btcutil"
+	"encoding/hex"
+	"github.com/bitlum/connector/lnurl"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"strings"
+	"time"
 )
 
 var satoshiPerBitcoin = decimal.New(btcutil.SatoshiPerBitcoin, 0)
@@ -35,6 +40,93 @@ func sat2DecAmount(amount btcutil.Amount) decimal.Decimal {
 	return amt.Div(satoshiPerBitcoin)
 }
 
+// isNodePubKey returns true if receipt looks like hex encoded compressed
+// public key of the lightning network node, rather than lightning network
+// invoice. Strict validation of the key is made by the connector itself.
+func isNodePubKey(receipt string) bool {
+	if len(receipt) != 66 {
+		return false
+	}
+
+	_, err := hex.DecodeString(receipt)
+	return err == nil
+}
+
+// isLightningInvoice returns true if receipt looks like lightning network
+// invoice rather than blockchain address. Strict validation of the invoice
+// is made by the connector itself.
+func isLightningInvoice(receipt string) bool {
+	// Bech32 strings can't be of mixed case, which also helps to
+	// distinguish invoice from the base58 encoded address.
+	lower := strings.ToLower(receipt)
+	if receipt != lower && receipt != strings.ToUpper(receipt) {
+		return false
+	}
+
+	return strings.HasPrefix(lower, "ln") && strings.Contains(lower, "1")
+}
+
+// parseSendOptions validates and converts lightning network payment options
+// of the send request.
+func parseSendOptions(req *SendPaymentRequest) (*connectors.SendOptions,
+	error) {
+
+	opts := &connectors.SendOptions{
+		MaxFeePercent: req.MaxFeePercent,
+		Timeout:       time.Duration(req.Timeout) * time.Second,
+	}
+
+	if req.MaxFee != "" {
+		if req.MaxFeePercent != 0 {
+			return nil, newErrInvalidArgument("max_fee_percent")
+		}
+
+		var err error
+		opts.MaxFee, err = decimal.NewFromString(req.MaxFee)
+		if err != nil || opts.MaxFee.LessThanOrEqual(decimal.Zero) {
+			return nil, newErrInvalidArgument("max_fee")
+		}
+	}
+
+	if req.MaxFeePercent < 0 {
+		return nil, newErrInvalidArgument("max_fee_percent")
+	}
+
+	if req.Timeout < 0 {
+		return nil, newErrInvalidArgument("timeout")
+	}
+
+	return opts, nil
+}
+
+// fetchLNURLInvoice requests lightning network invoice with the given amount
+// from the lnurl-pay service, invoice is decoded with the given connector.
+func fetchLNURLInvoice(c connectors.LightningConnector, receipt,
+	amount string) (string, error) {
+
+	amt, err := decimal.NewFromString(amount)
+	if err != nil {
+		return "", errors.Errorf("unable to parse amount(%v): %v",
+			amount, err)
+	}
+
+	if amt.LessThanOrEqual(decimal.Zero) {
+		return "", errors.Errorf("amount should be specified for lnurl " +
+			"payments")
+	}
+
+	params, err := lnurl.FetchPayParams(receipt)
+	if err != nil {
+		return "", err
+	}
+
+	decode := func(invoice string) (*zpay32.Invoice, error) {
+		return c.ValidateInvoice(invoice, "0")
+	}
+
+	return lnurl.FetchInvoice(params, lnurl.BtcToMsat(amt), decode)
+}
+
 func convertProtoMessage(resp proto.Message) string {
 	jsonMarshaler := &jsonpb.Marshaler{
 		EmitDefaults: true,
@@ -87,6 +179,10 @@ func convertAssetToProto(asset connectors.Asset) (Asset, error) {
 		protoAsset = Asset_LTC
 	case connectors.DASH:
 		protoAsset = Asset_DASH
+	case connectors.DOGE:
+		protoAsset = Asset_DOGE
+	case connectors.ZEC:
+		protoAsset = Asset_ZEC
 	default:
 		return protoAsset, errors.Errorf("unable convert unknown asset: %v",
 			asset)
@@ -149,7 +245,7 @@ func convertPaymentToProto(payment *connectors.Payment) (*Payment, error) {
 		return nil, err
 	}
 
-	return &Payment{
+	protoPayment := &Payment{
 		PaymentId: payment.PaymentID,
 		UpdatedAt: payment.UpdatedAt,
 		Status:    status,
@@ -160,7 +256,29 @@ func convertPaymentToProto(payment *connectors.Payment) (*Payment, error) {
 		Amount:    payment.Amount.String(),
 		MediaFee:  payment.MediaFee.String(),
 		MediaId:   payment.MediaID,
-	}, nil
+		External:  payment.External,
+	}
+
+	if details, ok := payment.Detail.(*connectors.BlockchainFailedDetails); ok {
+		protoPayment.FailureReason = details.Reason
+		protoPayment.ReplacementPaymentIds = details.ReplacementPaymentIDs
+	}
+
+	if details, ok := payment.Detail.(*connectors.UnsignedTxDetails); ok {
+		protoPayment.Psbt = details.PSBT
+	}
+
+	if details, ok := payment.Detail.(*connectors.BlockchainPendingDetails); ok {
+		protoPayment.Confirmations = details.Confirmations
+		protoPayment.ConfirmationsLeft = details.ConfirmationsLeft
+		protoPayment.BlockHash = details.BlockHash
+	}
+
+	if details, ok := payment.Detail.(*connectors.BlockchainConfirmedDetails); ok {
+		protoPayment.BlockHash = details.BlockHash
+	}
+
+	return protoPayment, nil
 }
 
 func ConvertPaymentStatusFromProto(protoStatus PaymentStatus) (
@@ -196,6 +314,10 @@ func ConvertAssetFromProto(protoAsset Asset) (connectors.Asset, error) {
 		asset = connectors.LTC
 	case Asset_DASH:
 		asset = connectors.DASH
+	case Asset_DOGE:
+		asset = connectors.DOGE
+	case Asset_ZEC:
+		asset = connectors.ZEC
 	default:
 		return asset, errors.Errorf("unable convert unknown asset: %v",
 			protoAsset)
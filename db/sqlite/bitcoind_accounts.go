@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind"
+	"github.com/jinzhu/gorm"
+)
+
+// BitcoindDerivedAddress is the deposit address derived from the extended
+// public key.
+type BitcoindDerivedAddress struct {
+	CreatedAt time.Time
+
+	Address         string `gorm:"primary_key"`
+	Asset           string
+	XPub            string
+	DerivationIndex uint32
+	Account         string
+}
+
+// BitcoindAddress is the deposit address assigned to the account.
+type BitcoindAddress struct {
+	CreatedAt time.Time
+
+	Address string `gorm:"primary_key"`
+	Asset   string `gorm:"primary_key"`
+	Account string
+}
+
+// BitcoindAccountsStorage is used to keep track of connections between
+// addresses and accounts, because of the reason of accounts API being
+// removed from the bitcoind wallet.
+type BitcoindAccountsStorage struct {
+	db    *DB
+	asset connectors.Asset
+}
+
+func NewBitcoindAccountsStorage(asset connectors.Asset,
+	db *DB) *BitcoindAccountsStorage {
+	return &BitcoindAccountsStorage{
+		db:    db,
+		asset: asset,
+	}
+}
+
+// Runtime check to ensure that BitcoindAccountsStorage implements
+// bitcoind.AccountsStorage interface.
+var _ bitcoind.AccountsStorage = (*BitcoindAccountsStorage)(nil)
+
+// GetAccountByAddress returns account by given address, empty string is
+// returned if address isn't assigned to any account.
+//
+// NOTE: Part of the bitcoind.AccountsStorage interface.
+func (s *BitcoindAccountsStorage) GetAccountByAddress(address string) (
+	string, error) {
+
+	bitcoindAddress := &BitcoindAddress{}
+	err := s.db.Where("asset = ? AND address = ?", string(s.asset), address).
+		First(bitcoindAddress).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return bitcoindAddress.Account, nil
+}
+
+// GetLastAccountAddress returns last address which were assigned to
+// account.
+//
+// NOTE: Part of the bitcoind.AccountsStorage interface.
+func (s *BitcoindAccountsStorage) GetLastAccountAddress(account string) (
+	string, error) {
+
+	bitcoindAddress := &BitcoindAddress{}
+	err := s.db.Where("asset = ? AND account = ?", string(s.asset), account).
+		Order("created_at desc").First(bitcoindAddress).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return bitcoindAddress.Address, nil
+}
+
+// AddAddressToAccount assigns new address to account.
+//
+// NOTE: Part of the bitcoind.AccountsStorage interface.
+func (s *BitcoindAccountsStorage) AddAddressToAccount(address,
+	account string) error {
+
+	return s.db.Create(&BitcoindAddress{
+		Address: address,
+		Asset:   string(s.asset),
+		Account: account,
+	}).Error
+}
+
+// BitcoindHDAccountsStorage is used to keep track of the addresses derived
+// from the extended public key, and accounts they are assigned to.
+type BitcoindHDAccountsStorage struct {
+	db    *DB
+	asset connectors.Asset
+}
+
+func NewBitcoindHDAccountsStorage(asset connectors.Asset,
+	db *DB) *BitcoindHDAccountsStorage {
+	return &BitcoindHDAccountsStorage{
+		db:    db,
+		asset: asset,
+	}
+}
+
+// Runtime check to ensure that BitcoindHDAccountsStorage implements
+// bitcoind.HDAccountsStorage interface.
+var _ bitcoind.HDAccountsStorage = (*BitcoindHDAccountsStorage)(nil)
+
+// NextDerivationIndex returns the index of the next not used address
+// derived from the given extended public key.
+//
+// NOTE: Part of the bitcoind.HDAccountsStorage interface.
+func (s *BitcoindHDAccountsStorage) NextDerivationIndex(xpub string) (uint32,
+	error) {
+
+	address := &BitcoindDerivedAddress{}
+	err := s.db.Where("asset = ? AND x_pub = ?", string(s.asset), xpub).
+		Order("derivation_index desc").First(address).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return address.DerivationIndex + 1, nil
+}
+
+// AddDerivedAddress assigns address derived from the extended public key
+// with the given index to the account.
+//
+// NOTE: Part of the bitcoind.HDAccountsStorage interface.
+func (s *BitcoindHDAccountsStorage) AddDerivedAddress(xpub string,
+	index uint32, address, account string) error {
+
+	return s.db.Save(&BitcoindDerivedAddress{
+		Address:         address,
+		Asset:           string(s.asset),
+		XPub:            xpub,
+		DerivationIndex: index,
+		Account:         account,
+	}).Error
+}
+
+// DerivedAddressAccount returns account of the address derived from the
+// extended public key, empty string is returned if address hasn't been
+// derived.
+//
+// NOTE: Part of the bitcoind.HDAccountsStorage interface.
+func (s *BitcoindHDAccountsStorage) DerivedAddressAccount(address string) (
+	string, error) {
+
+	derivedAddress := &BitcoindDerivedAddress{}
+	err := s.db.Where("asset = ? AND address = ?", string(s.asset), address).
+		First(derivedAddress).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return derivedAddress.Account, nil
+}
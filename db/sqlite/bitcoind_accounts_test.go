@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/bitlum/connector/connectors"
+)
+
+func TestBitcoindHDAccountsStorage(t *testing.T) {
+	db, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	storage := NewBitcoindHDAccountsStorage(connectors.BTC, db)
+
+	index, err := storage.NextDerivationIndex("xpub1")
+	if err != nil {
+		t.Fatalf("unable to get index: %v", err)
+	}
+
+	if index != 0 {
+		t.Fatalf("wrong index: %v", index)
+	}
+
+	err = storage.AddDerivedAddress("xpub1", 0, "address1", "account1")
+	if err != nil {
+		t.Fatalf("unable to add address: %v", err)
+	}
+
+	err = storage.AddDerivedAddress("xpub1", 1, "address2", "account1")
+	if err != nil {
+		t.Fatalf("unable to add address: %v", err)
+	}
+
+	index, err = storage.NextDerivationIndex("xpub1")
+	if err != nil {
+		t.Fatalf("unable to get index: %v", err)
+	}
+
+	if index != 2 {
+		t.Fatalf("wrong index: %v", index)
+	}
+
+	account, err := storage.DerivedAddressAccount("address2")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "account1" {
+		t.Fatalf("wrong account: %v", account)
+	}
+
+	account, err = storage.DerivedAddressAccount("address3")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "" {
+		t.Fatalf("wrong account: %v", account)
+	}
+
+	// Indexes of other extended keys and assets are tracked separately.
+	index, err = storage.NextDerivationIndex("xpub2")
+	if err != nil {
+		t.Fatalf("unable to get index: %v", err)
+	}
+
+	if index != 0 {
+		t.Fatalf("wrong index: %v", index)
+	}
+
+	ltcStorage := NewBitcoindHDAccountsStorage(connectors.LTC, db)
+	index, err = ltcStorage.NextDerivationIndex("xpub1")
+	if err != nil {
+		t.Fatalf("unable to get index: %v", err)
+	}
+
+	if index != 0 {
+		t.Fatalf("wrong index: %v", index)
+	}
+
+	account, err = ltcStorage.DerivedAddressAccount("address1")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "" {
+		t.Fatalf("wrong account: %v", account)
+	}
+}
+
+func TestBitcoindAccountsStorage(t *testing.T) {
+	db, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	storage := NewBitcoindAccountsStorage(connectors.BTC, db)
+
+	if err := storage.AddAddressToAccount("address1", "account1"); err != nil {
+		t.Fatalf("unable to add address: %v", err)
+	}
+
+	if err := storage.AddAddressToAccount("address2", "account1"); err != nil {
+		t.Fatalf("unable to add address: %v", err)
+	}
+
+	account, err := storage.GetAccountByAddress("address1")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "account1" {
+		t.Fatalf("wrong account: %v", account)
+	}
+
+	account, err = storage.GetAccountByAddress("address3")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "" {
+		t.Fatalf("wrong account: %v", account)
+	}
+
+	address, err := storage.GetLastAccountAddress("account1")
+	if err != nil {
+		t.Fatalf("unable to get address: %v", err)
+	}
+
+	if address != "address2" {
+		t.Fatalf("wrong address: %v", address)
+	}
+
+	address, err = storage.GetLastAccountAddress("account2")
+	if err != nil {
+		t.Fatalf("unable to get address: %v", err)
+	}
+
+	if address != "" {
+		t.Fatalf("wrong address: %v", address)
+	}
+
+	// Addresses of other assets are tracked separately.
+	ltcStorage := NewBitcoindAccountsStorage(connectors.LTC, db)
+	account, err = ltcStorage.GetAccountByAddress("address1")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "" {
+		t.Fatalf("wrong account: %v", account)
+	}
+}
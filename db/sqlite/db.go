@@ -34,6 +34,11 @@ func Open(dbPath string, dbName string) (*DB, error) {
 		&ConnectorState{},
 		&EthereumAddress{},
 		&Payment{},
+		&LightningState{},
+		&LightningInvoice{},
+		&ReceiptLink{},
+		&BitcoindDerivedAddress{},
+		&BitcoindAddress{},
 	).Error
 	if err != nil {
 		return nil, err
@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/lnd"
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+type LightningState struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Asset           string `gorm:"primary_key"`
+	ForwardingIndex uint32
+}
+
+// LightningInvoice is used to keep the account for which invoice has been
+// created, lnd doesn't allow to attach custom data to the invoice.
+type LightningInvoice struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	PaymentHash string `gorm:"primary_key"`
+	Asset       string
+	Account     string
+}
+
+// LightningStateStorage is used to keep the synchronisation state of the
+// lightning network daemon connector.
+type LightningStateStorage struct {
+	db    *DB
+	asset connectors.Asset
+}
+
+func NewLightningStateStorage(asset connectors.Asset,
+	db *DB) *LightningStateStorage {
+	return &LightningStateStorage{
+		asset: asset,
+		db:    db,
+	}
+}
+
+// Runtime check to ensure that LightningStateStorage implements
+// lnd.StateStorage interface.
+var _ lnd.StateStorage = (*LightningStateStorage)(nil)
+
+// PutForwardingIndex is used to save the index of the last processed
+// forwarding event.
+//
+// NOTE: Part of the lnd.StateStorage interface.
+func (s *LightningStateStorage) PutForwardingIndex(index uint32) error {
+	return s.db.Save(&LightningState{
+		Asset:           string(s.asset),
+		ForwardingIndex: index,
+	}).Error
+}
+
+// ForwardingIndex returns the index of the last processed forwarding
+// event.
+//
+// NOTE: Part of the lnd.StateStorage interface.
+func (s *LightningStateStorage) ForwardingIndex() (uint32, error) {
+	state := &LightningState{}
+	err := s.db.Where("asset = ?", string(s.asset)).Find(state).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return state.ForwardingIndex, nil
+}
+
+// PutInvoiceAccount is used to save the account for which invoice with the
+// given payment hash has been created.
+//
+// NOTE: Part of the lnd.StateStorage interface.
+func (s *LightningStateStorage) PutInvoiceAccount(paymentHash,
+	account string) error {
+	return s.db.Save(&LightningInvoice{
+		PaymentHash: paymentHash,
+		Asset:       string(s.asset),
+		Account:     account,
+	}).Error
+}
+
+// InvoiceAccount returns the account of the invoice with the given payment
+// hash, if invoice is unknown empty account is returned.
+//
+// NOTE: Part of the lnd.StateStorage interface.
+func (s *LightningStateStorage) InvoiceAccount(paymentHash string) (string,
+	error) {
+	invoice := &LightningInvoice{}
+	err := s.db.Where("payment_hash = ? AND asset = ?", paymentHash,
+		string(s.asset)).Find(invoice).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return invoice.Account, nil
+}
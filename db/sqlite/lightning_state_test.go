@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"github.com/bitlum/connector/connectors"
+	"testing"
+)
+
+func TestPutForwardingIndex(t *testing.T) {
+	db, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	storage := NewLightningStateStorage(connectors.BTC, db)
+
+	index, err := storage.ForwardingIndex()
+	if err != nil {
+		t.Fatalf("unable to get index: %v", err)
+	}
+
+	if index != 0 {
+		t.Fatalf("wrong initial index: %v", index)
+	}
+
+	if err := storage.PutForwardingIndex(10); err != nil {
+		t.Fatalf("unable to put index: %v", err)
+	}
+
+	if err := storage.PutForwardingIndex(20); err != nil {
+		t.Fatalf("unable to put index: %v", err)
+	}
+
+	index, err = storage.ForwardingIndex()
+	if err != nil {
+		t.Fatalf("unable to get index: %v", err)
+	}
+
+	if index != 20 {
+		t.Fatalf("wrong index: %v", index)
+	}
+}
+
+func TestInvoiceAccount(t *testing.T) {
+	db, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	storage := NewLightningStateStorage(connectors.BTC, db)
+
+	account, err := storage.InvoiceAccount("hash")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "" {
+		t.Fatalf("account of unknown invoice: %v", account)
+	}
+
+	if err := storage.PutInvoiceAccount("hash", "account"); err != nil {
+		t.Fatalf("unable to put account: %v", err)
+	}
+
+	account, err = storage.InvoiceAccount("hash")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "account" {
+		t.Fatalf("wrong account: %v", account)
+	}
+
+	// Invoices of other asset shouldn't be visible.
+	other := NewLightningStateStorage(connectors.LTC, db)
+	account, err = other.InvoiceAccount("hash")
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+
+	if account != "" {
+		t.Fatalf("account of other asset invoice: %v", account)
+	}
+}
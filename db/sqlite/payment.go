@@ -60,6 +60,9 @@ type Payment struct {
 
 	// DetailType is used to identify details type, to decode it properly.
 	DetailType int
+
+	// External denotes that payment hasn't been initiated by the connector.
+	External bool
 }
 
 // Runtime check to ensure that PaymentStore implements
@@ -178,6 +181,16 @@ func convertPaymentTo(payment *connectors.Payment) (*Payment, error) {
 			detailType = 1
 		case *connectors.BlockchainPendingDetails:
 			detailType = 2
+		case *connectors.LightningForwardDetails:
+			detailType = 3
+		case *connectors.BlockchainConfirmedDetails:
+			detailType = 4
+		case *connectors.BlockchainFailedDetails:
+			detailType = 5
+		case *connectors.UnsignedTxDetails:
+			detailType = 6
+		case *connectors.LnurlWithdrawDetails:
+			detailType = 7
 		default:
 			return nil, errors.Errorf("unknown details type: %v", payment.Detail)
 		}
@@ -197,6 +210,7 @@ func convertPaymentTo(payment *connectors.Payment) (*Payment, error) {
 		MediaID:    payment.MediaID,
 		Detail:     details,
 		DetailType: detailType,
+		External:   payment.External,
 	}
 
 	return dbPayment, nil
@@ -211,6 +225,16 @@ func convertPaymentFrom(dbPayment *Payment) (*connectors.Payment, error) {
 			detail = &connectors.GeneratedTxDetails{}
 		case 2:
 			detail = &connectors.BlockchainPendingDetails{}
+		case 3:
+			detail = &connectors.LightningForwardDetails{}
+		case 4:
+			detail = &connectors.BlockchainConfirmedDetails{}
+		case 5:
+			detail = &connectors.BlockchainFailedDetails{}
+		case 6:
+			detail = &connectors.UnsignedTxDetails{}
+		case 7:
+			detail = &connectors.LnurlWithdrawDetails{}
 		default:
 			return nil, errors.Errorf("unknown details type: %v", dbPayment.DetailType)
 		}
@@ -244,6 +268,7 @@ func convertPaymentFrom(dbPayment *Payment) (*connectors.Payment, error) {
 		MediaFee:  mediaFee,
 		MediaID:   dbPayment.MediaID,
 		Detail:    detail,
+		External:  dbPayment.External,
 	}
 
 	return payment, nil
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"time"
+	"github.com/bitlum/connector/connectors"
+)
+
+type ReceiptLink struct {
+	CreatedAt time.Time
+
+	LinkedReceipt string `gorm:"primary_key"`
+	Receipt       string `gorm:"index"`
+}
+
+// ReceiptsStore is used to keep links between receipts, for example between
+// lightning network invoice and its on-chain fallback address.
+type ReceiptsStore struct {
+	DB *DB
+}
+
+// Runtime check to ensure that ReceiptsStore implements
+// connectors.ReceiptsStore interface.
+var _ connectors.ReceiptsStore = (*ReceiptsStore)(nil)
+
+// LinkReceipt links the given receipt to the original one.
+//
+// NOTE: Part of the connectors.ReceiptsStore interface.
+func (s *ReceiptsStore) LinkReceipt(receipt, linkedReceipt string) error {
+	return s.DB.Save(&ReceiptLink{
+		Receipt:       receipt,
+		LinkedReceipt: linkedReceipt,
+	}).Error
+}
+
+// LinkedReceipts returns receipts linked to the given one.
+//
+// NOTE: Part of the connectors.ReceiptsStore interface.
+func (s *ReceiptsStore) LinkedReceipts(receipt string) ([]string, error) {
+	var links []*ReceiptLink
+	err := s.DB.Find(&links, "receipt = ?", receipt).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []string
+	for _, link := range links {
+		receipts = append(receipts, link.LinkedReceipt)
+	}
+
+	return receipts, nil
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReceiptsStore(t *testing.T) {
+	db, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	store := ReceiptsStore{DB: db}
+
+	if err := store.LinkReceipt("invoice", "address1"); err != nil {
+		t.Fatalf("unable to link receipt: %v", err)
+	}
+
+	if err := store.LinkReceipt("invoice", "address2"); err != nil {
+		t.Fatalf("unable to link receipt: %v", err)
+	}
+
+	receipts, err := store.LinkedReceipts("invoice")
+	if err != nil {
+		t.Fatalf("unable to get linked receipts: %v", err)
+	}
+
+	if !reflect.DeepEqual(receipts, []string{"address1", "address2"}) {
+		t.Fatalf("wrong linked receipts: %v", receipts)
+	}
+
+	receipts, err = store.LinkedReceipts("address1")
+	if err != nil {
+		t.Fatalf("unable to get linked receipts: %v", err)
+	}
+
+	if len(receipts) != 0 {
+		t.Fatalf("wrong linked receipts: %v", receipts)
+	}
+}
@@ -0,0 +1,19 @@
+package lnurl
+
+import (
+	"github.com/btcsuite/btcutil"
+	"github.com/shopspring/decimal"
+)
+
+var msatPerBitcoin = decimal.New(btcutil.SatoshiPerBitcoin*1000, 0)
+
+// BtcToMsat converts amount in bitcoins to millisatoshis, in which amounts
+// are specified in lnurl requests and responses.
+func BtcToMsat(amount decimal.Decimal) int64 {
+	return amount.Mul(msatPerBitcoin).IntPart()
+}
+
+// MsatToBtc converts amount in millisatoshis to bitcoins.
+func MsatToBtc(amount int64) decimal.Decimal {
+	return decimal.New(amount, 0).Div(msatPerBitcoin)
+}
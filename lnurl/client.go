@@ -0,0 +1,124 @@
+package lnurl
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+// httpClient is used to make requests to the remote lnurl services.
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// get makes the request to the lnurl service and decodes the response in
+// the given value, if service returned error, than it is returned.
+func get(rawURL string, v interface{}) error {
+	resp, err := httpClient.Get(rawURL)
+	if err != nil {
+		return errors.Errorf("unable make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Errorf("unable read response: %v", err)
+	}
+
+	var status Response
+	if err := json.Unmarshal(body, &status); err == nil &&
+		status.Status == statusError {
+		return errors.Errorf("lnurl service error: %v", status.Reason)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Errorf("unable decode response: %v", err)
+	}
+
+	return nil
+}
+
+// FetchPayParams decodes the lnurl and fetches the lnurl-pay parameters
+// from the remote service.
+func FetchPayParams(lnurl string) (*PayParams, error) {
+	rawURL, err := Decode(lnurl)
+	if err != nil {
+		return nil, errors.Errorf("unable decode lnurl: %v", err)
+	}
+
+	params := &PayParams{}
+	if err := get(rawURL, params); err != nil {
+		return nil, err
+	}
+
+	if params.Tag != PayTag {
+		return nil, errors.Errorf("lnurl tag(%v) isn't supported, only "+
+			"pay requests could be used as payment receipt", params.Tag)
+	}
+
+	if params.Callback == "" {
+		return nil, errors.Errorf("callback isn't specified")
+	}
+
+	return params, nil
+}
+
+// InvoiceDecoder decodes lightning network invoice, it is used to verify
+// invoices returned by lnurl-pay service.
+type InvoiceDecoder func(invoice string) (*zpay32.Invoice, error)
+
+// FetchInvoice requests lightning network invoice with the given amount from
+// the lnurl-pay service. As required by the lnurl-pay spec, invoice is
+// verified to commit to the metadata of the service and to the requested
+// amount.
+func FetchInvoice(params *PayParams, amountMsat int64,
+	decode InvoiceDecoder) (string, error) {
+
+	if amountMsat < params.MinSendable || amountMsat > params.MaxSendable {
+		return "", errors.Errorf("amount(%v msat) is out of the allowed "+
+			"range [%v, %v] msat", amountMsat, params.MinSendable,
+			params.MaxSendable)
+	}
+
+	callback, err := url.Parse(params.Callback)
+	if err != nil {
+		return "", errors.Errorf("unable parse callback: %v", err)
+	}
+
+	query := callback.Query()
+	query.Set("amount", strconv.FormatInt(amountMsat, 10))
+	callback.RawQuery = query.Encode()
+
+	invoice := &PayInvoice{}
+	if err := get(callback.String(), invoice); err != nil {
+		return "", err
+	}
+
+	if invoice.PR == "" {
+		return "", errors.Errorf("service haven't returned invoice")
+	}
+
+	decoded, err := decode(invoice.PR)
+	if err != nil {
+		return "", errors.Errorf("unable decode invoice: %v", err)
+	}
+
+	metadataHash := sha256.Sum256([]byte(params.Metadata))
+	if decoded.DescriptionHash == nil ||
+		*decoded.DescriptionHash != metadataHash {
+		return "", errors.Errorf("invoice description hash doesn't " +
+			"match metadata")
+	}
+
+	if decoded.MilliSat == nil || int64(*decoded.MilliSat) != amountMsat {
+		return "", errors.Errorf("invoice amount doesn't match requested "+
+			"amount(%v msat)", amountMsat)
+	}
+
+	return invoice.PR, nil
+}
@@ -0,0 +1,90 @@
+package lnurl
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+func TestFetchInvoice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, &PayInvoice{PR: "lnbc1"})
+		}))
+	defer server.Close()
+
+	params := &PayParams{
+		Tag:         PayTag,
+		Callback:    server.URL,
+		MinSendable: 1000,
+		MaxSendable: 100000000,
+		Metadata:    `[["text/plain","Deposit to kek account"]]`,
+	}
+
+	metadataHash := sha256.Sum256([]byte(params.Metadata))
+	wrongHash := sha256.Sum256([]byte("kek"))
+	amount := lnwire.MilliSatoshi(10000)
+	wrongAmount := lnwire.MilliSatoshi(20000)
+
+	tests := []struct {
+		name    string
+		invoice *zpay32.Invoice
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			invoice: &zpay32.Invoice{
+				MilliSat:        &amount,
+				DescriptionHash: &metadataHash,
+			},
+			wantErr: false,
+		},
+		{
+			name: "wrong description hash",
+			invoice: &zpay32.Invoice{
+				MilliSat:        &amount,
+				DescriptionHash: &wrongHash,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no description hash",
+			invoice: &zpay32.Invoice{
+				MilliSat: &amount,
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong amount",
+			invoice: &zpay32.Invoice{
+				MilliSat:        &wrongAmount,
+				DescriptionHash: &metadataHash,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no amount",
+			invoice: &zpay32.Invoice{
+				DescriptionHash: &metadataHash,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decode := func(string) (*zpay32.Invoice, error) {
+				return test.invoice, nil
+			}
+
+			_, err := FetchInvoice(params, int64(amount), decode)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("error = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
+	}
+}
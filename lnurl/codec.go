@@ -0,0 +1,168 @@
+package lnurl
+
+import (
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+const (
+	// hrp is the human readable part of the bech32 encoded lnurl.
+	hrp = "lnurl"
+
+	// lightningScheme is an optional prefix which might be used by wallets
+	// in links and qr codes.
+	lightningScheme = "lightning:"
+
+	// charset is the bech32 character set.
+	charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// generator is used to compute bech32 checksum.
+var generator = []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd,
+	0x2a1462b3}
+
+// IsLNURL returns true if given string looks like bech32 encoded lnurl.
+func IsLNURL(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, lightningScheme)
+	return strings.HasPrefix(s, hrp+"1")
+}
+
+// Encode encodes url in bech32 lnurl format. Bech32 length limit of 90
+// characters is not applied, as far as urls are usually longer.
+func Encode(rawURL string) (string, error) {
+	data, err := convertBits([]byte(rawURL), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	checksum := createChecksum(hrp, data)
+	combined := append(data, checksum...)
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteString("1")
+	for _, b := range combined {
+		sb.WriteByte(charset[b])
+	}
+
+	return strings.ToUpper(sb.String()), nil
+}
+
+// Decode decodes bech32 encoded lnurl and returns the url.
+func Decode(lnurl string) (string, error) {
+	s := strings.TrimSpace(lnurl)
+	if strings.HasPrefix(strings.ToLower(s), lightningScheme) {
+		s = s[len(lightningScheme):]
+	}
+
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", errors.Errorf("lnurl shouldn't be of mixed case")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", errors.Errorf("invalid separator position")
+	}
+
+	if s[:sep] != hrp {
+		return "", errors.Errorf("wrong human readable part(%v)", s[:sep])
+	}
+
+	data := make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		idx := strings.IndexRune(charset, c)
+		if idx == -1 {
+			return "", errors.Errorf("invalid character(%v)", string(c))
+		}
+		data = append(data, byte(idx))
+	}
+
+	if !verifyChecksum(hrp, data) {
+		return "", errors.Errorf("checksum failed")
+	}
+
+	decoded, err := convertBits(data[:len(data)-6], 5, 8, false)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decoded), nil
+}
+
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	v := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		v = append(v, hrp[i]>>5)
+	}
+	v = append(v, 0)
+	for i := 0; i < len(hrp); i++ {
+		v = append(v, hrp[i]&31)
+	}
+	return v
+}
+
+func verifyChecksum(hrp string, data []byte) bool {
+	return polymod(append(hrpExpand(hrp), data...)) == 1
+}
+
+func createChecksum(hrp string, data []byte) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := polymod(values) ^ 1
+
+	checksum := make([]byte, 6)
+	for i := 0; i < 6; i++ {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+// convertBits converts a byte slice where each byte is encoding fromBits
+// bits, to a byte slice where each byte is encoding toBits bits.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc    uint32
+		bits   uint
+		result []byte
+		maxv   = uint32(1)<<toBits - 1
+	)
+
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, errors.Errorf("invalid data range(%v)", value)
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, errors.Errorf("invalid padding")
+	}
+
+	return result, nil
+}
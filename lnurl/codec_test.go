@@ -0,0 +1,81 @@
+package lnurl
+
+import (
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		lnurl   string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "upper case",
+			lnurl: "LNURL1DP68GURN8GHJ7UM9WFMXJCM99E3K7MF0V9CXJ0M385EKVCENXC6R2C35" +
+				"XVUKXEFCV5MKVV34X5EKZD3EV56NYD3HXQURZEPEXEJXXEPNXSCRVWFNV9NXZCN9" +
+				"XQ6XYEFHVGCXXCMYXYMNSERXFQ5FNS",
+			url: "https://service.com/api?q=3fc3645b439ce8e7f2553a69e5267081d9" +
+				"6dcd340693afabe04be7b0ccd178df",
+			wantErr: false,
+		},
+		{
+			name: "lower case with scheme",
+			lnurl: "lightning:lnurl1dp68gurn8ghj7um9wfmxjcm99e3k7mf0v9cxj0m385ekvcenxc6r2c35" +
+				"xvukxefcv5mkvv34x5ekzd3ev56nyd3hxqurzepexejxxepnxscrvwfnv9nxzcn9" +
+				"xq6xyefhvgcxxcmyxymnserxfq5fns",
+			url: "https://service.com/api?q=3fc3645b439ce8e7f2553a69e5267081d9" +
+				"6dcd340693afabe04be7b0ccd178df",
+			wantErr: false,
+		},
+		{
+			name: "wrong checksum",
+			lnurl: "LNURL1DP68GURN8GHJ7UM9WFMXJCM99E3K7MF0V9CXJ0M385EKVCENXC6R2C35" +
+				"XVUKXEFCV5MKVV34X5EKZD3EV56NYD3HXQURZEPEXEJXXEPNXSCRVWFNV9NXZCN9" +
+				"XQ6XYEFHVGCXXCMYXYMNSERXFQ5FNQ",
+			wantErr: true,
+		},
+		{
+			name:    "wrong hrp",
+			lnurl:   "bc1qn6f5cd9rpxtgavsxyk7lgyvgn75mj8tcnxexy2",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			url, err := Decode(test.lnurl)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, test.wantErr)
+			}
+
+			if !test.wantErr && url != test.url {
+				t.Fatalf("wrong url: %v", url)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	url := "https://connector.bitlum.io/lnurl/withdraw/" +
+		"9f5c1c6b1aa9c9dbd4c7fa7d8e7d0a2b0a44d1d5e3b0c3e7c9f0e1a2b3c4d5e6"
+
+	lnurl, err := Encode(url)
+	if err != nil {
+		t.Fatalf("unable to encode: %v", err)
+	}
+
+	if !IsLNURL(lnurl) {
+		t.Fatalf("encoded string isn't recognized as lnurl")
+	}
+
+	decoded, err := Decode(lnurl)
+	if err != nil {
+		t.Fatalf("unable to decode: %v", err)
+	}
+
+	if decoded != url {
+		t.Fatalf("wrong url: %v", decoded)
+	}
+}
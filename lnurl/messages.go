@@ -0,0 +1,71 @@
+package lnurl
+
+import (
+	"encoding/json"
+)
+
+const (
+	// PayTag is the tag of lnurl-pay first step response.
+	PayTag = "payRequest"
+
+	// WithdrawTag is the tag of lnurl-withdraw first step response.
+	WithdrawTag = "withdrawRequest"
+
+	statusOK    = "OK"
+	statusError = "ERROR"
+)
+
+// Response is a generic lnurl response, which is returned by the service in
+// case of error or as the successful result of the withdraw callback.
+type Response struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// PayParams is the response of the lnurl-pay service on the first request.
+// All amounts are in millisatoshis.
+type PayParams struct {
+	Tag         string `json:"tag"`
+	Callback    string `json:"callback"`
+	MinSendable int64  `json:"minSendable"`
+	MaxSendable int64  `json:"maxSendable"`
+
+	// Metadata is json encoded array of the [mime type, content] pairs,
+	// which describes the payment.
+	Metadata string `json:"metadata"`
+}
+
+// Description returns plain text description of the payment from
+// metadata.
+func (p *PayParams) Description() string {
+	var metadata [][]string
+	if err := json.Unmarshal([]byte(p.Metadata), &metadata); err != nil {
+		return ""
+	}
+
+	for _, entry := range metadata {
+		if len(entry) == 2 && entry[0] == "text/plain" {
+			return entry[1]
+		}
+	}
+
+	return ""
+}
+
+// PayInvoice is the response of the lnurl-pay service on the callback
+// request.
+type PayInvoice struct {
+	PR     string   `json:"pr"`
+	Routes []string `json:"routes"`
+}
+
+// WithdrawParams is the response of the lnurl-withdraw service on the first
+// request. All amounts are in millisatoshis.
+type WithdrawParams struct {
+	Tag                string `json:"tag"`
+	Callback           string `json:"callback"`
+	K1                 string `json:"k1"`
+	MinWithdrawable    int64  `json:"minWithdrawable"`
+	MaxWithdrawable    int64  `json:"maxWithdrawable"`
+	DefaultDescription string `json:"defaultDescription"`
+}
@@ -0,0 +1,546 @@
+package lnurl
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/go-errors/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	payPath      = "/lnurl/pay/"
+	withdrawPath = "/lnurl/withdraw/"
+	callbackPath = "callback"
+)
+
+// Config is a lnurl server config.
+type Config struct {
+	// Host is the host on which lnurl http server is listening.
+	Host string
+
+	// Port is the port on which lnurl http server is listening.
+	Port string
+
+	// PublicURL is the url under which lnurl server is reachable by the
+	// lightning wallets, for example "https://connector.bitlum.io".
+	PublicURL string
+
+	// LightningConnectors is the map of lightning connectors which are used
+	// to create invoices and send payments.
+	LightningConnectors map[connectors.Asset]connectors.LightningConnector
+
+	// PaymentsStore is used to keep withdraw links, so that they are
+	// valid after restart.
+	PaymentsStore connectors.PaymentsStore
+
+	// MinSendable is the minimum amount which could be paid with lnurl-pay.
+	MinSendable decimal.Decimal
+
+	// MaxSendable is the maximum amount which could be paid with lnurl-pay.
+	MaxSendable decimal.Decimal
+
+	// MaxWithdrawable is the maximum amount for which lnurl-withdraw link
+	// might be created.
+	MaxWithdrawable decimal.Decimal
+
+	// WithdrawExpiry is the time after which withdraw link couldn't be
+	// used anymore.
+	WithdrawExpiry time.Duration
+}
+
+func (c *Config) validate() error {
+	if c.PublicURL == "" {
+		return errors.Errorf("public url should be specified")
+	}
+
+	if _, err := url.Parse(c.PublicURL); err != nil {
+		return errors.Errorf("unable parse public url: %v", err)
+	}
+
+	if c.Port == "" {
+		return errors.Errorf("port should be specified")
+	}
+
+	if c.LightningConnectors == nil {
+		return errors.Errorf("lightning connectors should be specified")
+	}
+
+	if c.PaymentsStore == nil {
+		return errors.Errorf("payments store should be specified")
+	}
+
+	if c.MaxSendable.LessThan(c.MinSendable) {
+		return errors.Errorf("max sendable is less than min sendable")
+	}
+
+	if c.WithdrawExpiry == 0 {
+		return errors.Errorf("withdraw expiry should be specified")
+	}
+
+	return nil
+}
+
+// Server is the http server which serves lnurl-pay and lnurl-withdraw
+// endpoints.
+type Server struct {
+	cfg    *Config
+	server *http.Server
+
+	// linksMtx is used to change the status of the withdraw links
+	// atomically, so that the link couldn't be used twice.
+	linksMtx sync.Mutex
+}
+
+// NewServer creates new instance of the lnurl server.
+func NewServer(cfg *Config) (*Server, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Errorf("config is invalid: %v", err)
+	}
+
+	s := &Server{
+		cfg: cfg,
+	}
+
+	handler := http.NewServeMux()
+	handler.HandleFunc(payPath, s.handlePay)
+	handler.HandleFunc(withdrawPath, s.handleWithdraw)
+
+	s.server = &http.Server{
+		Addr:    net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler: handler,
+	}
+
+	return s, nil
+}
+
+// Start starts serving lnurl http endpoints.
+func (s *Server) Start() {
+	go func() {
+		log.Infof("Starting lnurl http server on `%s`", s.server.Addr)
+
+		for {
+			switch err := s.server.ListenAndServe(); err {
+			case http.ErrServerClosed:
+				log.Infof("Lnurl http server shutdown")
+				return
+			default:
+				log.Errorf("Lnurl http server error: %v", err)
+
+				time.Sleep(5 * time.Second)
+
+				log.Infof("Trying to start lnurl http"+
+					" server on '%s' one more time", s.server.Addr)
+			}
+		}
+	}()
+}
+
+// Stop stops the lnurl http server.
+func (s *Server) Stop() error {
+	return s.server.Close()
+}
+
+// PayLink returns lnurl-pay link, which might be used by lightning wallets
+// to get invoices for the given account.
+func (s *Server) PayLink(asset connectors.Asset, account string) (string, error) {
+	if _, ok := s.cfg.LightningConnectors[asset]; !ok {
+		return "", errors.Errorf("asset(%v) isn't supported", asset)
+	}
+
+	return Encode(s.payURL(asset, account))
+}
+
+// CreateWithdrawLink creates one time lnurl-withdraw link, which might be
+// used by lightning wallet to withdraw funds within the given limits.
+func (s *Server) CreateWithdrawLink(asset connectors.Asset, minAmount,
+	maxAmount decimal.Decimal, description string) (string, time.Time, error) {
+
+	if _, ok := s.cfg.LightningConnectors[asset]; !ok {
+		return "", time.Time{}, errors.Errorf("asset(%v) isn't supported",
+			asset)
+	}
+
+	if maxAmount.LessThanOrEqual(decimal.Zero) {
+		return "", time.Time{}, errors.Errorf("max amount should be " +
+			"positive")
+	}
+
+	if minAmount.LessThan(decimal.Zero) || minAmount.GreaterThan(maxAmount) {
+		return "", time.Time{}, errors.Errorf("min amount should be " +
+			"within [0, max amount]")
+	}
+
+	if maxAmount.GreaterThan(s.cfg.MaxWithdrawable) {
+		return "", time.Time{}, errors.Errorf("max amount(%v) is greater "+
+			"than allowed(%v)", maxAmount, s.cfg.MaxWithdrawable)
+	}
+
+	var k1 [32]byte
+	if _, err := rand.Read(k1[:]); err != nil {
+		return "", time.Time{}, errors.Errorf("unable generate k1: %v", err)
+	}
+
+	expiry := time.Now().Add(s.cfg.WithdrawExpiry)
+	details := &connectors.LnurlWithdrawDetails{
+		K1:          hex.EncodeToString(k1[:]),
+		MinAmount:   minAmount,
+		MaxAmount:   maxAmount,
+		Description: description,
+		Expiry:      connectors.ConvertTimeToMilliSeconds(expiry),
+	}
+
+	lnurl, err := Encode(s.withdrawURL(details.K1))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	s.linksMtx.Lock()
+	defer s.linksMtx.Unlock()
+
+	if err := s.failExpiredLinks(); err != nil {
+		log.Errorf("unable to fail expired withdraw links: %v", err)
+	}
+
+	// Link is stored as outgoing payment, which is waiting for the wallet
+	// to provide the invoice.
+	link := &connectors.Payment{
+		PaymentID: withdrawLinkID(details.K1),
+		UpdatedAt: connectors.NowInMilliSeconds(),
+		Status:    connectors.Waiting,
+		Direction: connectors.Outgoing,
+		Receipt:   lnurl,
+		Asset:     asset,
+		Media:     connectors.Lightning,
+		Amount:    maxAmount,
+		MediaFee:  decimal.Zero,
+		Detail:    details,
+	}
+
+	if err := s.cfg.PaymentsStore.SavePayment(link); err != nil {
+		return "", time.Time{}, errors.Errorf("unable save withdraw "+
+			"link: %v", err)
+	}
+
+	return lnurl, expiry, nil
+}
+
+// withdrawLinkID returns the id of the payment, which represents the
+// withdraw link in the payments store.
+func withdrawLinkID(k1 string) string {
+	return connectors.GeneratePaymentID(k1, "lnurl-withdraw")
+}
+
+// withdrawLink returns the withdraw link payment and its details.
+//
+// NOTE: Should be called with links mutex held.
+func (s *Server) withdrawLink(k1 string) (*connectors.Payment,
+	*connectors.LnurlWithdrawDetails, error) {
+
+	link, err := s.cfg.PaymentsStore.PaymentByID(withdrawLinkID(k1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	details, ok := link.Detail.(*connectors.LnurlWithdrawDetails)
+	if !ok || details.K1 != k1 {
+		return nil, nil, errors.Errorf("payment(%v) isn't withdraw link",
+			link.PaymentID)
+	}
+
+	return link, details, nil
+}
+
+// isExpired returns true if withdraw link couldn't be used anymore.
+func isExpired(details *connectors.LnurlWithdrawDetails) bool {
+	return connectors.NowInMilliSeconds() > details.Expiry
+}
+
+// failExpiredLinks marks expired withdraw links, which haven't been used,
+// as failed.
+//
+// NOTE: Should be called with links mutex held.
+func (s *Server) failExpiredLinks() error {
+	payments, err := s.cfg.PaymentsStore.ListPayments("",
+		connectors.Waiting, connectors.Outgoing, connectors.Lightning)
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		details, ok := payment.Detail.(*connectors.LnurlWithdrawDetails)
+		if !ok || !isExpired(details) {
+			continue
+		}
+
+		payment.Status = connectors.Failed
+		payment.UpdatedAt = connectors.NowInMilliSeconds()
+		if err := s.cfg.PaymentsStore.SavePayment(payment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) payURL(asset connectors.Asset, account string) string {
+	return strings.TrimSuffix(s.cfg.PublicURL, "/") + payPath +
+		strings.ToLower(string(asset)) + "/" + url.PathEscape(account)
+}
+
+func (s *Server) withdrawURL(k1 string) string {
+	return strings.TrimSuffix(s.cfg.PublicURL, "/") + withdrawPath + k1
+}
+
+// handlePay handles lnurl-pay requests:
+// 	/lnurl/pay/{asset}/{account} - returns pay parameters.
+// 	/lnurl/pay/{asset}/{account}/callback?amount={msat} - returns invoice.
+func (s *Server) handlePay(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, payPath), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[1] == "" {
+		writeError(w, "wrong request path")
+		return
+	}
+
+	asset := connectors.Asset(strings.ToUpper(parts[0]))
+	account, err := url.PathUnescape(parts[1])
+	if err != nil {
+		writeError(w, "wrong account")
+		return
+	}
+
+	c, ok := s.cfg.LightningConnectors[asset]
+	if !ok {
+		writeError(w, fmt.Sprintf("asset(%v) isn't supported", asset))
+		return
+	}
+
+	description := fmt.Sprintf("Deposit to %v account", account)
+	metadata, _ := json.Marshal([][]string{{"text/plain", description}})
+
+	if len(parts) == 2 {
+		writeJSON(w, &PayParams{
+			Tag:         PayTag,
+			Callback:    s.payURL(asset, account) + "/" + callbackPath,
+			MinSendable: BtcToMsat(s.cfg.MinSendable),
+			MaxSendable: BtcToMsat(s.cfg.MaxSendable),
+			Metadata:    string(metadata),
+		})
+		return
+	}
+
+	if parts[2] != callbackPath {
+		writeError(w, "wrong request path")
+		return
+	}
+
+	amountMsat, err := strconv.ParseInt(r.URL.Query().Get("amount"), 10, 64)
+	if err != nil {
+		writeError(w, "wrong amount")
+		return
+	}
+
+	if amountMsat < BtcToMsat(s.cfg.MinSendable) ||
+		amountMsat > BtcToMsat(s.cfg.MaxSendable) {
+		writeError(w, "amount is out of the allowed range")
+		return
+	}
+
+	// Lightning network invoices are created with satoshi precision.
+	if amountMsat%1000 != 0 {
+		writeError(w, "amount should be whole number of satoshis")
+		return
+	}
+
+	// As required by lnurl-pay spec, invoice should contain hash of the
+	// metadata, so that wallet could ensure that it pays the right
+	// invoice.
+	invoice, _, err := c.CreateInvoice(account,
+		MsatToBtc(amountMsat).String(), string(metadata),
+		&connectors.InvoiceOptions{DescriptionHash: true})
+	if err != nil {
+		log.Errorf("unable create invoice for account(%v): %v", account, err)
+		writeError(w, "unable create invoice")
+		return
+	}
+
+	log.Infof("Created lnurl-pay invoice(%v) for account(%v)", invoice,
+		account)
+
+	writeJSON(w, &PayInvoice{
+		PR:     invoice,
+		Routes: []string{},
+	})
+}
+
+// handleWithdraw handles lnurl-withdraw requests:
+// 	/lnurl/withdraw/{k1} - returns withdraw parameters.
+// 	/lnurl/withdraw/{k1}/callback?k1={k1}&pr={invoice} - pays the invoice.
+func (s *Server) handleWithdraw(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, withdrawPath), "/")
+	if len(parts) < 1 || len(parts) > 2 || parts[0] == "" {
+		writeError(w, "wrong request path")
+		return
+	}
+
+	k1 := parts[0]
+
+	s.linksMtx.Lock()
+	link, details, err := s.withdrawLink(k1)
+	s.linksMtx.Unlock()
+
+	if err != nil || link.Status != connectors.Waiting || isExpired(details) {
+		writeError(w, "withdraw link not found or expired")
+		return
+	}
+
+	if len(parts) == 1 {
+		writeJSON(w, &WithdrawParams{
+			Tag:                WithdrawTag,
+			Callback:           s.withdrawURL(k1) + "/" + callbackPath,
+			K1:                 k1,
+			MinWithdrawable:    BtcToMsat(details.MinAmount),
+			MaxWithdrawable:    BtcToMsat(details.MaxAmount),
+			DefaultDescription: details.Description,
+		})
+		return
+	}
+
+	if parts[1] != callbackPath || r.URL.Query().Get("k1") != k1 {
+		writeError(w, "wrong request")
+		return
+	}
+
+	invoiceStr := r.URL.Query().Get("pr")
+	if invoiceStr == "" {
+		writeError(w, "invoice should be specified")
+		return
+	}
+
+	c, ok := s.cfg.LightningConnectors[link.Asset]
+	if !ok {
+		writeError(w, fmt.Sprintf("asset(%v) isn't supported", link.Asset))
+		return
+	}
+
+	invoice, err := c.ValidateInvoice(invoiceStr, "0")
+	if err != nil {
+		writeError(w, fmt.Sprintf("invalid invoice: %v", err))
+		return
+	}
+
+	if invoice.MilliSat == nil {
+		writeError(w, "invoice amount should be specified")
+		return
+	}
+
+	amount := MsatToBtc(int64(*invoice.MilliSat))
+	if amount.LessThan(details.MinAmount) ||
+		amount.GreaterThan(details.MaxAmount) {
+		writeError(w, "amount is out of the allowed range")
+		return
+	}
+
+	// Mark link as pending, so that it couldn't be used concurrently for
+	// another payment, including after restart.
+	link, err = s.updateWithdrawLink(k1, connectors.Waiting,
+		func(link *connectors.Payment, _ *connectors.LnurlWithdrawDetails) {
+			link.Status = connectors.Pending
+		})
+	if err != nil {
+		writeError(w, err.Error())
+		return
+	}
+
+	payment, err := c.SendTo(invoiceStr, amount.String(), nil)
+	if err != nil {
+		// Allow to reuse the link if payment has failed.
+		_, updateErr := s.updateWithdrawLink(k1, connectors.Pending,
+			func(link *connectors.Payment, _ *connectors.LnurlWithdrawDetails) {
+				link.Status = connectors.Waiting
+			})
+		if updateErr != nil {
+			log.Errorf("unable to release withdraw link(%v): %v",
+				link.PaymentID, updateErr)
+		}
+	} else {
+		_, updateErr := s.updateWithdrawLink(k1, connectors.Pending,
+			func(link *connectors.Payment,
+				details *connectors.LnurlWithdrawDetails) {
+
+				link.Status = connectors.Completed
+				link.Amount = payment.Amount
+				link.MediaFee = payment.MediaFee
+				link.MediaID = payment.MediaID
+				details.PaymentID = payment.PaymentID
+			})
+		if updateErr != nil {
+			log.Errorf("unable to complete withdraw link(%v): %v",
+				link.PaymentID, updateErr)
+		}
+	}
+
+	if err != nil {
+		log.Errorf("unable to pay lnurl-withdraw invoice(%v): %v",
+			invoiceStr, err)
+		writeError(w, "unable to pay invoice")
+		return
+	}
+
+	log.Infof("Paid lnurl-withdraw invoice, payment(%v)", payment.PaymentID)
+
+	writeJSON(w, &Response{Status: statusOK})
+}
+
+// updateWithdrawLink applies the update to the withdraw link, if it has the
+// given status, and saves it.
+func (s *Server) updateWithdrawLink(k1 string, status connectors.PaymentStatus,
+	update func(*connectors.Payment, *connectors.LnurlWithdrawDetails)) (
+	*connectors.Payment, error) {
+
+	s.linksMtx.Lock()
+	defer s.linksMtx.Unlock()
+
+	link, details, err := s.withdrawLink(k1)
+	if err != nil {
+		return nil, errors.Errorf("withdraw link not found")
+	}
+
+	if link.Status != status {
+		return nil, errors.Errorf("withdraw link is %v",
+			strings.ToLower(string(link.Status)))
+	}
+
+	update(link, details)
+	link.UpdatedAt = connectors.NowInMilliSeconds()
+
+	if err := s.cfg.PaymentsStore.SavePayment(link); err != nil {
+		return nil, errors.Errorf("unable save withdraw link: %v", err)
+	}
+
+	return link, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("unable to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, reason string) {
+	writeJSON(w, &Response{
+		Status: statusError,
+		Reason: reason,
+	})
+}
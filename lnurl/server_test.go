@@ -0,0 +1,146 @@
+package lnurl
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"github.com/shopspring/decimal"
+)
+
+// newTestConnector returns lightning connector, which validates any invoice
+// as the invoice with the given amount.
+func newTestConnector(amountMsat lnwire.MilliSatoshi) *mock.LightningConnector {
+	return &mock.LightningConnector{
+		Invoice: &zpay32.Invoice{MilliSat: &amountMsat},
+	}
+}
+
+func newTestServer(t *testing.T, store *mock.PaymentsStore,
+	connector *mock.LightningConnector) *Server {
+
+	s, err := NewServer(&Config{
+		Port:      "8089",
+		PublicURL: "https://connector.bitlum.io",
+		LightningConnectors: map[connectors.Asset]connectors.LightningConnector{
+			connectors.BTC: connector,
+		},
+		PaymentsStore:   store,
+		MaxWithdrawable: decimal.New(1, -2),
+		WithdrawExpiry:  time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+
+	return s
+}
+
+func requestWithdraw(s *Server, path string, v interface{}) *Response {
+	recorder := httptest.NewRecorder()
+	s.handleWithdraw(recorder, httptest.NewRequest("GET", path, nil))
+
+	body := recorder.Body.Bytes()
+
+	status := &Response{}
+	json.Unmarshal(body, status)
+	if status.Status != statusError && v != nil {
+		json.Unmarshal(body, v)
+	}
+
+	return status
+}
+
+func TestWithdrawLinkRestart(t *testing.T) {
+	store := mock.NewPaymentsStore()
+	connector := newTestConnector(100000000)
+
+	s := newTestServer(t, store, connector)
+	if _, _, err := s.CreateWithdrawLink(connectors.BTC, decimal.Zero,
+		decimal.New(1, -3), "withdrawal"); err != nil {
+		t.Fatalf("unable to create withdraw link: %v", err)
+	}
+
+	var k1 string
+	for _, payment := range store.Payments {
+		k1 = payment.Detail.(*connectors.LnurlWithdrawDetails).K1
+	}
+
+	// Link should be valid for the new instance of the server, which uses
+	// the same payments store.
+	s = newTestServer(t, store, connector)
+
+	params := &WithdrawParams{}
+	status := requestWithdraw(s, withdrawPath+k1, params)
+	if status.Status == statusError {
+		t.Fatalf("unable to get withdraw params: %v", status.Reason)
+	}
+
+	if params.K1 != k1 || params.MaxWithdrawable != 100000000 ||
+		params.DefaultDescription != "withdrawal" {
+		t.Fatalf("wrong withdraw params: %v", params)
+	}
+
+	callback := withdrawPath + k1 + "/" + callbackPath + "?k1=" + k1 +
+		"&pr=lnbc1"
+	status = requestWithdraw(s, callback, nil)
+	if status.Status != statusOK {
+		t.Fatalf("unable to withdraw: %v", status.Reason)
+	}
+
+	link, err := store.PaymentByID(withdrawLinkID(k1))
+	if err != nil {
+		t.Fatalf("withdraw link isn't stored: %v", err)
+	}
+
+	details := link.Detail.(*connectors.LnurlWithdrawDetails)
+	if link.Status != connectors.Completed || details.PaymentID != "lnbc1" {
+		t.Fatalf("withdraw link isn't completed: %v", link.Status)
+	}
+
+	// Link couldn't be used twice.
+	status = requestWithdraw(s, callback, nil)
+	if status.Status != statusError || connector.Sent != 1 {
+		t.Fatalf("withdraw link is used twice")
+	}
+}
+
+func TestWithdrawLinkExpired(t *testing.T) {
+	store := mock.NewPaymentsStore()
+	connector := newTestConnector(100000000)
+	s := newTestServer(t, store, connector)
+
+	if _, _, err := s.CreateWithdrawLink(connectors.BTC, decimal.Zero,
+		decimal.New(1, -3), "withdrawal"); err != nil {
+		t.Fatalf("unable to create withdraw link: %v", err)
+	}
+
+	var expired *connectors.Payment
+	for _, payment := range store.Payments {
+		expired = payment
+	}
+
+	details := expired.Detail.(*connectors.LnurlWithdrawDetails)
+	details.Expiry = connectors.ConvertTimeToMilliSeconds(
+		time.Now().Add(-time.Minute))
+
+	status := requestWithdraw(s, withdrawPath+details.K1, nil)
+	if status.Status != statusError {
+		t.Fatalf("expired withdraw link is used")
+	}
+
+	// Expired links are failed when new link is created.
+	if _, _, err := s.CreateWithdrawLink(connectors.BTC, decimal.Zero,
+		decimal.New(1, -3), "withdrawal"); err != nil {
+		t.Fatalf("unable to create withdraw link: %v", err)
+	}
+
+	if expired.Status != connectors.Failed {
+		t.Fatalf("expired withdraw link isn't failed: %v", expired.Status)
+	}
+}
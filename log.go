@@ -11,6 +11,8 @@ import (
 	"github.com/bitlum/connector/crpc"
 	"github.com/bitlum/connector/connectors/daemons/lnd"
 	"github.com/bitlum/connector/metrics"
+	"github.com/bitlum/connector/lnurl"
+	"github.com/bitlum/connector/sweeper"
 	"github.com/btcsuite/btclog"
 	"github.com/jrick/logrotate/rotator"
 )
@@ -53,6 +55,8 @@ var (
 	rpcLog       = backendLog.Logger("RPC")
 	lndLog       = backendLog.Logger("LND")
 	estimatorLog = backendLog.Logger("EST")
+	lnurlLog     = backendLog.Logger("LNURL")
+	sweeperLog   = backendLog.Logger("SWEEP")
 )
 
 // Initialize package-global logger variables.
@@ -60,6 +64,8 @@ func init() {
 	metrics.UseLogger(metricsLog)
 	lnd.UseLogger(lndLog)
 	crpc.UseLogger(rpcLog)
+	lnurl.UseLogger(lnurlLog)
+	sweeper.UseLogger(sweeperLog)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -69,6 +75,8 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"LND":     lndLog,
 	"RPC":     rpcLog,
 	"EST":     estimatorLog,
+	"LNURL":   lnurlLog,
+	"SWEEP":   sweeperLog,
 }
 
 // initLogRotator initializes the logging rotator to write logs to logFile and
@@ -23,6 +23,9 @@ import (
 	"github.com/bitlum/connector/connectors"
 	"github.com/bitlum/connector/db/sqlite"
 	"time"
+	"github.com/bitlum/connector/lnurl"
+	"github.com/bitlum/connector/sweeper"
+	"github.com/shopspring/decimal"
 )
 
 var (
@@ -68,6 +71,7 @@ func backendMain() error {
 	}
 
 	paymentsStore := &sqlite.PaymentsStore{DB: db}
+	receiptsStore := &sqlite.ReceiptsStore{DB: db}
 
 	// Create blockchain connectors in order to be able to listen for incoming
 	// transaction, be able to answer on the question how many
@@ -83,6 +87,15 @@ func backendMain() error {
 			LastSyncedBlockHash: loadedConfig.BitcoinCash.ForceLastHash,
 			PaymentStore:        paymentsStore,
 			StateStorage:        sqlite.NewConnectorStateStorage(connectors.BCH, db),
+			ZMQBlockAddress:     loadedConfig.BitcoinCash.ZMQBlockHost,
+			ZMQTxAddress:        loadedConfig.BitcoinCash.ZMQTxHost,
+			XPub:                loadedConfig.BitcoinCash.XPub,
+			HDStorage:           sqlite.NewBitcoindHDAccountsStorage(connectors.BCH, db),
+			AccountStorage:      sqlite.NewBitcoindAccountsStorage(connectors.BCH, db),
+			PendingExpiry:       loadedConfig.BitcoinCash.PendingExpiry,
+			RecoveryInterval:    loadedConfig.BitcoinCash.RecoveryInterval,
+			WaitingTimeout:      loadedConfig.BitcoinCash.WaitingTimeout,
+			WaitingPolicy:       connectors.WaitingPolicy(loadedConfig.BitcoinCash.WaitingPolicy),
 			// TODO(andrew.shvv) Create subsystem to return current fee per unit
 			FeePerByte: loadedConfig.BitcoinCash.FeePerUnit,
 			DaemonCfg: &bitcoind.DaemonConfig{
@@ -109,6 +122,17 @@ func backendMain() error {
 			LastSyncedBlockHash: loadedConfig.Bitcoin.ForceLastHash,
 			PaymentStore:        paymentsStore,
 			StateStorage:        sqlite.NewConnectorStateStorage(connectors.BTC, db),
+			ZMQBlockAddress:     loadedConfig.Bitcoin.ZMQBlockHost,
+			ZMQTxAddress:        loadedConfig.Bitcoin.ZMQTxHost,
+			XPub:                loadedConfig.Bitcoin.XPub,
+			ColdSigning:         loadedConfig.Bitcoin.ColdSigning,
+			HDStorage:           sqlite.NewBitcoindHDAccountsStorage(connectors.BTC, db),
+			AccountStorage:      sqlite.NewBitcoindAccountsStorage(connectors.BTC, db),
+			PendingExpiry:       loadedConfig.Bitcoin.PendingExpiry,
+			RecoveryInterval:    loadedConfig.Bitcoin.RecoveryInterval,
+			WaitingTimeout:      loadedConfig.Bitcoin.WaitingTimeout,
+			WaitingPolicy:       connectors.WaitingPolicy(loadedConfig.Bitcoin.WaitingPolicy),
+			AddressType:         bitcoind.AddressType(loadedConfig.Bitcoin.AddressType),
 			// TODO(andrew.shvv) Create subsystem to return current fee per unit
 			FeePerByte: loadedConfig.BitcoinCash.FeePerUnit,
 			DaemonCfg: &bitcoind.DaemonConfig{
@@ -135,6 +159,15 @@ func backendMain() error {
 			LastSyncedBlockHash: loadedConfig.Dash.ForceLastHash,
 			PaymentStore:        paymentsStore,
 			StateStorage:        sqlite.NewConnectorStateStorage(connectors.DASH, db),
+			ZMQBlockAddress:     loadedConfig.Dash.ZMQBlockHost,
+			ZMQTxAddress:        loadedConfig.Dash.ZMQTxHost,
+			XPub:                loadedConfig.Dash.XPub,
+			HDStorage:           sqlite.NewBitcoindHDAccountsStorage(connectors.DASH, db),
+			AccountStorage:      sqlite.NewBitcoindAccountsStorage(connectors.DASH, db),
+			PendingExpiry:       loadedConfig.Dash.PendingExpiry,
+			RecoveryInterval:    loadedConfig.Dash.RecoveryInterval,
+			WaitingTimeout:      loadedConfig.Dash.WaitingTimeout,
+			WaitingPolicy:       connectors.WaitingPolicy(loadedConfig.Dash.WaitingPolicy),
 			// TODO(andrew.shvv) Create subsystem to return current fee per unit
 			FeePerByte: loadedConfig.Dash.FeePerUnit,
 			DaemonCfg: &bitcoind.DaemonConfig{
@@ -150,6 +183,76 @@ func backendMain() error {
 		}
 	}
 
+	if !loadedConfig.Dogecoin.Disabled {
+		blockchainConnectors[connectors.DOGE], err = bitcoind.NewConnector(&bitcoind.Config{
+			Net:                 loadedConfig.Network,
+			MinConfirmations:    loadedConfig.Dogecoin.MinConfirmations,
+			SyncLoopDelay:       loadedConfig.Dogecoin.SyncDelay,
+			Asset:               connectors.DOGE,
+			Logger:              mainLog,
+			Metrics:             cryptoMetricsBackend,
+			LastSyncedBlockHash: loadedConfig.Dogecoin.ForceLastHash,
+			PaymentStore:        paymentsStore,
+			StateStorage:        sqlite.NewConnectorStateStorage(connectors.DOGE, db),
+			ZMQBlockAddress:     loadedConfig.Dogecoin.ZMQBlockHost,
+			ZMQTxAddress:        loadedConfig.Dogecoin.ZMQTxHost,
+			XPub:                loadedConfig.Dogecoin.XPub,
+			HDStorage:           sqlite.NewBitcoindHDAccountsStorage(connectors.DOGE, db),
+			AccountStorage:      sqlite.NewBitcoindAccountsStorage(connectors.DOGE, db),
+			PendingExpiry:       loadedConfig.Dogecoin.PendingExpiry,
+			RecoveryInterval:    loadedConfig.Dogecoin.RecoveryInterval,
+			WaitingTimeout:      loadedConfig.Dogecoin.WaitingTimeout,
+			WaitingPolicy:       connectors.WaitingPolicy(loadedConfig.Dogecoin.WaitingPolicy),
+			// TODO(andrew.shvv) Create subsystem to return current fee per unit
+			FeePerByte: loadedConfig.Dogecoin.FeePerUnit,
+			DaemonCfg: &bitcoind.DaemonConfig{
+				Name:       "dogecoind",
+				ServerHost: loadedConfig.Dogecoin.Host,
+				ServerPort: loadedConfig.Dogecoin.Port,
+				User:       loadedConfig.Dogecoin.User,
+				Password:   loadedConfig.Dogecoin.Password,
+			},
+		})
+		if err != nil {
+			return errors.Errorf("unable to create dogecoin connector: %v", err)
+		}
+	}
+
+	if !loadedConfig.Zcash.Disabled {
+		blockchainConnectors[connectors.ZEC], err = bitcoind.NewConnector(&bitcoind.Config{
+			Net:                 loadedConfig.Network,
+			MinConfirmations:    loadedConfig.Zcash.MinConfirmations,
+			SyncLoopDelay:       loadedConfig.Zcash.SyncDelay,
+			Asset:               connectors.ZEC,
+			Logger:              mainLog,
+			Metrics:             cryptoMetricsBackend,
+			LastSyncedBlockHash: loadedConfig.Zcash.ForceLastHash,
+			PaymentStore:        paymentsStore,
+			StateStorage:        sqlite.NewConnectorStateStorage(connectors.ZEC, db),
+			ZMQBlockAddress:     loadedConfig.Zcash.ZMQBlockHost,
+			ZMQTxAddress:        loadedConfig.Zcash.ZMQTxHost,
+			XPub:                loadedConfig.Zcash.XPub,
+			HDStorage:           sqlite.NewBitcoindHDAccountsStorage(connectors.ZEC, db),
+			AccountStorage:      sqlite.NewBitcoindAccountsStorage(connectors.ZEC, db),
+			PendingExpiry:       loadedConfig.Zcash.PendingExpiry,
+			RecoveryInterval:    loadedConfig.Zcash.RecoveryInterval,
+			WaitingTimeout:      loadedConfig.Zcash.WaitingTimeout,
+			WaitingPolicy:       connectors.WaitingPolicy(loadedConfig.Zcash.WaitingPolicy),
+			// TODO(andrew.shvv) Create subsystem to return current fee per unit
+			FeePerByte: loadedConfig.Zcash.FeePerUnit,
+			DaemonCfg: &bitcoind.DaemonConfig{
+				Name:       "zcashd",
+				ServerHost: loadedConfig.Zcash.Host,
+				ServerPort: loadedConfig.Zcash.Port,
+				User:       loadedConfig.Zcash.User,
+				Password:   loadedConfig.Zcash.Password,
+			},
+		})
+		if err != nil {
+			return errors.Errorf("unable to create zcash connector: %v", err)
+		}
+	}
+
 	if !loadedConfig.Litecoin.Disabled {
 		blockchainConnectors[connectors.LTC], err = bitcoind.NewConnector(&bitcoind.Config{
 			Net:                 loadedConfig.Network,
@@ -161,6 +264,17 @@ func backendMain() error {
 			LastSyncedBlockHash: loadedConfig.Litecoin.ForceLastHash,
 			PaymentStore:        paymentsStore,
 			StateStorage:        sqlite.NewConnectorStateStorage(connectors.LTC, db),
+			ZMQBlockAddress:     loadedConfig.Litecoin.ZMQBlockHost,
+			ZMQTxAddress:        loadedConfig.Litecoin.ZMQTxHost,
+			XPub:                loadedConfig.Litecoin.XPub,
+			ColdSigning:         loadedConfig.Litecoin.ColdSigning,
+			HDStorage:           sqlite.NewBitcoindHDAccountsStorage(connectors.LTC, db),
+			AccountStorage:      sqlite.NewBitcoindAccountsStorage(connectors.LTC, db),
+			PendingExpiry:       loadedConfig.Litecoin.PendingExpiry,
+			RecoveryInterval:    loadedConfig.Litecoin.RecoveryInterval,
+			WaitingTimeout:      loadedConfig.Litecoin.WaitingTimeout,
+			WaitingPolicy:       connectors.WaitingPolicy(loadedConfig.Litecoin.WaitingPolicy),
+			AddressType:         bitcoind.AddressType(loadedConfig.Litecoin.AddressType),
 			// TODO(andrew.shvv) Create subsystem to return current fee per unit
 			FeePerByte: loadedConfig.Litecoin.FeePerUnit,
 			DaemonCfg: &bitcoind.DaemonConfig{
@@ -188,6 +302,10 @@ func backendMain() error {
 			PaymentStorage:      paymentsStore,
 			StateStorage:        sqlite.NewConnectorStateStorage(connectors.ETH, db),
 			AccountStorage:      sqlite.NewGethAccountsStorage(db),
+			PendingExpiry:       loadedConfig.Ethereum.PendingExpiry,
+			RecoveryInterval:    loadedConfig.Ethereum.RecoveryInterval,
+			WaitingTimeout:      loadedConfig.Ethereum.WaitingTimeout,
+			WaitingPolicy:       connectors.WaitingPolicy(loadedConfig.Ethereum.WaitingPolicy),
 			DaemonCfg: &geth.DaemonConfig{
 				Name:       "geth",
 				ServerHost: loadedConfig.Ethereum.Host,
@@ -200,36 +318,61 @@ func backendMain() error {
 		}
 	}
 
-	if !loadedConfig.BitcoinLightning.Disabled {
+	lightningConfigs := map[connectors.Asset]*LndConfig{
+		connectors.BTC: loadedConfig.BitcoinLightning,
+		connectors.LTC: loadedConfig.LitecoinLightning,
+	}
+
+	for asset, lndConfig := range lightningConfigs {
+		if lndConfig.Disabled {
+			continue
+		}
+
+		var maxFee decimal.Decimal
+		if lndConfig.MaxFee != "" {
+			maxFee, err = decimal.NewFromString(lndConfig.MaxFee)
+			if err != nil {
+				return errors.Errorf("unable to parse %v lightning max "+
+					"fee: %v", asset, err)
+			}
+		}
+
 		lightningConnector, err := lnd.NewConnector(&lnd.Config{
-			PeerHost:     loadedConfig.BitcoinLightning.PeerHost,
-			PeerPort:     loadedConfig.BitcoinLightning.PeerPort,
+			PeerHost:     lndConfig.PeerHost,
+			PeerPort:     lndConfig.PeerPort,
 			Net:          loadedConfig.Network,
+			Asset:        asset,
 			Name:         "lnd",
-			Host:         loadedConfig.BitcoinLightning.Host,
-			Port:         loadedConfig.BitcoinLightning.Port,
-			TlsCertPath:  loadedConfig.BitcoinLightning.TlsCertPath,
-			MacaroonPath: loadedConfig.BitcoinLightning.MacaroonPath,
+			Host:         lndConfig.Host,
+			Port:         lndConfig.Port,
+			TlsCertPath:  lndConfig.TlsCertPath,
+			MacaroonPath: lndConfig.MacaroonPath,
 			Metrics:      cryptoMetricsBackend,
 			PaymentStore: paymentsStore,
+			StateStorage: sqlite.NewLightningStateStorage(asset, db),
+
+			MaxFee:         maxFee,
+			MaxFeePercent:  lndConfig.MaxFeePercent,
+			PaymentTimeout: lndConfig.PaymentTimeout,
 		})
 		if err != nil {
-			return errors.Errorf("unable to create lightning bitcoin "+
-				"connector: %v", err)
+			return errors.Errorf("unable to create %v lightning "+
+				"connector: %v", asset, err)
 		}
 
 		// Retry start connector until daemon will exit or connector start
 		// succeed. It is needed so that prometheus could scratch the fail
 		// start metric and send alert.
-		go func(c *lnd.Connector) {
+		go func(c *lnd.Connector, asset connectors.Asset) {
 			for {
 				if err := c.Start(); err != nil {
-					mainLog.Errorf("unable to start BTC lightning "+
-						" connector: %v", err)
+					mainLog.Errorf("unable to start %v lightning "+
+						" connector: %v", asset, err)
 
 					select {
 					case <-time.After(5 * time.Second):
-						mainLog.Infof("Retrying start BTC lightning connector")
+						mainLog.Infof("Retrying start %v lightning "+
+							"connector", asset)
 						continue
 					case <-quit:
 						return
@@ -238,16 +381,16 @@ func backendMain() error {
 
 				return
 			}
-		}(lightningConnector)
+		}(lightningConnector, asset)
 
-		defer func() {
-			if err := lightningConnector.Stop("stopped by user"); err != nil {
-				mainLog.Warn("unable to shutdown lightning bitcoin"+
-					" connector: %v", err)
+		defer func(c *lnd.Connector, asset connectors.Asset) {
+			if err := c.Stop("stopped by user"); err != nil {
+				mainLog.Warnf("unable to shutdown %v lightning"+
+					" connector: %v", asset, err)
 			}
-		}()
+		}(lightningConnector, asset)
 
-		lightningConnectors[connectors.BTC] = lightningConnector
+		lightningConnectors[asset] = lightningConnector
 	}
 
 	for asset, connector := range blockchainConnectors {
@@ -301,16 +444,91 @@ func backendMain() error {
 		}
 	}
 
+	// Initialise sweepers, which move the excess of the hot wallets funds
+	// in the cold wallets.
+	sweepConfigs := map[connectors.Asset]*sweepConfig{
+		connectors.BTC:  loadedConfig.Bitcoin.Sweep,
+		connectors.BCH:  loadedConfig.BitcoinCash.Sweep,
+		connectors.LTC:  loadedConfig.Litecoin.Sweep,
+		connectors.DASH: loadedConfig.Dash.Sweep,
+		connectors.DOGE: loadedConfig.Dogecoin.Sweep,
+		connectors.ZEC:  loadedConfig.Zcash.Sweep,
+		connectors.ETH:  loadedConfig.Ethereum.Sweep,
+	}
+
+	sweepers := make(map[connectors.Asset]*sweeper.Sweeper)
+	for asset, sweepCfg := range sweepConfigs {
+		connector, ok := blockchainConnectors[asset]
+		if !ok || sweepCfg == nil || sweepCfg.Ceiling == "" {
+			continue
+		}
+
+		s, err := newSweeper(asset, sweepCfg, connector, paymentsStore)
+		if err != nil {
+			return errors.Errorf("unable to create %v sweeper: %v", asset,
+				err)
+		}
+
+		s.Start()
+		defer s.Stop()
+
+		sweepers[asset] = s
+	}
+
 	// Initialise the metric endpoint. This endpoint is used by the metric
 	// server to collect the metric from.
 	metricsEndpointAddr := net.JoinHostPort(loadedConfig.Prometheus.Host,
 		loadedConfig.Prometheus.Port)
 	metrics.StartServer(metricsEndpointAddr)
 
+	// Initialise lnurl http server, which is used by lightning wallets to
+	// request invoices and withdraw funds.
+	var lnurlServer *lnurl.Server
+	if !loadedConfig.Lnurl.Disabled {
+		minSendable, err := decimal.NewFromString(loadedConfig.Lnurl.MinSendable)
+		if err != nil {
+			return errors.Errorf("unable to parse lnurl min sendable: %v", err)
+		}
+
+		maxSendable, err := decimal.NewFromString(loadedConfig.Lnurl.MaxSendable)
+		if err != nil {
+			return errors.Errorf("unable to parse lnurl max sendable: %v", err)
+		}
+
+		maxWithdrawable, err := decimal.NewFromString(loadedConfig.Lnurl.MaxWithdrawable)
+		if err != nil {
+			return errors.Errorf("unable to parse lnurl max withdrawable: %v",
+				err)
+		}
+
+		lnurlServer, err = lnurl.NewServer(&lnurl.Config{
+			Host:                loadedConfig.Lnurl.Host,
+			Port:                loadedConfig.Lnurl.Port,
+			PublicURL:           loadedConfig.Lnurl.PublicURL,
+			LightningConnectors: lightningConnectors,
+			PaymentsStore:       paymentsStore,
+			MinSendable:         minSendable,
+			MaxSendable:         maxSendable,
+			MaxWithdrawable:     maxWithdrawable,
+			WithdrawExpiry:      loadedConfig.Lnurl.WithdrawExpiry,
+		})
+		if err != nil {
+			return errors.Errorf("unable to create lnurl server: %v", err)
+		}
+
+		lnurlServer.Start()
+		defer func() {
+			if err := lnurlServer.Stop(); err != nil {
+				mainLog.Warnf("unable to shutdown lnurl server: %v", err)
+			}
+		}()
+	}
+
 	// Initialize RPC server to handle gRPC requests from trading bots and
 	// frontend users.
 	rpcServer, err := rpc.NewRPCServer(loadedConfig.Network, blockchainConnectors,
-		lightningConnectors, paymentsStore, rpcMetricsBackend)
+		lightningConnectors, paymentsStore, receiptsStore, rpcMetricsBackend,
+		lnurlServer, sweepers)
 	if err != nil {
 		return errors.Errorf("unable to init RPC server: %v", err)
 	}
@@ -36,8 +36,12 @@ type MetricsBackend interface {
 	OverallSent(daemon, asset string, amount float64)
 	OverallReceived(daemon, asset string, amount float64)
 	OverallFee(daemon, asset string, amount float64)
+	RoutingIncome(daemon, asset string, amount float64)
 	CurrentFunds(daemon, asset string, amount float64)
 	BlockNumber(daemon, asset string, blockNumber int64)
+	ReorgDepth(daemon, asset string, depth int)
+	BlocksBehind(daemon, asset string, blocks int64)
+	AddExternalPayment(daemon, asset string)
 
 	AddRequest(daemon, asset, request string)
 	AddError(daemon, asset, request, severity string)
@@ -45,6 +49,27 @@ type MetricsBackend interface {
 	AddRequestDuration(daemon, asset, request string, dur time.Duration)
 }
 
+// EmptyBackend is used as an empty metrics backend in order to avoid
+// reporting the metrics, for example in tests.
+type EmptyBackend struct{}
+
+func (b *EmptyBackend) OverallSent(daemon, asset string, amount float64)     {}
+func (b *EmptyBackend) OverallReceived(daemon, asset string, amount float64) {}
+func (b *EmptyBackend) OverallFee(daemon, asset string, amount float64)      {}
+func (b *EmptyBackend) RoutingIncome(daemon, asset string, amount float64)   {}
+func (b *EmptyBackend) CurrentFunds(daemon, asset string, amount float64)    {}
+func (b *EmptyBackend) BlockNumber(daemon, asset string, blockNumber int64)  {}
+func (b *EmptyBackend) ReorgDepth(daemon, asset string, depth int)           {}
+func (b *EmptyBackend) BlocksBehind(daemon, asset string, blocks int64)      {}
+func (b *EmptyBackend) AddExternalPayment(daemon, asset string)              {}
+func (b *EmptyBackend) AddRequest(daemon, asset, request string)             {}
+func (b *EmptyBackend) AddError(daemon, asset, request, severity string)     {}
+func (b *EmptyBackend) AddPanic(daemon, asset, request string)               {}
+
+func (b *EmptyBackend) AddRequestDuration(daemon, asset, request string,
+	dur time.Duration) {
+}
+
 // PrometheusBackend is the main subsystem metrics implementation. Uses
 // prometheus metrics singletons defined above.
 //
@@ -63,7 +88,11 @@ type PrometheusBackend struct {
 	overallSentFunds       *prometheus.GaugeVec
 	overallReceivedFunds   *prometheus.GaugeVec
 	overallFeeFunds        *prometheus.GaugeVec
+	routingIncomeFunds     *prometheus.GaugeVec
 	blockNumber            *prometheus.GaugeVec
+	reorgDepth             *prometheus.HistogramVec
+	blocksBehind           *prometheus.GaugeVec
+	externalPaymentsTotal  *prometheus.CounterVec
 }
 
 // CurrentFunds sets the number of funds available under control of system.
@@ -118,6 +147,20 @@ func (m PrometheusBackend) OverallFee(daemon, asset string, amount float64) {
 	).Set(amount)
 }
 
+// RoutingIncome sets the number of fee funds earned by the connector for
+// forwarding payments.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) RoutingIncome(daemon, asset string, amount float64) {
+	m.routingIncomeFunds.With(
+		prometheus.Labels{
+			assetLabel:  asset,
+			daemonLabel: daemon,
+		},
+	).Set(amount)
+}
+
 // BlockNumber sets the number of last synchronised block from daemon point
 // of view.
 //
@@ -132,6 +175,48 @@ func (m PrometheusBackend) BlockNumber(daemon, asset string, blockNumber int64)
 	).Set(float64(blockNumber))
 }
 
+// ReorgDepth observes the number of blocks which were orphaned by the chain
+// re-organisation.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) ReorgDepth(daemon, asset string, depth int) {
+	m.reorgDepth.With(
+		prometheus.Labels{
+			assetLabel:  asset,
+			daemonLabel: daemon,
+		},
+	).Observe(float64(depth))
+}
+
+// BlocksBehind sets the number of blocks which are still to be synchronised
+// to catch up with the daemon.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) BlocksBehind(daemon, asset string, blocks int64) {
+	m.blocksBehind.With(
+		prometheus.Labels{
+			assetLabel:  asset,
+			daemonLabel: daemon,
+		},
+	).Set(float64(blocks))
+}
+
+// AddExternalPayment increases counter of payments which haven't been
+// initiated by the connector.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) AddExternalPayment(daemon, asset string) {
+	m.externalPaymentsTotal.With(
+		prometheus.Labels{
+			assetLabel:  asset,
+			daemonLabel: daemon,
+		},
+	).Add(1)
+}
+
 // AddRequest increases request counter for the given request name.
 //
 // NOTE: Non-pointer receiver made by intent to avoid conflict in the system
@@ -384,6 +469,28 @@ func InitMetricsBackend(net string) (MetricsBackend, error) {
 				err.Error())
 	}
 
+	backend.routingIncomeFunds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "routing_income",
+			Help:      "Number of funds earned by service for forwarding payments",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			daemonLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.routingIncomeFunds); err != nil {
+		return backend, errors.Errorf(
+			"unable to register 'routingIncomeFunds' metric: " +
+				err.Error())
+	}
+
 	backend.blockNumber = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: metrics.Namespace,
@@ -406,5 +513,72 @@ func InitMetricsBackend(net string) (MetricsBackend, error) {
 				err.Error())
 	}
 
+	backend.reorgDepth = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "reorg_depth",
+			Help:      "Number of blocks orphaned by chain re-organisation",
+			Buckets:   []float64{1, 2, 3, 4, 6, 10, 20, 50},
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			daemonLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.reorgDepth); err != nil {
+		return backend, errors.Errorf(
+			"unable to register 'reorgDepth' metric: " +
+				err.Error())
+	}
+
+	backend.blocksBehind = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "blocks_behind",
+			Help:      "Number of blocks left to synchronise to catch up with daemon",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			daemonLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.blocksBehind); err != nil {
+		return backend, errors.Errorf(
+			"unable to register 'blocksBehind' metric: " +
+				err.Error())
+	}
+
+	backend.externalPaymentsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "external_payments_total",
+			Help:      "Total payments which haven't been initiated by connector",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			daemonLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.externalPaymentsTotal); err != nil {
+		return backend, errors.Errorf(
+			"unable to register 'externalPaymentsTotal' metric: " +
+				err.Error())
+	}
+
 	return backend, nil
 }
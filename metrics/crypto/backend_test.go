@@ -12,7 +12,12 @@ type testBackend struct {
 func (b *testBackend) OverallSent(daemon, asset string, amount float64)     {}
 func (b *testBackend) OverallReceived(daemon, asset string, amount float64) {}
 func (b *testBackend) OverallFee(daemon, asset string, amount float64)      {}
+func (b *testBackend) RoutingIncome(daemon, asset string, amount float64)   {}
 func (b *testBackend) CurrentFunds(daemon, asset string, amount float64)    {}
+func (b *testBackend) BlockNumber(daemon, asset string, blockNumber int64)  {}
+func (b *testBackend) ReorgDepth(daemon, asset string, depth int)           {}
+func (b *testBackend) BlocksBehind(daemon, asset string, blocks int64)      {}
+func (b *testBackend) AddExternalPayment(daemon, asset string)              {}
 func (b *testBackend) AddRequest(daemon, asset, request string)             {}
 func (b *testBackend) AddError(daemon, asset, request, severity string)     {}
 func (b *testBackend) AddPanic(daemon, asset, request string) {
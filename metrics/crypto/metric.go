@@ -66,6 +66,12 @@ func (m Metric) AddPanic() {
 	m.backend.AddPanic(m.daemon, m.asset, m.requestName)
 }
 
+// AddExternalPayment is used to report payment which hasn't been initiated
+// by the connector, for example withdrawal made with the daemon directly.
+func (m Metric) AddExternalPayment() {
+	m.backend.AddExternalPayment(m.daemon, m.asset)
+}
+
 // CurrentFunds set the current amount of funds available.
 func (m Metric) CurrentFunds(amount float64) {
 	m.backend.CurrentFunds(m.daemon, m.asset, amount)
@@ -86,12 +92,30 @@ func (m Metric) OverallFee(amount float64) {
 	m.backend.OverallFee(m.daemon, m.asset, amount)
 }
 
+// RoutingIncome overall number of fee earned by connector for forwarding
+// payments.
+func (m Metric) RoutingIncome(amount float64) {
+	m.backend.RoutingIncome(m.daemon, m.asset, amount)
+}
+
 // BlockNumber is used to report last synchronised block number of from
 // daemon point of view.
 func (m Metric) BlockNumber(blockNumber int64) {
 	m.backend.BlockNumber(m.daemon, m.asset, blockNumber)
 }
 
+// ReorgDepth is used to report the number of blocks orphaned by the chain
+// re-organisation.
+func (m Metric) ReorgDepth(depth int) {
+	m.backend.ReorgDepth(m.daemon, m.asset, depth)
+}
+
+// BlocksBehind is used to report the number of blocks which are still to be
+// synchronised, i.e. the progress of catching up with the daemon.
+func (m Metric) BlocksBehind(blocks int64) {
+	m.backend.BlocksBehind(m.daemon, m.asset, blocks)
+}
+
 // AddRequestDuration adds request duration metric. Supposed to be
 // called after `NewMetric` which defines `startTime`. Calculates
 // duration using `startTime` and now as end time.
@@ -0,0 +1,306 @@
+package sweeper
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/go-errors/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Config is a sweeper config.
+type Config struct {
+	// Asset is the asset which funds are swept.
+	Asset connectors.Asset
+
+	// Connector is the blockchain connector of the hot wallet which funds
+	// are swept.
+	Connector connectors.BlockchainConnector
+
+	// PaymentsStore is used to mark sweep payments as internal ones, and
+	// to track their state.
+	PaymentsStore connectors.PaymentsStore
+
+	// Address returns the cold wallet address on which funds are swept,
+	// it might return new address on every call, for example if addresses
+	// are derived from the cold wallet extended public key.
+	Address func() (string, error)
+
+	// IsColdAddress returns whether the given address is the cold wallet
+	// address, it is used to find the last sweep payment on restart.
+	IsColdAddress func(address string) (bool, error)
+
+	// Ceiling is the confirmed balance of the hot wallet, above which funds
+	// are swept.
+	Ceiling decimal.Decimal
+
+	// Target is the balance of the hot wallet which is left after sweep.
+	Target decimal.Decimal
+
+	// MinAmount is the minimum amount of the sweep, smaller excess is left
+	// in the hot wallet.
+	MinAmount decimal.Decimal
+
+	// Interval is the period with which hot wallet balance is checked.
+	Interval time.Duration
+
+	// Paused denotes that sweeper is started paused, and sweeps are made
+	// only after it has been resumed.
+	Paused bool
+}
+
+func (c *Config) validate() error {
+	if c.Connector == nil {
+		return errors.Errorf("connector should be specified")
+	}
+
+	if c.PaymentsStore == nil {
+		return errors.Errorf("payments store should be specified")
+	}
+
+	if c.Address == nil {
+		return errors.Errorf("address should be specified")
+	}
+
+	if c.IsColdAddress == nil {
+		return errors.Errorf("cold address check should be specified")
+	}
+
+	if c.Target.LessThan(decimal.Zero) {
+		return errors.Errorf("target shouldn't be negative")
+	}
+
+	if c.Ceiling.LessThanOrEqual(c.Target) {
+		return errors.Errorf("ceiling should be greater than target")
+	}
+
+	if c.MinAmount.LessThan(decimal.Zero) {
+		return errors.Errorf("min amount shouldn't be negative")
+	}
+
+	if c.Interval <= 0 {
+		return errors.Errorf("interval should be positive")
+	}
+
+	return nil
+}
+
+// Sweeper moves the excess of the hot wallet funds in the cold wallet,
+// when hot wallet confirmed balance exceeds the ceiling. Funds are swept
+// down to the target level, and sweep is recorded as internal payment.
+type Sweeper struct {
+	cfg *Config
+
+	pausedMtx sync.Mutex
+	paused    bool
+
+	// lastSweepID is the id of the last sweep payment, next sweep isn't
+	// made until this payment has been completed, so that funds which are
+	// being swept aren't counted twice.
+	lastSweepID string
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSweeper creates new instance of the sweeper.
+func NewSweeper(cfg *Config) (*Sweeper, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Errorf("config is invalid: %v", err)
+	}
+
+	s := &Sweeper{
+		cfg:    cfg,
+		paused: cfg.Paused,
+		quit:   make(chan struct{}),
+	}
+
+	// Sweep which has been made before restart might be still pending,
+	// so it should be known to not sweep the same funds twice.
+	lastSweepID, err := s.fetchLastSweepID()
+	if err != nil {
+		return nil, errors.Errorf("unable to fetch last sweep: %v", err)
+	}
+	s.lastSweepID = lastSweepID
+
+	return s, nil
+}
+
+// fetchLastSweepID returns the id of the latest internal payment on the
+// cold wallet address, empty id is returned if there were no sweeps.
+func (s *Sweeper) fetchLastSweepID() (string, error) {
+	payments, err := s.cfg.PaymentsStore.ListPayments(s.cfg.Asset, "",
+		connectors.Internal, connectors.Blockchain)
+	if err != nil {
+		return "", errors.Errorf("unable to list payments: %v", err)
+	}
+
+	var last *connectors.Payment
+	for _, payment := range payments {
+		if last != nil && payment.UpdatedAt <= last.UpdatedAt {
+			continue
+		}
+
+		// Internal payments are also made to our own wallets, for
+		// example lightning network deposits, such payments aren't
+		// sweeps.
+		isCold, err := s.cfg.IsColdAddress(payment.Receipt)
+		if err != nil {
+			return "", err
+		}
+
+		if isCold {
+			last = payment
+		}
+	}
+
+	if last == nil {
+		return "", nil
+	}
+
+	log.Infof("Last %v sweep payment(%v) is %v", s.cfg.Asset,
+		last.PaymentID, last.Status)
+
+	return last.PaymentID, nil
+}
+
+// Start starts periodic sweeping of the hot wallet.
+func (s *Sweeper) Start() {
+	log.Infof("Starting %v sweeper, ceiling(%v), target(%v), min "+
+		"amount(%v)", s.cfg.Asset, s.cfg.Ceiling, s.cfg.Target,
+		s.cfg.MinAmount)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if s.Paused() {
+					continue
+				}
+
+				if err := s.sweep(); err != nil {
+					log.Errorf("Unable to sweep %v: %v", s.cfg.Asset, err)
+				}
+
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the sweeper.
+func (s *Sweeper) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+
+	log.Infof("%v sweeper stopped", s.cfg.Asset)
+}
+
+// SetPaused pauses or resumes sweeping.
+func (s *Sweeper) SetPaused(paused bool) {
+	s.pausedMtx.Lock()
+	defer s.pausedMtx.Unlock()
+
+	s.paused = paused
+
+	log.Infof("%v sweeper paused(%v)", s.cfg.Asset, paused)
+}
+
+// Paused returns whether sweeping is paused.
+func (s *Sweeper) Paused() bool {
+	s.pausedMtx.Lock()
+	defer s.pausedMtx.Unlock()
+
+	return s.paused
+}
+
+// sweepAmount returns the amount which should be swept, in order to leave
+// target amount in the hot wallet after fee has been paid. Zero is returned
+// if balance doesn't exceed ceiling or excess is smaller than minimum sweep
+// amount.
+func sweepAmount(balance, fee, ceiling, target,
+	minAmount decimal.Decimal) decimal.Decimal {
+
+	if balance.LessThanOrEqual(ceiling) {
+		return decimal.Zero
+	}
+
+	amount := balance.Sub(target).Sub(fee)
+	if amount.LessThanOrEqual(decimal.Zero) || amount.LessThan(minAmount) {
+		return decimal.Zero
+	}
+
+	return amount
+}
+
+// sweep checks hot wallet balance and sweeps the excess in the cold wallet
+// if balance exceeds the ceiling.
+func (s *Sweeper) sweep() error {
+	if s.lastSweepID != "" {
+		payment, err := s.cfg.PaymentsStore.PaymentByID(s.lastSweepID)
+		if err != nil {
+			return errors.Errorf("unable to get last sweep payment(%v): %v",
+				s.lastSweepID, err)
+		}
+
+		if payment.Status == connectors.Waiting ||
+			payment.Status == connectors.Pending {
+			log.Debugf("Skip %v sweep, last sweep payment(%v) is %v",
+				s.cfg.Asset, payment.PaymentID, payment.Status)
+			return nil
+		}
+	}
+
+	balance, err := s.cfg.Connector.ConfirmedBalance(connectors.SentAccount)
+	if err != nil {
+		return errors.Errorf("unable to get balance: %v", err)
+	}
+
+	if balance.LessThanOrEqual(s.cfg.Ceiling) {
+		return nil
+	}
+
+	fee, err := s.cfg.Connector.EstimateFee(balance.Sub(s.cfg.Target).String())
+	if err != nil {
+		return errors.Errorf("unable to estimate fee: %v", err)
+	}
+
+	amount := sweepAmount(balance, fee, s.cfg.Ceiling, s.cfg.Target,
+		s.cfg.MinAmount)
+	if amount.IsZero() {
+		log.Debugf("Skip %v sweep, balance(%v), fee(%v), excess is less "+
+			"than min amount(%v)", s.cfg.Asset, balance, fee,
+			s.cfg.MinAmount)
+		return nil
+	}
+
+	address, err := s.cfg.Address()
+	if err != nil {
+		return errors.Errorf("unable to get cold address: %v", err)
+	}
+
+	// Funds stay under our control, that is why payment is created as
+	// internal.
+	payment, err := connectors.SendBlockchainPayment(s.cfg.Connector, address,
+		amount.String(), &connectors.PaymentOptions{Internal: true})
+	if payment != nil {
+		s.lastSweepID = payment.PaymentID
+	}
+	if err != nil {
+		return errors.Errorf("unable to send payment: %v", err)
+	}
+
+	log.Infof("Sweep %v %v from hot wallet, balance(%v), payment: %v",
+		amount, s.cfg.Asset, balance, spew.Sdump(payment))
+
+	return nil
+}
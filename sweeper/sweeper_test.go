@@ -0,0 +1,183 @@
+package sweeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/mock"
+	"github.com/shopspring/decimal"
+)
+
+func TestSweepAmount(t *testing.T) {
+	var (
+		ceiling   = decimal.New(10, 0)
+		target    = decimal.New(5, 0)
+		minAmount = decimal.New(1, 0)
+		fee       = decimal.New(1, -3)
+	)
+
+	tests := []struct {
+		name    string
+		balance decimal.Decimal
+		amount  decimal.Decimal
+	}{
+		{
+			name:    "below ceiling",
+			balance: decimal.New(9, 0),
+			amount:  decimal.Zero,
+		},
+		{
+			name:    "equal to ceiling",
+			balance: ceiling,
+			amount:  decimal.Zero,
+		},
+		{
+			name:    "above ceiling",
+			balance: decimal.New(12, 0),
+			amount:  decimal.New(6999, -3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			amount := sweepAmount(test.balance, fee, ceiling, target,
+				minAmount)
+			if !amount.Equal(test.amount) {
+				t.Fatalf("wrong amount, expected: %v, got: %v",
+					test.amount, amount)
+			}
+		})
+	}
+
+	// Excess which is smaller than min amount shouldn't be swept.
+	amount := sweepAmount(decimal.New(12, 0), fee, ceiling, target,
+		decimal.New(8, 0))
+	if !amount.IsZero() {
+		t.Fatalf("excess smaller than min amount is swept: %v", amount)
+	}
+}
+
+func newTestSweeper(connector *mock.BlockchainConnector) (*Sweeper,
+	error) {
+
+	return NewSweeper(&Config{
+		Asset:         connectors.BTC,
+		Connector:     connector,
+		PaymentsStore: connector.Store,
+		Address: func() (string, error) {
+			return "cold", nil
+		},
+		IsColdAddress: func(address string) (bool, error) {
+			return address == "cold", nil
+		},
+		Ceiling:  decimal.New(10, 0),
+		Target:   decimal.New(5, 0),
+		Interval: time.Minute,
+	})
+}
+
+func TestSweep(t *testing.T) {
+	store := mock.NewPaymentsStore()
+	connector := &mock.BlockchainConnector{
+		Balance: decimal.New(12, 0),
+		Store:   store,
+	}
+
+	s, err := newTestSweeper(connector)
+	if err != nil {
+		t.Fatalf("unable to create sweeper: %v", err)
+	}
+
+	if err := s.sweep(); err != nil {
+		t.Fatalf("unable to sweep: %v", err)
+	}
+
+	payment, err := store.PaymentByID("cold")
+	if err != nil {
+		t.Fatalf("sweep payment isn't saved: %v", err)
+	}
+
+	if payment.Direction != connectors.Internal {
+		t.Fatalf("wrong direction: %v", payment.Direction)
+	}
+
+	if !payment.Amount.Equal(decimal.New(7, 0)) {
+		t.Fatalf("wrong amount: %v", payment.Amount)
+	}
+
+	// Next sweep shouldn't be made while previous one is pending.
+	if err := s.sweep(); err != nil {
+		t.Fatalf("unable to sweep: %v", err)
+	}
+
+	if len(connector.Sent) != 1 {
+		t.Fatalf("sweep is made while previous one is pending")
+	}
+
+	payment.Status = connectors.Completed
+	if err := s.sweep(); err != nil {
+		t.Fatalf("unable to sweep: %v", err)
+	}
+
+	if len(connector.Sent) != 2 {
+		t.Fatalf("sweep isn't made after previous one is completed")
+	}
+}
+
+func TestSweepRestart(t *testing.T) {
+	store := mock.NewPaymentsStore()
+	connector := &mock.BlockchainConnector{
+		Balance: decimal.New(12, 0),
+		Store:   store,
+	}
+
+	s, err := newTestSweeper(connector)
+	if err != nil {
+		t.Fatalf("unable to create sweeper: %v", err)
+	}
+
+	if err := s.sweep(); err != nil {
+		t.Fatalf("unable to sweep: %v", err)
+	}
+
+	// Lightning network deposit is internal payment as well, but it
+	// shouldn't be taken as the last sweep.
+	store.Payments["lightning"] = &connectors.Payment{
+		PaymentID: "lightning",
+		UpdatedAt: connectors.NowInMilliSeconds() + 1000,
+		Status:    connectors.Completed,
+		Direction: connectors.Internal,
+		Asset:     connectors.BTC,
+		Media:     connectors.Blockchain,
+		Receipt:   "lnd",
+	}
+
+	// Sweeper which is created after restart shouldn't sweep while
+	// sweep made before restart is pending.
+	s, err = newTestSweeper(connector)
+	if err != nil {
+		t.Fatalf("unable to create sweeper: %v", err)
+	}
+
+	if s.lastSweepID != "cold" {
+		t.Fatalf("wrong last sweep: %v", s.lastSweepID)
+	}
+
+	if err := s.sweep(); err != nil {
+		t.Fatalf("unable to sweep: %v", err)
+	}
+
+	if len(connector.Sent) != 1 {
+		t.Fatalf("sweep is made while previous one is pending")
+	}
+
+	store.Payments["cold"].Status = connectors.Completed
+	if err := s.sweep(); err != nil {
+		t.Fatalf("unable to sweep: %v", err)
+	}
+
+	if len(connector.Sent) != 2 {
+		t.Fatalf("sweep isn't made after previous one is completed")
+	}
+}
@@ -2,6 +2,12 @@ package main
 
 import (
 	"os"
+
+	"github.com/bitlum/connector/connectors"
+	"github.com/bitlum/connector/connectors/daemons/bitcoind"
+	"github.com/bitlum/connector/sweeper"
+	"github.com/go-errors/errors"
+	"github.com/shopspring/decimal"
 )
 
 // fileExists reports whether the named file or directory exists.
@@ -14,3 +20,86 @@ func fileExists(name string) bool {
 	}
 	return true
 }
+
+// newSweeper creates the sweeper of the hot wallet funds of the given
+// connector in the cold wallet.
+func newSweeper(asset connectors.Asset, cfg *sweepConfig,
+	connector connectors.BlockchainConnector,
+	paymentsStore connectors.PaymentsStore) (*sweeper.Sweeper, error) {
+
+	ceiling, err := decimal.NewFromString(cfg.Ceiling)
+	if err != nil {
+		return nil, errors.Errorf("unable to parse ceiling: %v", err)
+	}
+
+	target := decimal.Zero
+	if cfg.Target != "" {
+		target, err = decimal.NewFromString(cfg.Target)
+		if err != nil {
+			return nil, errors.Errorf("unable to parse target: %v", err)
+		}
+	}
+
+	minAmount := decimal.Zero
+	if cfg.MinAmount != "" {
+		minAmount, err = decimal.NewFromString(cfg.MinAmount)
+		if err != nil {
+			return nil, errors.Errorf("unable to parse min amount: %v", err)
+		}
+	}
+
+	var (
+		address       func() (string, error)
+		isColdAddress func(string) (bool, error)
+	)
+	switch {
+	case cfg.XPub != "" && cfg.Address != "":
+		return nil, errors.Errorf("either address or xpub should be " +
+			"specified")
+
+	case cfg.XPub != "":
+		c, ok := connector.(*bitcoind.Connector)
+		if !ok {
+			return nil, errors.Errorf("xpub isn't supported for asset(%v)",
+				asset)
+		}
+
+		address = func() (string, error) {
+			return c.ColdAddress(cfg.XPub)
+		}
+		isColdAddress = c.IsColdAddress
+
+	case cfg.Address != "":
+		if err := connector.ValidateAddress(cfg.Address); err != nil {
+			return nil, errors.Errorf("invalid address: %v", err)
+		}
+
+		address = func() (string, error) {
+			return cfg.Address, nil
+		}
+		isColdAddress = func(a string) (bool, error) {
+			return a == cfg.Address, nil
+		}
+
+	default:
+		return nil, errors.Errorf("address or xpub should be specified")
+	}
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultSweepInterval
+	}
+
+	return sweeper.NewSweeper(&sweeper.Config{
+		Asset:         asset,
+		Connector:     connector,
+		PaymentsStore: paymentsStore,
+		Address:       address,
+		IsColdAddress: isColdAddress,
+		Ceiling:       ceiling,
+		Target:        target,
+		MinAmount:     minAmount,
+		Interval:      interval,
+		Paused:        cfg.Paused,
+	})
+}